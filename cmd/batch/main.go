@@ -0,0 +1,214 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/edosulai/pt-xyz-multifinance/internal/model"
+	"github.com/edosulai/pt-xyz-multifinance/internal/repo"
+	"github.com/edosulai/pt-xyz-multifinance/internal/usecase"
+	"github.com/edosulai/pt-xyz-multifinance/pkg/bankfile"
+	"github.com/edosulai/pt-xyz-multifinance/pkg/config"
+	"github.com/edosulai/pt-xyz-multifinance/pkg/database"
+	"github.com/edosulai/pt-xyz-multifinance/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// Batch jobs are meant to be run once a day by an external scheduler (cron, Kubernetes CronJob).
+// The disbursement jobs exchange files with the bank and run whenever treasury sends or receives one.
+const (
+	jobAccruePenalties           = "accrue-penalties"
+	jobEvaluateDelinquency       = "evaluate-delinquency"
+	jobExportDisbursements       = "export-disbursements"
+	jobImportDisbursementResults = "import-disbursement-results"
+)
+
+func main() {
+	configPath := flag.String("config", "configs/config.yaml", "path to the configuration file")
+	job := flag.String("job", "", "batch job to run: "+jobAccruePenalties+", "+jobEvaluateDelinquency+", "+
+		jobExportDisbursements+", "+jobImportDisbursementResults)
+	asOfFlag := flag.String("as-of", "", "business date to run the job for (YYYY-MM-DD), defaults to today")
+	file := flag.String("file", "", "bank file to write the disbursement batch to or read the results from")
+	flag.Parse()
+
+	// Load configuration
+	cfg, err := config.LoadConfig(*configPath)
+	if err != nil {
+		panic(fmt.Sprintf("Failed to load config: %v", err))
+	}
+
+	// Initialize logger
+	if err := logger.InitLogger(cfg.Logging.Level, cfg.Logging.Encoding, cfg.Logging.OutputPaths); err != nil {
+		panic(fmt.Sprintf("Failed to initialize logger: %v", err))
+	}
+	log := logger.GetLogger()
+	defer log.Sync()
+
+	asOf := time.Now()
+	if *asOfFlag != "" {
+		asOf, err = time.Parse("2006-01-02", *asOfFlag)
+		if err != nil {
+			log.Fatal("Invalid as-of date", zap.String("as_of", *asOfFlag), zap.Error(err))
+		}
+	}
+
+	// Initialize database
+	db, err := database.NewDB(&cfg.Database)
+	if err != nil {
+		log.Fatal("Failed to initialize database", zap.Error(err))
+	}
+	defer db.Close()
+
+	// Status changes made by batch jobs are attributed to the system actor
+	ctx := model.ContextWithActor(context.Background(), model.SystemActor)
+
+	switch *job {
+	case jobAccruePenalties:
+		runAccruePenalties(ctx, cfg, db, log, asOf)
+	case jobEvaluateDelinquency:
+		runEvaluateDelinquency(ctx, cfg, db, log, asOf)
+	case jobExportDisbursements:
+		runExportDisbursements(ctx, cfg, db, log, *file)
+	case jobImportDisbursementResults:
+		runImportDisbursementResults(ctx, cfg, db, log, *file)
+	default:
+		log.Fatal("Unknown batch job", zap.String("job", *job))
+	}
+}
+
+func runAccruePenalties(ctx context.Context, cfg *config.Config, db *database.DB, log *zap.Logger, asOf time.Time) {
+	penaltyUseCase := usecase.NewPenaltyUseCase(
+		repo.NewInstallmentRepository(db),
+		repo.NewLateChargeRepository(db),
+		usecase.PenaltyRule{
+			DailyRatePercent: cfg.Penalty.DailyRatePercent,
+			CapPercent:       cfg.Penalty.CapPercent,
+			GracePeriodDays:  cfg.Penalty.GracePeriodDays,
+		},
+	)
+
+	result, err := penaltyUseCase.AccrueLateCharges(ctx, asOf)
+	if err != nil {
+		log.Fatal("Failed to accrue late charges", zap.Error(err))
+	}
+
+	log.Info("Late charges accrued",
+		zap.Time("as_of", result.AsOf),
+		zap.Int("installments_evaluated", result.InstallmentsEvaluated),
+		zap.Int("charges_created", result.ChargesCreated),
+		zap.Stringer("total_accrued", result.TotalAccrued))
+}
+
+func runEvaluateDelinquency(ctx context.Context, cfg *config.Config, db *database.DB, log *zap.Logger, asOf time.Time) {
+	delinquencyUseCase := usecase.NewDelinquencyUseCase(
+		repo.NewLoanRepository(db),
+		repo.NewInstallmentRepository(db),
+		cfg.Delinquency.DefaultDPDThreshold,
+	)
+
+	result, err := delinquencyUseCase.EvaluateDelinquency(ctx, asOf)
+	if err != nil {
+		log.Fatal("Failed to evaluate delinquency", zap.Error(err))
+	}
+
+	log.Info("Delinquency evaluated",
+		zap.Time("as_of", result.AsOf),
+		zap.Int("loans_evaluated", result.LoansEvaluated),
+		zap.Int("loans_updated", result.LoansUpdated),
+		zap.Int("loans_defaulted", result.LoansDefaulted))
+}
+
+func newDisbursementBatchUseCase(cfg *config.Config, db *database.DB, log *zap.Logger) usecase.DisbursementBatchUseCase {
+	format, err := bankfile.ParseFormat(cfg.Disbursement.BatchFormat)
+	if err != nil {
+		log.Fatal("Invalid disbursement batch format", zap.Error(err))
+	}
+
+	disbursementRepo := repo.NewDisbursementRepository(db)
+	loanUseCase := usecase.NewLoanUseCase(
+		repo.NewLoanRepository(db),
+		repo.NewUserRepository(db),
+		repo.NewInstallmentRepository(db),
+		repo.NewPaymentRepository(db),
+		repo.NewPayoffQuoteRepository(db),
+		repo.NewCreditLimitRepository(db),
+		repo.NewLoanProductRepository(db),
+		repo.NewBankAccountRepository(db),
+		disbursementRepo,
+	)
+
+	return usecase.NewDisbursementBatchUseCase(disbursementRepo, loanUseCase, format, cfg.Disbursement.SourceAccount)
+}
+
+// runExportDisbursements writes the batch to a temporary file next to path and only renames it
+// into place once the pending instructions have been marked as sent
+func runExportDisbursements(ctx context.Context, cfg *config.Config, db *database.DB, log *zap.Logger, path string) {
+	if path == "" {
+		log.Fatal("The -file flag is required", zap.String("job", jobExportDisbursements))
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		log.Fatal("Failed to create disbursement batch file", zap.Error(err))
+	}
+
+	batch, err := newDisbursementBatchUseCase(cfg, db, log).ExportBatch(ctx, tmp)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		log.Fatal("Failed to export disbursements", zap.Error(err))
+	}
+
+	if batch.Instructions == 0 {
+		os.Remove(tmp.Name())
+		log.Info("No pending disbursements to export")
+		return
+	}
+
+	// The instructions are already marked as sent, so keep the temporary file if it cannot be renamed
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		log.Fatal("Failed to save disbursement batch file",
+			zap.String("batch_reference", batch.Reference), zap.String("temp_file", tmp.Name()), zap.Error(err))
+	}
+
+	log.Info("Disbursements exported",
+		zap.String("file", path),
+		zap.String("batch_reference", batch.Reference),
+		zap.Int("instructions", batch.Instructions),
+		zap.Stringer("total_amount", batch.TotalAmount))
+}
+
+func runImportDisbursementResults(ctx context.Context, cfg *config.Config, db *database.DB, log *zap.Logger, path string) {
+	if path == "" {
+		log.Fatal("The -file flag is required", zap.String("job", jobImportDisbursementResults))
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		log.Fatal("Failed to open disbursement result file", zap.Error(err))
+	}
+	defer f.Close()
+
+	result, err := newDisbursementBatchUseCase(cfg, db, log).ImportResults(ctx, f)
+	if err != nil {
+		log.Fatal("Failed to import disbursement results", zap.Error(err))
+	}
+
+	for _, message := range result.Errors {
+		log.Error("Disbursement result not applied", zap.String("error", message))
+	}
+
+	log.Info("Disbursement results imported",
+		zap.String("file", path),
+		zap.Int("processed", result.Processed),
+		zap.Int("succeeded", result.Succeeded),
+		zap.Int("failed", result.Failed),
+		zap.Int("skipped", result.Skipped),
+		zap.Int("errors", len(result.Errors)))
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/edosulai/pt-xyz-multifinance/pkg/database"
 	"github.com/edosulai/pt-xyz-multifinance/pkg/logger"
 	"github.com/edosulai/pt-xyz-multifinance/pkg/middleware"
+	"github.com/edosulai/pt-xyz-multifinance/pkg/storage"
 	pb "github.com/edosulai/pt-xyz-multifinance/proto/gen/go/xyz/multifinance/v1"
 	"github.com/gorilla/mux"
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
@@ -51,12 +52,74 @@ func main() {
 	wrappedDB := &database.DB{DB: db}
 	userRepo := repo.NewUserRepository(wrappedDB)
 	loanRepo := repo.NewLoanRepository(wrappedDB)
+	installmentRepo := repo.NewInstallmentRepository(wrappedDB)
+	paymentRepo := repo.NewPaymentRepository(wrappedDB)
+	restructuringRepo := repo.NewRestructuringRepository(wrappedDB)
+	payoffQuoteRepo := repo.NewPayoffQuoteRepository(wrappedDB)
+	creditLimitRepo := repo.NewCreditLimitRepository(wrappedDB)
+	loanProductRepo := repo.NewLoanProductRepository(wrappedDB)
+	transactionRepo := repo.NewTransactionRepository(wrappedDB)
+	bankAccountRepo := repo.NewBankAccountRepository(wrappedDB)
+	disbursementRepo := repo.NewDisbursementRepository(wrappedDB)
 
 	userUseCase, err := usecase.NewUserUseCase(userRepo, cfg.JWT.SecretKey, cfg.JWT.Expiration)
 	if err != nil {
 		log.Fatal("Failed to initialize user use case", zap.Error(err))
 	}
-	loanUseCase := usecase.NewLoanUseCase(loanRepo, userRepo)
+	allocationOrder, err := usecase.ParseAllocationOrder(cfg.Loan.PaymentAllocationOrder)
+	if err != nil {
+		log.Fatal("Invalid payment allocation order", zap.Error(err))
+	}
+	payoffPolicy := usecase.DefaultPayoffPolicy
+	if cfg.Loan.PayoffQuoteValidity > 0 {
+		payoffPolicy.QuoteValidity = cfg.Loan.PayoffQuoteValidity
+	}
+	applicationPolicy := usecase.DefaultApplicationPolicy
+	applicationPolicy.MaxOpenApplications = cfg.Loan.MaxOpenApplications
+	applicationPolicy.DuplicateWindow = cfg.Loan.DuplicateApplicationWindow
+	affordabilityRule := usecase.AffordabilityRule{
+		MaxDTIPercent:        cfg.Affordability.MaxDTIPercent,
+		EstimateInterestRate: cfg.Affordability.EstimateInterestRate,
+		RejectAboveMax:       cfg.Affordability.RejectAboveMax,
+	}
+	if affordabilityRule.MaxDTIPercent <= 0 {
+		affordabilityRule = usecase.DefaultAffordabilityRule
+	}
+	documentRules := usecase.DefaultDocumentRules
+	if len(cfg.Document.RequiredRules) > 0 {
+		documentRules = make([]usecase.DocumentRule, 0, len(cfg.Document.RequiredRules))
+		for _, rc := range cfg.Document.RequiredRules {
+			rule, err := usecase.ParseDocumentRule(rc.Type, rc.ProductCodes, rc.MinAmount, rc.MinMonthlyIncome)
+			if err != nil {
+				log.Fatal("Invalid required document rule", zap.Error(err))
+			}
+			documentRules = append(documentRules, rule)
+		}
+	}
+	loanUseCase := usecase.NewLoanUseCase(loanRepo, userRepo, installmentRepo, paymentRepo, payoffQuoteRepo, creditLimitRepo, loanProductRepo, bankAccountRepo, disbursementRepo,
+		usecase.WithAllocationOrder(allocationOrder),
+		usecase.WithPayoffPolicy(payoffPolicy),
+		usecase.WithAffordabilityRule(affordabilityRule),
+		usecase.WithDocumentRules(documentRules),
+		usecase.WithApplicationPolicy(applicationPolicy),
+	)
+	restructureUseCase := usecase.NewRestructureUseCase(loanRepo, installmentRepo, restructuringRepo, loanProductRepo)
+	creditLimitUseCase := usecase.NewCreditLimitUseCase(creditLimitRepo, userRepo)
+	transactionUseCase := usecase.NewTransactionUseCase(transactionRepo, creditLimitRepo, userRepo, cfg.Transaction.AnnualFlatRatePercent)
+	bankAccountUseCase := usecase.NewBankAccountUseCase(bankAccountRepo, userRepo)
+
+	documentStore, err := storage.NewLocalStore(cfg.Document.StorageDir)
+	if err != nil {
+		log.Fatal("Failed to initialize document storage", zap.Error(err))
+	}
+	uploadPolicy := usecase.DefaultDocumentUploadPolicy
+	if cfg.Document.MaxSizeBytes > 0 {
+		uploadPolicy.MaxSizeBytes = cfg.Document.MaxSizeBytes
+	}
+	if len(cfg.Document.AllowedContentTypes) > 0 {
+		uploadPolicy.AllowedContentTypes = cfg.Document.AllowedContentTypes
+	}
+	documentUseCase := usecase.NewDocumentUseCase(loanRepo, documentStore, uploadPolicy)
 
 	authInterceptor := middleware.NewAuthInterceptor(cfg.JWT.SecretKey)
 
@@ -64,10 +127,10 @@ func main() {
 	grpcShutdown := make(chan struct{})
 	httpShutdown := make(chan struct{})
 	// Start gRPC server
-	grpcServer := initGRPCServer(cfg, log, userUseCase, loanUseCase, authInterceptor, grpcShutdown)
+	grpcServer := initGRPCServer(cfg, log, userUseCase, loanUseCase, restructureUseCase, creditLimitUseCase, transactionUseCase, bankAccountUseCase, authInterceptor, grpcShutdown)
 
 	// Start HTTP server with gRPC-Gateway
-	httpServer := initHTTPServer(cfg, log, userUseCase, httpShutdown)
+	httpServer := initHTTPServer(cfg, log, userUseCase, documentUseCase, authInterceptor, httpShutdown)
 
 	// Wait for shutdown signal
 	quit := make(chan os.Signal, 1)
@@ -93,17 +156,25 @@ func main() {
 	log.Info("Servers exited properly")
 }
 
-func initGRPCServer(cfg *config.Config, log *zap.Logger, userUseCase usecase.UserUseCase, loanUseCase usecase.LoanUseCase, authInterceptor *middleware.AuthInterceptor, shutdown chan struct{}) *grpc.Server {
+func initGRPCServer(cfg *config.Config, log *zap.Logger, userUseCase usecase.UserUseCase, loanUseCase usecase.LoanUseCase, restructureUseCase usecase.RestructureUseCase, creditLimitUseCase usecase.CreditLimitUseCase, transactionUseCase usecase.TransactionUseCase, bankAccountUseCase usecase.BankAccountUseCase, authInterceptor *middleware.AuthInterceptor, shutdown chan struct{}) *grpc.Server {
 	// Initialize gRPC server with middleware
 	grpcServer := grpc.NewServer(
 		grpc.UnaryInterceptor(authInterceptor.UnaryServerInterceptor()),
 	)
 	// Register services
 	userHandler := handler.NewUserHandler(userUseCase, log)
-	loanHandler := handler.NewLoanHandler(loanUseCase, log)
+	loanHandler := handler.NewLoanHandler(loanUseCase, restructureUseCase, log)
+	creditLimitHandler := handler.NewCreditLimitHandler(creditLimitUseCase, log)
+	transactionHandler := handler.NewTransactionHandler(transactionUseCase, log)
+	bankAccountHandler := handler.NewBankAccountHandler(bankAccountUseCase, log)
+	adminLoanHandler := handler.NewAdminLoanHandler(loanUseCase, restructureUseCase, log)
 
 	pb.RegisterUserServiceServer(grpcServer, userHandler)
 	pb.RegisterLoanServiceServer(grpcServer, loanHandler)
+	pb.RegisterCreditLimitServiceServer(grpcServer, creditLimitHandler)
+	pb.RegisterTransactionServiceServer(grpcServer, transactionHandler)
+	pb.RegisterBankAccountServiceServer(grpcServer, bankAccountHandler)
+	pb.RegisterAdminLoanServiceServer(grpcServer, adminLoanHandler)
 	reflection.Register(grpcServer)
 
 	// Start gRPC server
@@ -124,7 +195,7 @@ func initGRPCServer(cfg *config.Config, log *zap.Logger, userUseCase usecase.Use
 	return grpcServer
 }
 
-func initHTTPServer(cfg *config.Config, log *zap.Logger, userUseCase usecase.UserUseCase, shutdown chan struct{}) *http.Server {
+func initHTTPServer(cfg *config.Config, log *zap.Logger, userUseCase usecase.UserUseCase, documentUseCase usecase.DocumentUseCase, authInterceptor *middleware.AuthInterceptor, shutdown chan struct{}) *http.Server {
 	// Initialize gRPC-Gateway
 	ctx := context.Background()
 	gwmux := runtime.NewServeMux()
@@ -146,6 +217,46 @@ func initHTTPServer(cfg *config.Config, log *zap.Logger, userUseCase usecase.Use
 		opts,
 	); err != nil {
 		log.Fatal("Failed to register loan service handler", zap.Error(err))
+	}
+
+	// Register credit limit service handler
+	if err := pb.RegisterCreditLimitServiceHandlerFromEndpoint(
+		ctx,
+		gwmux,
+		fmt.Sprintf("localhost:%d", cfg.Server.GRPCPort),
+		opts,
+	); err != nil {
+		log.Fatal("Failed to register credit limit service handler", zap.Error(err))
+	}
+
+	// Register transaction service handler
+	if err := pb.RegisterTransactionServiceHandlerFromEndpoint(
+		ctx,
+		gwmux,
+		fmt.Sprintf("localhost:%d", cfg.Server.GRPCPort),
+		opts,
+	); err != nil {
+		log.Fatal("Failed to register transaction service handler", zap.Error(err))
+	}
+
+	// Register bank account service handler
+	if err := pb.RegisterBankAccountServiceHandlerFromEndpoint(
+		ctx,
+		gwmux,
+		fmt.Sprintf("localhost:%d", cfg.Server.GRPCPort),
+		opts,
+	); err != nil {
+		log.Fatal("Failed to register bank account service handler", zap.Error(err))
+	}
+
+	// Register admin loan service handler
+	if err := pb.RegisterAdminLoanServiceHandlerFromEndpoint(
+		ctx,
+		gwmux,
+		fmt.Sprintf("localhost:%d", cfg.Server.GRPCPort),
+		opts,
+	); err != nil {
+		log.Fatal("Failed to register admin loan service handler", zap.Error(err))
 	} // Initialize router with both gRPC-Gateway and HTTP handlers
 	router := mux.NewRouter()
 
@@ -153,6 +264,10 @@ func initHTTPServer(cfg *config.Config, log *zap.Logger, userUseCase usecase.Use
 	swaggerFiles := []string{
 		"proto/gen/openapiv2/proto/user.swagger.json",
 		"proto/gen/openapiv2/proto/loan.swagger.json",
+		"proto/gen/openapiv2/proto/credit_limit.swagger.json",
+		"proto/gen/openapiv2/proto/transaction.swagger.json",
+		"proto/gen/openapiv2/proto/bank_account.swagger.json",
+		"proto/gen/openapiv2/proto/admin_loan.swagger.json",
 	}
 	swaggerHandler := handler.SwaggerHandler(swaggerFiles)
 	router.PathPrefix("/swagger/").Handler(http.StripPrefix("/swagger/", swaggerHandler))
@@ -170,7 +285,7 @@ func initHTTPServer(cfg *config.Config, log *zap.Logger, userUseCase usecase.Use
 		})
 	})
 	// Add additional HTTP routes first
-	httpHandler := handler.NewHTTPHandler(log)
+	httpHandler := handler.NewHTTPHandler(log, documentUseCase, authInterceptor)
 	httpHandler.RegisterHTTPRoutes(router)
 
 	// Then serve gRPC-Gateway API endpoints
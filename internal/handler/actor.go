@@ -0,0 +1,85 @@
+package handler
+
+import (
+	"context"
+	"net"
+	"strings"
+
+	"github.com/edosulai/pt-xyz-multifinance/internal/model"
+	"github.com/golang-jwt/jwt/v5"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// withActor attaches the authenticated caller to ctx so loan status changes are attributed to them
+func withActor(ctx context.Context) context.Context {
+	claims, ok := ctx.Value("user_claims").(jwt.MapClaims)
+	if !ok {
+		return ctx
+	}
+
+	userID, _ := claims["user_id"].(string)
+	role, _ := claims["role"].(string)
+	if role == "" {
+		role = string(model.ActorRoleCustomer)
+	}
+
+	return model.ContextWithActor(ctx, model.Actor{ID: userID, Role: model.ActorRole(role)})
+}
+
+// requireAdmin rejects callers that are not admins
+func requireAdmin(ctx context.Context) error {
+	if model.ActorFromContext(withActor(ctx)).Role != model.ActorRoleAdmin {
+		return status.Error(codes.PermissionDenied, "admin role required")
+	}
+	return nil
+}
+
+// requirePaymentPoster rejects callers other than admins and the payment channel, which posts
+// payments as the system once the money has arrived
+func requirePaymentPoster(ctx context.Context) error {
+	switch model.ActorFromContext(withActor(ctx)).Role {
+	case model.ActorRoleAdmin, model.ActorRoleSystem:
+		return nil
+	}
+	return status.Error(codes.PermissionDenied, "only admins and the payment channel can post payments")
+}
+
+// requireSelfOrAdmin rejects callers that are neither the given user nor an admin
+func requireSelfOrAdmin(ctx context.Context, userID string) error {
+	actor := model.ActorFromContext(withActor(ctx))
+	if actor.Role != model.ActorRoleAdmin && actor.ID != userID {
+		return status.Error(codes.PermissionDenied, "not allowed to access another user's data")
+	}
+	return nil
+}
+
+// clientInfo returns the address and user agent of the client behind the call. Calls through
+// the gateway arrive from loopback and carry the client's address as the last X-Forwarded-For
+// entry, appended by the gateway itself; entries before it are set by the client and not trusted.
+func clientInfo(ctx context.Context) (ipAddress, userAgent string) {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		ipAddress = p.Addr.String()
+		if host, _, err := net.SplitHostPort(ipAddress); err == nil {
+			ipAddress = host
+		}
+	}
+	if ip := net.ParseIP(ipAddress); ip != nil && ip.IsLoopback() {
+		if forwarded := md.Get("x-forwarded-for"); len(forwarded) > 0 {
+			hops := strings.Split(forwarded[len(forwarded)-1], ",")
+			ipAddress = strings.TrimSpace(hops[len(hops)-1])
+		}
+	}
+
+	if agent := md.Get("grpcgateway-user-agent"); len(agent) > 0 {
+		userAgent = agent[0]
+	} else if agent := md.Get("user-agent"); len(agent) > 0 {
+		userAgent = agent[0]
+	}
+
+	return ipAddress, userAgent
+}
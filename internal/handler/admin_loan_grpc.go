@@ -0,0 +1,273 @@
+package handler
+
+import (
+	"context"
+	"time"
+
+	"github.com/edosulai/pt-xyz-multifinance/internal/model"
+	"github.com/edosulai/pt-xyz-multifinance/internal/usecase"
+	pb "github.com/edosulai/pt-xyz-multifinance/proto/gen/go/xyz/multifinance/v1"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// AdminLoanHandler serves the back-office loan operations. Every method requires an admin token.
+type AdminLoanHandler struct {
+	pb.UnimplementedAdminLoanServiceServer
+	loanUseCase        usecase.LoanUseCase
+	restructureUseCase usecase.RestructureUseCase
+	log                *zap.Logger
+}
+
+func NewAdminLoanHandler(loanUseCase usecase.LoanUseCase, restructureUseCase usecase.RestructureUseCase, log *zap.Logger) *AdminLoanHandler {
+	return &AdminLoanHandler{
+		loanUseCase:        loanUseCase,
+		restructureUseCase: restructureUseCase,
+		log:                log,
+	}
+}
+
+func (h *AdminLoanHandler) ListPendingApplications(ctx context.Context, req *pb.ListPendingApplicationsRequest) (*pb.ListPendingApplicationsResponse, error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	loans, err := h.loanUseCase.ListPendingApplications(ctx)
+	if err != nil {
+		h.log.Error("Failed to list pending applications", zap.Error(err))
+		return nil, toStatusError(err)
+	}
+
+	response := &pb.ListPendingApplicationsResponse{
+		Loans: make([]*pb.LoanApplication, 0, len(loans)),
+	}
+	for i := range loans {
+		response.Loans = append(response.Loans, convertLoanToProto(&loans[i]))
+	}
+
+	return response, nil
+}
+
+func (h *AdminLoanHandler) SearchLoans(ctx context.Context, req *pb.SearchLoansRequest) (*pb.SearchLoansResponse, error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	minAmount, err := parseMoney("min_amount", req.MinAmount)
+	if err != nil {
+		return nil, err
+	}
+	maxAmount, err := parseMoney("max_amount", req.MaxAmount)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := model.LoanSearchFilter{
+		UserID:        req.UserId,
+		ProductCode:   req.ProductCode,
+		MinAmount:     minAmount,
+		MaxAmount:     maxAmount,
+		CreatedFrom:   timeFromProto(req.CreatedFrom),
+		CreatedTo:     timeFromProto(req.CreatedTo),
+		DisbursedFrom: timeFromProto(req.DisbursedFrom),
+		DisbursedTo:   timeFromProto(req.DisbursedTo),
+	}
+	for _, s := range req.Statuses {
+		filter.Statuses = append(filter.Statuses, model.LoanStatus(s))
+	}
+	sort := model.LoanSort{Field: model.LoanSortField(req.SortBy), Descending: req.Descending}
+
+	loans, nextPageToken, err := h.loanUseCase.SearchLoans(ctx, filter, sort, req.PageToken, int(req.PageSize))
+	if err != nil {
+		h.log.Error("Failed to search loans", zap.Error(err))
+		return nil, toStatusError(err)
+	}
+
+	response := &pb.SearchLoansResponse{
+		Loans:         make([]*pb.LoanApplication, 0, len(loans)),
+		NextPageToken: nextPageToken,
+	}
+	for i := range loans {
+		response.Loans = append(response.Loans, convertLoanToProto(&loans[i]))
+	}
+
+	return response, nil
+}
+
+func (h *AdminLoanHandler) DecideLoanApplication(ctx context.Context, req *pb.DecideLoanApplicationRequest) (*pb.LoanApplication, error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	ctx = withActor(ctx)
+
+	if err := h.loanUseCase.ProcessLoanApplication(ctx, req.LoanId, req.Approve, req.InterestRate, req.Reason); err != nil {
+		h.log.Error("Failed to process loan application", zap.Error(err))
+		return nil, toStatusError(err)
+	}
+
+	loan, err := h.loanUseCase.GetLoanStatus(ctx, req.LoanId)
+	if err != nil {
+		h.log.Error("Failed to get updated loan status", zap.Error(err))
+		return nil, toStatusError(err)
+	}
+
+	return convertLoanToProto(loan), nil
+}
+
+func (h *AdminLoanHandler) SetInterestRate(ctx context.Context, req *pb.SetInterestRateRequest) (*pb.LoanApplication, error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	ctx = withActor(ctx)
+
+	loan, err := h.loanUseCase.SetInterestRate(ctx, req.LoanId, req.InterestRate)
+	if err != nil {
+		h.log.Error("Failed to set interest rate", zap.Error(err))
+		return nil, toStatusError(err)
+	}
+
+	return convertLoanToProto(loan), nil
+}
+
+func (h *AdminLoanHandler) DisburseLoan(ctx context.Context, req *pb.DisburseLoanRequest) (*pb.DisbursementInstruction, error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	ctx = withActor(ctx)
+
+	amount, err := parseMoney("amount", req.Amount)
+	if err != nil {
+		return nil, err
+	}
+
+	instruction, err := h.loanUseCase.DisburseLoan(ctx, req.LoanId, amount, req.BankAccountId)
+	if err != nil {
+		h.log.Error("Failed to disburse loan", zap.Error(err))
+		return nil, toStatusError(err)
+	}
+
+	return convertDisbursementInstructionToProto(instruction), nil
+}
+
+func (h *AdminLoanHandler) GetDisbursements(ctx context.Context, req *pb.GetDisbursementsRequest) (*pb.GetDisbursementsResponse, error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	instructions, err := h.loanUseCase.GetDisbursements(ctx, req.LoanId)
+	if err != nil {
+		h.log.Error("Failed to get disbursements", zap.Error(err))
+		return nil, toStatusError(err)
+	}
+
+	response := &pb.GetDisbursementsResponse{
+		Instructions: make([]*pb.DisbursementInstruction, 0, len(instructions)),
+	}
+	for i := range instructions {
+		response.Instructions = append(response.Instructions, convertDisbursementInstructionToProto(&instructions[i]))
+	}
+
+	return response, nil
+}
+
+func (h *AdminLoanHandler) GetDisbursementTranches(ctx context.Context, req *pb.GetDisbursementTranchesRequest) (*pb.GetDisbursementTranchesResponse, error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	tranches, err := h.loanUseCase.GetDisbursementTranches(ctx, req.LoanId)
+	if err != nil {
+		h.log.Error("Failed to get disbursement tranches", zap.Error(err))
+		return nil, toStatusError(err)
+	}
+
+	response := &pb.GetDisbursementTranchesResponse{
+		Tranches: make([]*pb.DisbursementTranche, 0, len(tranches)),
+	}
+	for _, tranche := range tranches {
+		response.Tranches = append(response.Tranches, &pb.DisbursementTranche{
+			Id:            tranche.ID,
+			LoanId:        tranche.LoanID,
+			InstructionId: tranche.InstructionID,
+			TrancheNumber: int32(tranche.TrancheNumber),
+			Amount:        tranche.Amount.String(),
+			DisbursedAt:   timestamppb.New(tranche.DisbursedAt),
+		})
+	}
+
+	return response, nil
+}
+
+func (h *AdminLoanHandler) ReviewDocument(ctx context.Context, req *pb.ReviewDocumentRequest) (*pb.Document, error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	ctx = withActor(ctx)
+
+	doc, err := h.loanUseCase.ReviewDocument(ctx, req.LoanId, req.DocumentId, model.DocumentReview{
+		Status:     model.DocumentStatus(req.Status),
+		ReasonCode: model.DocumentReasonCode(req.ReasonCode),
+		Note:       req.Note,
+	})
+	if err != nil {
+		h.log.Error("Failed to review document", zap.Error(err))
+		return nil, toStatusError(err)
+	}
+
+	return convertDocumentToProto(doc), nil
+}
+
+func (h *AdminLoanHandler) RestructureLoan(ctx context.Context, req *pb.RestructureLoanRequest) (*pb.LoanRestructuring, error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	ctx = withActor(ctx)
+
+	terms := usecase.RestructureTerms{
+		TenureMonths:      int(req.TenureMonths),
+		InterestRate:      req.InterestRate,
+		GracePeriodMonths: int(req.GracePeriodMonths),
+		Reason:            req.Reason,
+	}
+
+	restructuring, err := h.restructureUseCase.RestructureLoan(ctx, req.LoanId, terms)
+	if err != nil {
+		h.log.Error("Failed to restructure loan", zap.Error(err))
+		return nil, toStatusError(err)
+	}
+
+	return convertRestructuringToProto(restructuring), nil
+}
+
+// timeFromProto converts an optional proto timestamp to a time, leaving unset values nil
+func timeFromProto(ts *timestamppb.Timestamp) *time.Time {
+	if ts == nil {
+		return nil
+	}
+	t := ts.AsTime()
+	return &t
+}
+
+// Helper function to convert model.DisbursementInstruction to proto DisbursementInstruction
+func convertDisbursementInstructionToProto(instruction *model.DisbursementInstruction) *pb.DisbursementInstruction {
+	result := &pb.DisbursementInstruction{
+		Id:             instruction.ID,
+		LoanId:         instruction.LoanID,
+		BankAccountId:  instruction.BankAccountID,
+		Amount:         instruction.Amount.String(),
+		Status:         string(instruction.Status),
+		BankReference:  instruction.BankReference,
+		BatchReference: instruction.BatchReference,
+		FailureReason:  instruction.FailureReason,
+		CreatedAt:      timestamppb.New(instruction.CreatedAt),
+	}
+
+	if instruction.SentAt != nil {
+		result.SentAt = timestamppb.New(*instruction.SentAt)
+	}
+	if instruction.CompletedAt != nil {
+		result.CompletedAt = timestamppb.New(*instruction.CompletedAt)
+	}
+
+	return result
+}
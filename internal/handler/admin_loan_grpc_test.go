@@ -0,0 +1,100 @@
+package handler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/edosulai/pt-xyz-multifinance/internal/model"
+	"github.com/edosulai/pt-xyz-multifinance/internal/usecase"
+	pb "github.com/edosulai/pt-xyz-multifinance/proto/gen/go/xyz/multifinance/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// stubRestructureUseCase restructures every loan onto the requested tenure
+type stubRestructureUseCase struct {
+	usecase.RestructureUseCase
+	actor model.Actor
+}
+
+func (s *stubRestructureUseCase) RestructureLoan(ctx context.Context, loanID string, terms usecase.RestructureTerms) (*model.LoanRestructuring, error) {
+	s.actor = model.ActorFromContext(ctx)
+	return &model.LoanRestructuring{ID: "restructuring-1", LoanID: loanID, NewTenureMonths: terms.TenureMonths}, nil
+}
+
+// stubDisbursementUseCase lists fixed disbursements for loan-1; other loans are not found
+type stubDisbursementUseCase struct {
+	usecase.LoanUseCase
+}
+
+func (s *stubDisbursementUseCase) GetDisbursements(ctx context.Context, loanID string) ([]model.DisbursementInstruction, error) {
+	if loanID != "loan-1" {
+		return nil, usecase.ErrLoanNotFound
+	}
+	return []model.DisbursementInstruction{
+		{ID: "instr-1", LoanID: loanID, Amount: model.NewMoney(5000000), Status: model.DisbursementStatusSucceeded},
+		{ID: "instr-2", LoanID: loanID, Amount: model.NewMoney(7000000), Status: model.DisbursementStatusPending},
+	}, nil
+}
+
+func (s *stubDisbursementUseCase) GetDisbursementTranches(ctx context.Context, loanID string) ([]model.Disbursement, error) {
+	if loanID != "loan-1" {
+		return nil, usecase.ErrLoanNotFound
+	}
+	return []model.Disbursement{
+		{ID: "tranche-1", LoanID: loanID, InstructionID: "instr-1", TrancheNumber: 1, Amount: model.NewMoney(5000000), DisbursedAt: time.Now()},
+	}, nil
+}
+
+func TestAdminLoanHandler_Disbursements(t *testing.T) {
+	h := NewAdminLoanHandler(&stubDisbursementUseCase{}, nil, zap.NewNop())
+	admin := withClaims("admin-1", "admin")
+
+	instructions, err := h.GetDisbursements(admin, &pb.GetDisbursementsRequest{LoanId: "loan-1"})
+	require.NoError(t, err)
+	require.Len(t, instructions.Instructions, 2)
+	assert.Equal(t, "5000000.00", instructions.Instructions[0].Amount)
+	assert.Equal(t, "pending", instructions.Instructions[1].Status)
+
+	tranches, err := h.GetDisbursementTranches(admin, &pb.GetDisbursementTranchesRequest{LoanId: "loan-1"})
+	require.NoError(t, err)
+	require.Len(t, tranches.Tranches, 1)
+	assert.Equal(t, "instr-1", tranches.Tranches[0].InstructionId)
+	assert.Equal(t, int32(1), tranches.Tranches[0].TrancheNumber)
+
+	_, err = h.GetDisbursements(admin, &pb.GetDisbursementsRequest{LoanId: "loan-2"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	borrower := withClaims("user-1", "customer")
+	_, err = h.GetDisbursements(borrower, &pb.GetDisbursementsRequest{LoanId: "loan-1"})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	_, err = h.GetDisbursementTranches(borrower, &pb.GetDisbursementTranchesRequest{LoanId: "loan-1"})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+func TestAdminLoanHandler_RestructureLoan(t *testing.T) {
+	req := &pb.RestructureLoanRequest{LoanId: "loan-1", TenureMonths: 24, Reason: "hardship"}
+
+	t.Run("admin", func(t *testing.T) {
+		restructureUseCase := &stubRestructureUseCase{}
+		h := NewAdminLoanHandler(nil, restructureUseCase, zap.NewNop())
+
+		restructuring, err := h.RestructureLoan(withClaims("admin-1", "admin"), req)
+
+		require.NoError(t, err)
+		assert.Equal(t, int32(24), restructuring.NewTenureMonths)
+		assert.Equal(t, model.Actor{ID: "admin-1", Role: model.ActorRoleAdmin}, restructureUseCase.actor)
+	})
+
+	t.Run("borrower", func(t *testing.T) {
+		h := NewAdminLoanHandler(nil, &stubRestructureUseCase{}, zap.NewNop())
+
+		_, err := h.RestructureLoan(withClaims("user-1", "customer"), req)
+
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+}
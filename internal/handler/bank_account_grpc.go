@@ -0,0 +1,94 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/edosulai/pt-xyz-multifinance/internal/model"
+	"github.com/edosulai/pt-xyz-multifinance/internal/usecase"
+	pb "github.com/edosulai/pt-xyz-multifinance/proto/gen/go/xyz/multifinance/v1"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type BankAccountHandler struct {
+	pb.UnimplementedBankAccountServiceServer
+	bankAccountUseCase usecase.BankAccountUseCase
+	log                *zap.Logger
+}
+
+func NewBankAccountHandler(bankAccountUseCase usecase.BankAccountUseCase, log *zap.Logger) *BankAccountHandler {
+	return &BankAccountHandler{
+		bankAccountUseCase: bankAccountUseCase,
+		log:                log,
+	}
+}
+
+func (h *BankAccountHandler) RegisterBankAccount(ctx context.Context, req *pb.RegisterBankAccountRequest) (*pb.BankAccount, error) {
+	if err := requireSelfOrAdmin(ctx, req.UserId); err != nil {
+		return nil, err
+	}
+
+	account, err := h.bankAccountUseCase.RegisterBankAccount(ctx, req.UserId, req.BankCode, req.AccountNumber, req.HolderName)
+	if err != nil {
+		h.log.Error("Failed to register bank account", zap.Error(err))
+		return nil, toStatusError(err)
+	}
+
+	return convertBankAccountToProto(account), nil
+}
+
+func (h *BankAccountHandler) ListBankAccounts(ctx context.Context, req *pb.ListBankAccountsRequest) (*pb.ListBankAccountsResponse, error) {
+	if err := requireSelfOrAdmin(ctx, req.UserId); err != nil {
+		return nil, err
+	}
+
+	accounts, err := h.bankAccountUseCase.ListBankAccounts(ctx, req.UserId)
+	if err != nil {
+		h.log.Error("Failed to list bank accounts", zap.Error(err))
+		return nil, toStatusError(err)
+	}
+
+	response := &pb.ListBankAccountsResponse{
+		Accounts: make([]*pb.BankAccount, 0, len(accounts)),
+	}
+	for _, account := range accounts {
+		response.Accounts = append(response.Accounts, convertBankAccountToProto(&account))
+	}
+
+	return response, nil
+}
+
+func (h *BankAccountHandler) VerifyBankAccount(ctx context.Context, req *pb.VerifyBankAccountRequest) (*pb.BankAccount, error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	ctx = withActor(ctx)
+
+	account, err := h.bankAccountUseCase.VerifyBankAccount(ctx, req.AccountId, req.Verified, req.Reason)
+	if err != nil {
+		h.log.Error("Failed to verify bank account", zap.Error(err))
+		return nil, toStatusError(err)
+	}
+
+	return convertBankAccountToProto(account), nil
+}
+
+// Helper function to convert model.BankAccount to proto BankAccount
+func convertBankAccountToProto(account *model.BankAccount) *pb.BankAccount {
+	result := &pb.BankAccount{
+		Id:              account.ID,
+		UserId:          account.UserID,
+		BankCode:        account.BankCode,
+		AccountNumber:   account.AccountNumber,
+		HolderName:      account.HolderName,
+		Status:          string(account.Status),
+		RejectionReason: account.RejectionReason,
+		CreatedAt:       timestamppb.New(account.CreatedAt),
+	}
+
+	if account.VerifiedAt != nil {
+		result.VerifiedAt = timestamppb.New(*account.VerifiedAt)
+	}
+
+	return result
+}
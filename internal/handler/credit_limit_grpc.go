@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/edosulai/pt-xyz-multifinance/internal/model"
+	"github.com/edosulai/pt-xyz-multifinance/internal/usecase"
+	pb "github.com/edosulai/pt-xyz-multifinance/proto/gen/go/xyz/multifinance/v1"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type CreditLimitHandler struct {
+	pb.UnimplementedCreditLimitServiceServer
+	creditLimitUseCase usecase.CreditLimitUseCase
+	log                *zap.Logger
+}
+
+func NewCreditLimitHandler(creditLimitUseCase usecase.CreditLimitUseCase, log *zap.Logger) *CreditLimitHandler {
+	return &CreditLimitHandler{
+		creditLimitUseCase: creditLimitUseCase,
+		log:                log,
+	}
+}
+
+func (h *CreditLimitHandler) GetCreditLimits(ctx context.Context, req *pb.GetCreditLimitsRequest) (*pb.GetCreditLimitsResponse, error) {
+	if err := requireSelfOrAdmin(ctx, req.UserId); err != nil {
+		return nil, err
+	}
+
+	limits, err := h.creditLimitUseCase.GetCreditLimits(ctx, req.UserId)
+	if err != nil {
+		h.log.Error("Failed to get credit limits", zap.Error(err))
+		return nil, toStatusError(err)
+	}
+
+	response := &pb.GetCreditLimitsResponse{
+		UserId: req.UserId,
+		Limits: make([]*pb.CreditLimit, 0, len(limits)),
+	}
+	for _, limit := range limits {
+		response.Limits = append(response.Limits, convertCreditLimitToProto(&limit))
+	}
+
+	return response, nil
+}
+
+func (h *CreditLimitHandler) SetCreditLimit(ctx context.Context, req *pb.SetCreditLimitRequest) (*pb.CreditLimit, error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	limitAmount, err := parseMoney("limit_amount", req.LimitAmount)
+	if err != nil {
+		return nil, err
+	}
+
+	limit, err := h.creditLimitUseCase.SetCreditLimit(ctx, req.UserId, int(req.TenureMonths), limitAmount)
+	if err != nil {
+		h.log.Error("Failed to set credit limit", zap.Error(err))
+		return nil, toStatusError(err)
+	}
+
+	return convertCreditLimitToProto(limit), nil
+}
+
+// Helper function to convert model.CreditLimit to proto CreditLimit
+func convertCreditLimitToProto(limit *model.CreditLimit) *pb.CreditLimit {
+	return &pb.CreditLimit{
+		Id:              limit.ID,
+		UserId:          limit.UserID,
+		TenureMonths:    int32(limit.TenureMonths),
+		LimitAmount:     limit.LimitAmount.String(),
+		UsedAmount:      limit.UsedAmount.String(),
+		RemainingAmount: limit.RemainingAmount().String(),
+		UpdatedAt:       timestamppb.New(limit.UpdatedAt),
+	}
+}
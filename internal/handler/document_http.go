@@ -0,0 +1,99 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/edosulai/pt-xyz-multifinance/internal/model"
+	"github.com/gorilla/mux"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// maxUploadRequestBytes bounds a multipart upload request; the document use case applies
+	// the configured size limit to the file itself
+	maxUploadRequestBytes = 32 << 20
+	// uploadMemoryBytes is how much of an upload is buffered in memory before spilling to disk
+	uploadMemoryBytes = 1 << 20
+)
+
+// jsonMarshaler renders responses the same way as the gRPC-Gateway routes
+var jsonMarshaler = &runtime.JSONPb{}
+
+// handleUploadDocument stores a file sent as multipart/form-data with a "type" field and a
+// "file" part, and attaches it to the loan
+func (h *HTTPHandler) handleUploadDocument(w http.ResponseWriter, r *http.Request) {
+	ctx := withActor(r.Context())
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxUploadRequestBytes)
+	if err := r.ParseMultipartForm(uploadMemoryBytes); err != nil {
+		h.writeError(w, status.Errorf(codes.InvalidArgument, "invalid multipart upload: %v", err))
+		return
+	}
+	defer r.MultipartForm.RemoveAll()
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		h.writeError(w, status.Error(codes.InvalidArgument, "file is required"))
+		return
+	}
+	defer file.Close()
+
+	docType := model.DocumentType(r.FormValue("type"))
+	doc, err := h.documentUseCase.UploadDocument(ctx, mux.Vars(r)["loan_id"], docType, header.Filename, file)
+	if err != nil {
+		h.logger.Error("Failed to upload document", zap.Error(err))
+		h.writeError(w, toStatusError(err))
+		return
+	}
+
+	data, err := jsonMarshaler.Marshal(convertDocumentToProto(doc))
+	if err != nil {
+		h.logger.Error("Failed to encode document", zap.Error(err))
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	w.Write(data)
+}
+
+// handleDocumentContent serves the stored file of an uploaded document
+func (h *HTTPHandler) handleDocumentContent(w http.ResponseWriter, r *http.Request) {
+	ctx := withActor(r.Context())
+
+	doc, content, err := h.documentUseCase.GetDocumentContent(ctx, mux.Vars(r)["document_id"])
+	if err != nil {
+		h.logger.Error("Failed to get document content", zap.Error(err))
+		h.writeError(w, toStatusError(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", doc.ContentType)
+	w.Header().Set("Content-Length", strconv.Itoa(len(content)))
+	w.Header().Set("Content-Disposition", "attachment; filename="+strconv.Quote(doc.Name))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Write(content)
+}
+
+// writeError writes a gRPC status error in the body format of the gRPC-Gateway routes
+func (h *HTTPHandler) writeError(w http.ResponseWriter, err error) {
+	st := status.Convert(err)
+	if st.Code() == codes.Unknown {
+		st = status.New(codes.Internal, "internal server error")
+	}
+
+	data, marshalErr := jsonMarshaler.Marshal(st.Proto())
+	if marshalErr != nil {
+		http.Error(w, st.Message(), runtime.HTTPStatusFromCode(st.Code()))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(runtime.HTTPStatusFromCode(st.Code()))
+	w.Write(data)
+}
@@ -5,17 +5,23 @@ import (
 	"net/http"
 
 	"github.com/dchest/captcha"
+	"github.com/edosulai/pt-xyz-multifinance/internal/usecase"
+	"github.com/edosulai/pt-xyz-multifinance/pkg/middleware"
 	"github.com/gorilla/mux"
 	"go.uber.org/zap"
 )
 
 type HTTPHandler struct {
-	logger *zap.Logger
+	logger          *zap.Logger
+	documentUseCase usecase.DocumentUseCase
+	auth            *middleware.AuthInterceptor
 }
 
-func NewHTTPHandler(logger *zap.Logger) *HTTPHandler {
+func NewHTTPHandler(logger *zap.Logger, documentUseCase usecase.DocumentUseCase, auth *middleware.AuthInterceptor) *HTTPHandler {
 	return &HTTPHandler{
-		logger: logger,
+		logger:          logger,
+		documentUseCase: documentUseCase,
+		auth:            auth,
 	}
 }
 
@@ -25,6 +31,12 @@ func (h *HTTPHandler) RegisterHTTPRoutes(router *mux.Router) {
 	router.HandleFunc("/v1/captcha/new", h.handleNewCaptcha).Methods(http.MethodGet)
 	router.HandleFunc("/v1/captcha/{id}.png", h.handleCaptchaImage).Methods(http.MethodGet)
 
+	// Document routes need the same bearer token as the gRPC-Gateway API
+	documents := router.NewRoute().Subrouter()
+	documents.Use(h.auth.HTTPMiddleware)
+	documents.HandleFunc("/v1/loans/{loan_id}/documents/upload", h.handleUploadDocument).Methods(http.MethodPost)
+	documents.HandleFunc("/v1/documents/{document_id}/content", h.handleDocumentContent).Methods(http.MethodGet)
+
 	// Health check
 	router.HandleFunc("/health", h.handleHealthCheck).Methods(http.MethodGet)
 }
@@ -2,42 +2,70 @@ package handler
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"time"
 
 	"github.com/edosulai/pt-xyz-multifinance/internal/model"
 	"github.com/edosulai/pt-xyz-multifinance/internal/usecase"
 	pb "github.com/edosulai/pt-xyz-multifinance/proto/gen/go/xyz/multifinance/v1"
 	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type LoanHandler struct {
 	pb.UnimplementedLoanServiceServer
-	loanUseCase usecase.LoanUseCase
-	log         *zap.Logger
+	loanUseCase        usecase.LoanUseCase
+	restructureUseCase usecase.RestructureUseCase
+	log                *zap.Logger
 }
 
-func NewLoanHandler(loanUseCase usecase.LoanUseCase, log *zap.Logger) *LoanHandler {
+func NewLoanHandler(loanUseCase usecase.LoanUseCase, restructureUseCase usecase.RestructureUseCase, log *zap.Logger) *LoanHandler {
 	return &LoanHandler{
-		loanUseCase: loanUseCase,
-		log:         log,
+		loanUseCase:        loanUseCase,
+		restructureUseCase: restructureUseCase,
+		log:                log,
 	}
 }
 
 func (h *LoanHandler) ApplyLoan(ctx context.Context, req *pb.LoanApplicationRequest) (*pb.LoanApplication, error) {
-	loan, err := h.loanUseCase.ApplyLoan(ctx, req.UserId, req.Amount, int(req.TenureMonths), req.Purpose)
+	amount, err := parseMoney("amount", req.Amount)
 	if err != nil {
-		h.log.Error("Failed to apply loan", zap.Error(err))
 		return nil, err
 	}
 
+	loan, err := h.loanUseCase.ApplyLoan(ctx, req.UserId, req.ProductCode, amount, int(req.TenureMonths), req.Purpose)
+	if err != nil {
+		h.log.Error("Failed to apply loan", zap.Error(err))
+		return nil, toStatusError(err)
+	}
+
 	return convertLoanToProto(loan), nil
 }
 
+func (h *LoanHandler) ListLoanProducts(ctx context.Context, req *pb.ListLoanProductsRequest) (*pb.ListLoanProductsResponse, error) {
+	products, err := h.loanUseCase.ListLoanProducts(ctx)
+	if err != nil {
+		h.log.Error("Failed to list loan products", zap.Error(err))
+		return nil, toStatusError(err)
+	}
+
+	result := make([]*pb.LoanProduct, 0, len(products))
+	for i := range products {
+		result = append(result, convertLoanProductToProto(&products[i]))
+	}
+
+	return &pb.ListLoanProductsResponse{Products: result}, nil
+}
+
 func (h *LoanHandler) GetLoanStatus(ctx context.Context, req *pb.GetLoanStatusRequest) (*pb.LoanApplication, error) {
 	loan, err := h.loanUseCase.GetLoanStatus(ctx, req.LoanId)
 	if err != nil {
 		h.log.Error("Failed to get loan status", zap.Error(err))
-		return nil, err
+		return nil, toStatusError(err)
 	}
 
 	return convertLoanToProto(loan), nil
@@ -47,7 +75,7 @@ func (h *LoanHandler) GetLoanHistory(ctx context.Context, req *pb.GetLoanHistory
 	loans, total, err := h.loanUseCase.GetLoanHistory(ctx, req.UserId, int(req.Page), int(req.PageSize))
 	if err != nil {
 		h.log.Error("Failed to get loan history", zap.Error(err))
-		return nil, err
+		return nil, toStatusError(err)
 	}
 
 	response := &pb.GetLoanHistoryResponse{
@@ -65,6 +93,8 @@ func (h *LoanHandler) GetLoanHistory(ctx context.Context, req *pb.GetLoanHistory
 }
 
 func (h *LoanHandler) SubmitLoanDocuments(ctx context.Context, req *pb.SubmitLoanDocumentsRequest) (*pb.LoanApplication, error) {
+	ctx = withActor(ctx)
+
 	docs := make([]model.Document, 0, len(req.Documents))
 	for _, doc := range req.Documents {
 		docs = append(docs, model.Document{
@@ -76,18 +106,271 @@ func (h *LoanHandler) SubmitLoanDocuments(ctx context.Context, req *pb.SubmitLoa
 
 	if err := h.loanUseCase.SubmitLoanDocuments(ctx, req.LoanId, docs); err != nil {
 		h.log.Error("Failed to submit loan documents", zap.Error(err))
-		return nil, err
+		return nil, toStatusError(err)
 	}
 
 	loan, err := h.loanUseCase.GetLoanStatus(ctx, req.LoanId)
 	if err != nil {
 		h.log.Error("Failed to get updated loan status", zap.Error(err))
-		return nil, err
+		return nil, toStatusError(err)
+	}
+
+	return convertLoanToProto(loan), nil
+}
+
+func (h *LoanHandler) AcceptLoanOffer(ctx context.Context, req *pb.AcceptLoanOfferRequest) (*pb.LoanOfferAcceptance, error) {
+	ctx = withActor(ctx)
+	ipAddress, userAgent := clientInfo(ctx)
+
+	acceptance, err := h.loanUseCase.AcceptLoanOffer(ctx, req.LoanId, req.TermsHash, ipAddress, userAgent)
+	if err != nil {
+		h.log.Error("Failed to accept loan offer", zap.Error(err))
+		return nil, toStatusError(err)
+	}
+
+	return &pb.LoanOfferAcceptance{
+		Id:         acceptance.ID,
+		LoanId:     acceptance.LoanID,
+		UserId:     acceptance.UserID,
+		Terms:      string(acceptance.Terms),
+		TermsHash:  acceptance.TermsHash,
+		AcceptedAt: timestamppb.New(acceptance.AcceptedAt),
+		IpAddress:  acceptance.IPAddress,
+		UserAgent:  acceptance.UserAgent,
+	}, nil
+}
+
+func (h *LoanHandler) DeclineLoanOffer(ctx context.Context, req *pb.DeclineLoanOfferRequest) (*pb.LoanApplication, error) {
+	ctx = withActor(ctx)
+
+	loan, err := h.loanUseCase.DeclineLoanOffer(ctx, req.LoanId, req.Reason)
+	if err != nil {
+		h.log.Error("Failed to decline loan offer", zap.Error(err))
+		return nil, toStatusError(err)
 	}
 
 	return convertLoanToProto(loan), nil
 }
 
+func (h *LoanHandler) GetRepaymentSchedule(ctx context.Context, req *pb.GetRepaymentScheduleRequest) (*pb.GetRepaymentScheduleResponse, error) {
+	ctx = withActor(ctx)
+
+	installments, err := h.loanUseCase.GetRepaymentSchedule(ctx, req.LoanId)
+	if err != nil {
+		h.log.Error("Failed to get repayment schedule", zap.Error(err))
+		return nil, toStatusError(err)
+	}
+
+	response := &pb.GetRepaymentScheduleResponse{
+		LoanId:       req.LoanId,
+		Installments: make([]*pb.Installment, 0, len(installments)),
+	}
+
+	for _, inst := range installments {
+		response.Installments = append(response.Installments, convertInstallmentToProto(&inst))
+	}
+
+	return response, nil
+}
+
+func (h *LoanHandler) RecordPayment(ctx context.Context, req *pb.RecordPaymentRequest) (*pb.Payment, error) {
+	if err := requirePaymentPoster(ctx); err != nil {
+		return nil, err
+	}
+	ctx = withActor(ctx)
+
+	amount, err := parseMoney("amount", req.Amount)
+	if err != nil {
+		return nil, err
+	}
+
+	var paidAt time.Time
+	if req.PaidAt != nil {
+		paidAt = req.PaidAt.AsTime()
+	}
+
+	payment, err := h.loanUseCase.RecordPayment(ctx, req.LoanId, amount, req.PaymentMethod, req.Reference, paidAt)
+	if err != nil {
+		h.log.Error("Failed to record payment", zap.Error(err))
+		return nil, toStatusError(err)
+	}
+
+	return convertPaymentToProto(payment), nil
+}
+
+func (h *LoanHandler) GetPayoffQuote(ctx context.Context, req *pb.GetPayoffQuoteRequest) (*pb.PayoffQuote, error) {
+	ctx = withActor(ctx)
+
+	quote, err := h.loanUseCase.GetPayoffQuote(ctx, req.LoanId)
+	if err != nil {
+		h.log.Error("Failed to get payoff quote", zap.Error(err))
+		return nil, toStatusError(err)
+	}
+
+	return &pb.PayoffQuote{
+		Id:                   quote.ID,
+		LoanId:               quote.LoanID,
+		OutstandingPrincipal: quote.OutstandingPrincipal.String(),
+		AccruedInterest:      quote.AccruedInterest.String(),
+		OutstandingFees:      quote.OutstandingFees.String(),
+		EarlyTerminationFee:  quote.EarlyTerminationFee.String(),
+		TotalAmount:          quote.TotalAmount.String(),
+		Status:               string(quote.Status),
+		QuotedAt:             timestamppb.New(quote.QuotedAt),
+		ExpiresAt:            timestamppb.New(quote.ExpiresAt),
+	}, nil
+}
+
+func (h *LoanHandler) SettleLoan(ctx context.Context, req *pb.SettleLoanRequest) (*pb.Payment, error) {
+	if err := requirePaymentPoster(ctx); err != nil {
+		return nil, err
+	}
+	ctx = withActor(ctx)
+
+	amount, err := parseMoney("amount", req.Amount)
+	if err != nil {
+		return nil, err
+	}
+
+	var paidAt time.Time
+	if req.PaidAt != nil {
+		paidAt = req.PaidAt.AsTime()
+	}
+
+	payment, err := h.loanUseCase.SettleLoan(ctx, req.LoanId, req.QuoteId, amount, req.PaymentMethod, req.Reference, paidAt)
+	if err != nil {
+		h.log.Error("Failed to settle loan", zap.Error(err))
+		return nil, toStatusError(err)
+	}
+
+	return convertPaymentToProto(payment), nil
+}
+
+func (h *LoanHandler) GetRestructuringHistory(ctx context.Context, req *pb.GetRestructuringHistoryRequest) (*pb.GetRestructuringHistoryResponse, error) {
+	ctx = withActor(ctx)
+
+	restructurings, err := h.restructureUseCase.GetRestructurings(ctx, req.LoanId)
+	if err != nil {
+		h.log.Error("Failed to get restructuring history", zap.Error(err))
+		return nil, toStatusError(err)
+	}
+
+	response := &pb.GetRestructuringHistoryResponse{
+		Restructurings: make([]*pb.LoanRestructuring, 0, len(restructurings)),
+	}
+	for _, restructuring := range restructurings {
+		response.Restructurings = append(response.Restructurings, convertRestructuringToProto(&restructuring))
+	}
+
+	return response, nil
+}
+
+func (h *LoanHandler) GetLoanStatusHistory(ctx context.Context, req *pb.GetLoanStatusHistoryRequest) (*pb.GetLoanStatusHistoryResponse, error) {
+	ctx = withActor(ctx)
+
+	history, err := h.loanUseCase.GetLoanStatusHistory(ctx, req.LoanId)
+	if err != nil {
+		h.log.Error("Failed to get loan status history", zap.Error(err))
+		return nil, toStatusError(err)
+	}
+
+	response := &pb.GetLoanStatusHistoryResponse{
+		LoanId:  req.LoanId,
+		History: make([]*pb.LoanStatusChange, 0, len(history)),
+	}
+	for _, change := range history {
+		response.History = append(response.History, &pb.LoanStatusChange{
+			FromStatus: string(change.FromStatus),
+			ToStatus:   string(change.ToStatus),
+			ActorId:    change.ActorID,
+			ActorRole:  string(change.ActorRole),
+			Reason:     change.Reason,
+			CreatedAt:  timestamppb.New(change.CreatedAt),
+		})
+	}
+
+	return response, nil
+}
+
+// toStatusError maps use case errors to gRPC status errors
+func toStatusError(err error) error {
+	var transitionErr *model.StatusTransitionError
+	var affordabilityErr *usecase.AffordabilityError
+	var duplicateErr *model.DuplicateApplicationError
+	var openLimitErr *model.OpenApplicationLimitError
+	switch {
+	case errors.As(err, &transitionErr), errors.As(err, &openLimitErr),
+		errors.Is(err, usecase.ErrOfferTermsChanged), errors.Is(err, usecase.ErrOfferNotAccepted):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.As(err, &duplicateErr):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.As(err, &affordabilityErr):
+		return affordabilityStatus(affordabilityErr)
+	case errors.As(err, &usecase.ValidationError{}):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.As(err, &usecase.ConflictError{}):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, usecase.ErrLoanNotFound), errors.Is(err, usecase.ErrPayoffQuoteNotFound),
+		errors.Is(err, usecase.ErrUserNotFound), errors.Is(err, usecase.ErrBankAccountNotFound),
+		errors.Is(err, usecase.ErrDisbursementNotFound), errors.Is(err, usecase.ErrDocumentNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, usecase.ErrLoanAccessDenied):
+		return status.Error(codes.PermissionDenied, err.Error())
+	default:
+		return err
+	}
+}
+
+// affordabilityStatus reports a failed debt-to-income check with the figures behind it
+func affordabilityStatus(err *usecase.AffordabilityError) error {
+	st := status.New(codes.InvalidArgument, err.Error())
+	detailed, detailErr := st.WithDetails(&errdetails.ErrorInfo{
+		Reason: "DTI_RATIO_EXCEEDED",
+		Domain: "loan.affordability",
+		Metadata: map[string]string{
+			"monthly_income":     err.MonthlyIncome.String(),
+			"existing_payments":  err.ExistingPayments.String(),
+			"estimated_payment":  err.EstimatedPayment.String(),
+			"dti_percent":        fmt.Sprintf("%.2f", err.DTIPercent),
+			"max_dti_percent":    fmt.Sprintf("%.2f", err.MaxDTIPercent),
+			"affordable_payment": err.AffordablePayment().String(),
+			"shortfall":          err.Shortfall().String(),
+		},
+	})
+	if detailErr != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
+// Helper function to convert model.LoanProduct to proto LoanProduct
+func convertLoanProductToProto(product *model.LoanProduct) *pb.LoanProduct {
+	tenures := make([]int32, 0, len(product.TenureOptions))
+	for _, tenure := range product.TenureOptions {
+		tenures = append(tenures, int32(tenure))
+	}
+	documents := make([]string, 0, len(product.RequiredDocuments))
+	for _, doc := range product.RequiredDocuments {
+		documents = append(documents, string(doc))
+	}
+
+	return &pb.LoanProduct{
+		Code:                       product.Code,
+		Name:                       product.Name,
+		Description:                product.Description,
+		MinAmount:                  product.MinAmount.String(),
+		MaxAmount:                  product.MaxAmount.String(),
+		TenureOptions:              tenures,
+		InterestMethod:             string(product.InterestMethod),
+		MinInterestRate:            product.MinInterestRate,
+		MaxInterestRate:            product.MaxInterestRate,
+		AdminFee:                   product.AdminFee.String(),
+		ProvisionFeePercent:        product.ProvisionFeePercent,
+		RequiredDocuments:          documents,
+		EarlyTerminationFeePercent: product.EarlyTerminationFeePercent,
+	}
+}
+
 // Helper function to convert model.Loan to proto LoanApplication
 func convertLoanToProto(loan *model.Loan) *pb.LoanApplication {
 	if loan == nil {
@@ -95,38 +378,169 @@ func convertLoanToProto(loan *model.Loan) *pb.LoanApplication {
 	}
 
 	result := &pb.LoanApplication{
-		Id:             loan.ID,
-		UserId:         loan.UserID,
-		Amount:         loan.Amount,
-		TenureMonths:   int32(loan.TenureMonths),
-		Purpose:        loan.Purpose,
-		Status:         string(loan.Status),
-		MonthlyPayment: loan.MonthlyPayment,
-		InterestRate:   loan.InterestRate,
-		CreatedAt:      timestamppb.New(loan.CreatedAt),
-		UpdatedAt:      timestamppb.New(loan.UpdatedAt),
+		Id:                   loan.ID,
+		UserId:               loan.UserID,
+		ProductCode:          loan.ProductCode,
+		InterestMethod:       string(loan.InterestMethod),
+		EffectiveAnnualRate:  loan.EffectiveAnnualRate,
+		Amount:               loan.Amount.String(),
+		TenureMonths:         int32(loan.TenureMonths),
+		Purpose:              loan.Purpose,
+		Status:               string(loan.Status),
+		MonthlyPayment:       loan.MonthlyPayment.String(),
+		InterestRate:         loan.InterestRate,
+		CreatedAt:            timestamppb.New(loan.CreatedAt),
+		UpdatedAt:            timestamppb.New(loan.UpdatedAt),
+		OutstandingBalance:   loan.OutstandingBalance.String(),
+		DaysPastDue:          int32(loan.DaysPastDue),
+		Collectibility:       int32(loan.Collectibility),
+		DtiRatio:             loan.DTIRatio,
+		AffordabilityFlagged: loan.AffordabilityFlagged,
+		CreditScore:          int32(loan.CreditScore),
+		RiskGrade:            string(loan.RiskGrade),
+		ScoreReasonCodes:     loan.ScoreReasonCodes,
+		ScoringModel:         loan.ScoringModel,
 	}
 
 	if loan.DisbursedAmount > 0 {
-		result.DisbursedAmount = loan.DisbursedAmount
+		result.DisbursedAmount = loan.DisbursedAmount.String()
 	}
 	if loan.DisbursedAt != nil {
 		result.DisbursedAt = timestamppb.New(*loan.DisbursedAt)
 	}
+	if loan.ScoredAt != nil {
+		result.ScoredAt = timestamppb.New(*loan.ScoredAt)
+	}
 
 	result.Documents = make([]*pb.Document, 0, len(loan.Documents))
-	for _, doc := range loan.Documents {
-		pdoc := &pb.Document{
-			Id:     doc.ID,
-			Type:   string(doc.Type),
-			Name:   doc.Name,
-			Status: string(doc.Status),
-			Url:    doc.URL,
-		}
-		if doc.UploadedAt != nil {
-			pdoc.UploadedAt = timestamppb.New(*doc.UploadedAt)
-		}
-		result.Documents = append(result.Documents, pdoc)
+	for i := range loan.Documents {
+		result.Documents = append(result.Documents, convertDocumentToProto(&loan.Documents[i]))
+	}
+	for _, docType := range loan.DocumentsToReupload() {
+		result.DocumentsToReupload = append(result.DocumentsToReupload, string(docType))
+	}
+	if loan.Status == model.LoanStatusOffered {
+		result.Offer = convertLoanOfferToProto(loan.OfferTerms())
+	}
+
+	return result
+}
+
+// convertLoanOfferToProto presents offer terms together with the hash that accepts them
+func convertLoanOfferToProto(terms model.LoanOfferTerms) *pb.LoanOffer {
+	offer := &pb.LoanOffer{
+		ProductCode:         terms.ProductCode,
+		Amount:              terms.Amount.String(),
+		TenureMonths:        int32(terms.TenureMonths),
+		InterestRate:        terms.InterestRate,
+		InterestMethod:      string(terms.InterestMethod),
+		EffectiveAnnualRate: terms.EffectiveAnnualRate,
+		MonthlyPayment:      terms.MonthlyPayment.String(),
+	}
+	if _, hash, err := terms.Encode(); err == nil {
+		offer.TermsHash = hash
+	}
+	return offer
+}
+
+// Helper function to convert model.Document to proto Document
+func convertDocumentToProto(doc *model.Document) *pb.Document {
+	result := &pb.Document{
+		Id:          doc.ID,
+		Type:        string(doc.Type),
+		Name:        doc.Name,
+		Status:      string(doc.Status),
+		Url:         doc.URL,
+		ContentType: doc.ContentType,
+		SizeBytes:   doc.SizeBytes,
+		Sha256:      doc.SHA256,
+		ReasonCode:  string(doc.ReasonCode),
+		ReviewNote:  doc.ReviewNote,
+		ReviewedBy:  doc.ReviewedBy,
+		Required:    doc.Required,
+	}
+	if doc.StorageKey != "" {
+		result.Url = "/v1/documents/" + doc.ID + "/content"
+	}
+	if doc.UploadedAt != nil {
+		result.UploadedAt = timestamppb.New(*doc.UploadedAt)
+	}
+	if doc.ReviewedAt != nil {
+		result.ReviewedAt = timestamppb.New(*doc.ReviewedAt)
+	}
+
+	return result
+}
+
+// Helper function to convert model.Installment to proto Installment
+func convertInstallmentToProto(inst *model.Installment) *pb.Installment {
+	result := &pb.Installment{
+		Id:                inst.ID,
+		InstallmentNumber: int32(inst.InstallmentNumber),
+		DueDate:           timestamppb.New(inst.DueDate),
+		PrincipalAmount:   inst.PrincipalAmount.String(),
+		InterestAmount:    inst.InterestAmount.String(),
+		TotalAmount:       inst.TotalAmount.String(),
+		RemainingBalance:  inst.RemainingBalance.String(),
+		Status:            string(inst.Status),
+		FeeAmount:         inst.FeeAmount.String(),
+		FeePaid:           inst.FeePaid.String(),
+		InterestPaid:      inst.InterestPaid.String(),
+		PrincipalPaid:     inst.PrincipalPaid.String(),
+	}
+	if inst.PaidAt != nil {
+		result.PaidAt = timestamppb.New(*inst.PaidAt)
+	}
+
+	return result
+}
+
+// Helper function to convert model.Payment to proto Payment
+func convertPaymentToProto(payment *model.Payment) *pb.Payment {
+	result := &pb.Payment{
+		Id:            payment.ID,
+		LoanId:        payment.LoanID,
+		Amount:        payment.Amount.String(),
+		PaymentMethod: payment.PaymentMethod,
+		Reference:     payment.Reference,
+		PaidAt:        timestamppb.New(payment.PaidAt),
+		CreatedAt:     timestamppb.New(payment.CreatedAt),
+		Allocations:   make([]*pb.PaymentAllocation, 0, len(payment.Allocations)),
+	}
+
+	for _, alloc := range payment.Allocations {
+		result.Allocations = append(result.Allocations, &pb.PaymentAllocation{
+			InstallmentId: alloc.InstallmentID,
+			Component:     string(alloc.Component),
+			Amount:        alloc.Amount.String(),
+		})
+	}
+
+	return result
+}
+
+// Helper function to convert model.LoanRestructuring to proto LoanRestructuring
+func convertRestructuringToProto(rs *model.LoanRestructuring) *pb.LoanRestructuring {
+	result := &pb.LoanRestructuring{
+		Id:                     rs.ID,
+		LoanId:                 rs.LoanID,
+		PreviousStatus:         string(rs.PreviousStatus),
+		PreviousTenureMonths:   int32(rs.PreviousTenureMonths),
+		PreviousInterestRate:   rs.PreviousInterestRate,
+		PreviousMonthlyPayment: rs.PreviousMonthlyPayment.String(),
+		OutstandingPrincipal:   rs.OutstandingPrincipal.String(),
+		CapitalizedAmount:      rs.CapitalizedAmount.String(),
+		NewTenureMonths:        int32(rs.NewTenureMonths),
+		NewInterestRate:        rs.NewInterestRate,
+		GracePeriodMonths:      int32(rs.GracePeriodMonths),
+		NewMonthlyPayment:      rs.NewMonthlyPayment.String(),
+		Reason:                 rs.Reason,
+		CreatedAt:              timestamppb.New(rs.CreatedAt),
+		Installments:           make([]*pb.Installment, 0, len(rs.Installments)),
+	}
+
+	for _, inst := range rs.Installments {
+		result.Installments = append(result.Installments, convertInstallmentToProto(&inst))
 	}
 
 	return result
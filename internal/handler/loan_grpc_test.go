@@ -0,0 +1,110 @@
+package handler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/edosulai/pt-xyz-multifinance/internal/model"
+	"github.com/edosulai/pt-xyz-multifinance/internal/usecase"
+	pb "github.com/edosulai/pt-xyz-multifinance/proto/gen/go/xyz/multifinance/v1"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// stubLoanUseCase answers the payment and loan lookup calls with fixed results; any other call panics
+type stubLoanUseCase struct {
+	usecase.LoanUseCase
+	payment *model.Payment
+	quote   *model.PayoffQuote
+	err     error
+}
+
+func (s *stubLoanUseCase) RecordPayment(ctx context.Context, loanID string, amount model.Money, paymentMethod, reference string, paidAt time.Time) (*model.Payment, error) {
+	return s.payment, s.err
+}
+
+func (s *stubLoanUseCase) SettleLoan(ctx context.Context, loanID, quoteID string, amount model.Money, paymentMethod, reference string, paidAt time.Time) (*model.Payment, error) {
+	return s.payment, s.err
+}
+
+func (s *stubLoanUseCase) GetLoanStatus(ctx context.Context, loanID string) (*model.Loan, error) {
+	return nil, s.err
+}
+
+func (s *stubLoanUseCase) GetRepaymentSchedule(ctx context.Context, loanID string) ([]model.Installment, error) {
+	return nil, s.err
+}
+
+func (s *stubLoanUseCase) GetPayoffQuote(ctx context.Context, loanID string) (*model.PayoffQuote, error) {
+	return s.quote, s.err
+}
+
+func withClaims(userID, role string) context.Context {
+	return context.WithValue(context.Background(), "user_claims", jwt.MapClaims{"user_id": userID, "role": role})
+}
+
+func TestLoanHandler_PaymentPosting(t *testing.T) {
+	payment := &model.Payment{ID: "pay-1", LoanID: "loan-1", Amount: model.NewMoney(500000)}
+	h := NewLoanHandler(&stubLoanUseCase{payment: payment}, nil, zap.NewNop())
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		code codes.Code
+	}{
+		{"admin", withClaims("admin-1", "admin"), codes.OK},
+		{"payment channel", withClaims("channel-1", "system"), codes.OK},
+		{"borrower", withClaims("user-1", "customer"), codes.PermissionDenied},
+		{"no role claim", withClaims("user-1", ""), codes.PermissionDenied},
+		{"unauthenticated", context.Background(), codes.PermissionDenied},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			paid, err := h.RecordPayment(tt.ctx, &pb.RecordPaymentRequest{LoanId: "loan-1", Amount: "500000"})
+			assert.Equal(t, tt.code, status.Code(err))
+			if tt.code == codes.OK {
+				require.NotNil(t, paid)
+				assert.Equal(t, "pay-1", paid.Id)
+			}
+
+			_, err = h.SettleLoan(tt.ctx, &pb.SettleLoanRequest{LoanId: "loan-1", QuoteId: "quote-1", Amount: "500000"})
+			assert.Equal(t, tt.code, status.Code(err))
+		})
+	}
+}
+
+func TestLoanHandler_LoanNotRepayable(t *testing.T) {
+	ctx := withClaims("admin-1", "admin")
+	h := NewLoanHandler(&stubLoanUseCase{
+		err: &model.StatusTransitionError{From: model.LoanStatusApproved, To: model.LoanStatusPaidOff},
+	}, nil, zap.NewNop())
+
+	_, err := h.RecordPayment(ctx, &pb.RecordPaymentRequest{LoanId: "loan-1", Amount: "500000", PaymentMethod: "transfer"})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	_, err = h.GetPayoffQuote(ctx, &pb.GetPayoffQuoteRequest{LoanId: "loan-1"})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	_, err = h.SettleLoan(ctx, &pb.SettleLoanRequest{LoanId: "loan-1", QuoteId: "quote-1", Amount: "500000", PaymentMethod: "transfer"})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
+
+func TestLoanHandler_LoanNotFound(t *testing.T) {
+	ctx := withClaims("user-1", "customer")
+	h := NewLoanHandler(&stubLoanUseCase{err: usecase.ErrLoanNotFound}, nil, zap.NewNop())
+
+	_, err := h.GetLoanStatus(ctx, &pb.GetLoanStatusRequest{LoanId: "loan-1"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = h.GetRepaymentSchedule(ctx, &pb.GetRepaymentScheduleRequest{LoanId: "loan-1"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = h.GetPayoffQuote(ctx, &pb.GetPayoffQuoteRequest{LoanId: "loan-1"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
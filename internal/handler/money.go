@@ -0,0 +1,20 @@
+package handler
+
+import (
+	"github.com/edosulai/pt-xyz-multifinance/internal/model"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// parseMoney parses a decimal amount from a request. An empty value is zero, like an unset number.
+func parseMoney(field, value string) (model.Money, error) {
+	if value == "" {
+		return 0, nil
+	}
+
+	amount, err := model.ParseMoney(value)
+	if err != nil {
+		return 0, status.Errorf(codes.InvalidArgument, "%s: %v", field, err)
+	}
+	return amount, nil
+}
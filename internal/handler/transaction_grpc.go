@@ -0,0 +1,96 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/edosulai/pt-xyz-multifinance/internal/model"
+	"github.com/edosulai/pt-xyz-multifinance/internal/usecase"
+	pb "github.com/edosulai/pt-xyz-multifinance/proto/gen/go/xyz/multifinance/v1"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type TransactionHandler struct {
+	pb.UnimplementedTransactionServiceServer
+	transactionUseCase usecase.TransactionUseCase
+	log                *zap.Logger
+}
+
+func NewTransactionHandler(transactionUseCase usecase.TransactionUseCase, log *zap.Logger) *TransactionHandler {
+	return &TransactionHandler{
+		transactionUseCase: transactionUseCase,
+		log:                log,
+	}
+}
+
+func (h *TransactionHandler) CreateTransaction(ctx context.Context, req *pb.CreateTransactionRequest) (*pb.Transaction, error) {
+	if err := requireSelfOrAdmin(ctx, req.UserId); err != nil {
+		return nil, err
+	}
+
+	otrPrice, err := parseMoney("otr_price", req.OtrPrice)
+	if err != nil {
+		return nil, err
+	}
+	adminFee, err := parseMoney("admin_fee", req.AdminFee)
+	if err != nil {
+		return nil, err
+	}
+
+	transaction := &model.Transaction{
+		UserID:       req.UserId,
+		TenureMonths: int(req.TenureMonths),
+		OTRPrice:     otrPrice,
+		AdminFee:     adminFee,
+		AssetName:    req.AssetName,
+		SalesChannel: req.SalesChannel,
+	}
+
+	if err := h.transactionUseCase.CreateTransaction(ctx, transaction); err != nil {
+		h.log.Error("Failed to create transaction", zap.Error(err))
+		return nil, toStatusError(err)
+	}
+
+	return convertTransactionToProto(transaction), nil
+}
+
+func (h *TransactionHandler) ListTransactions(ctx context.Context, req *pb.ListTransactionsRequest) (*pb.ListTransactionsResponse, error) {
+	if err := requireSelfOrAdmin(ctx, req.UserId); err != nil {
+		return nil, err
+	}
+
+	transactions, total, err := h.transactionUseCase.ListTransactions(ctx, req.UserId, int(req.Page), int(req.PageSize))
+	if err != nil {
+		h.log.Error("Failed to list transactions", zap.Error(err))
+		return nil, toStatusError(err)
+	}
+
+	response := &pb.ListTransactionsResponse{
+		Transactions: make([]*pb.Transaction, 0, len(transactions)),
+		Total:        int32(total),
+		Page:         req.Page,
+		PageSize:     req.PageSize,
+	}
+	for _, transaction := range transactions {
+		response.Transactions = append(response.Transactions, convertTransactionToProto(&transaction))
+	}
+
+	return response, nil
+}
+
+// Helper function to convert model.Transaction to proto Transaction
+func convertTransactionToProto(t *model.Transaction) *pb.Transaction {
+	return &pb.Transaction{
+		Id:                t.ID,
+		UserId:            t.UserID,
+		ContractNumber:    t.ContractNumber,
+		TenureMonths:      int32(t.TenureMonths),
+		OtrPrice:          t.OTRPrice.String(),
+		AdminFee:          t.AdminFee.String(),
+		InstallmentAmount: t.InstallmentAmount.String(),
+		InterestAmount:    t.InterestAmount.String(),
+		AssetName:         t.AssetName,
+		SalesChannel:      t.SalesChannel,
+		CreatedAt:         timestamppb.New(t.CreatedAt),
+	}
+}
@@ -27,6 +27,11 @@ func NewUserHandler(userUseCase usecase.UserUseCase, logger *zap.Logger) *UserHa
 }
 
 func (h *UserHandler) Register(ctx context.Context, req *pb.RegisterRequest) (*pb.RegisterResponse, error) {
+	monthlyIncome, err := parseMoney("monthly_income", req.MonthlyIncome)
+	if err != nil {
+		return nil, err
+	}
+
 	user := &model.User{
 		Username:      req.Username,
 		Email:         req.Email,
@@ -35,11 +40,11 @@ func (h *UserHandler) Register(ctx context.Context, req *pb.RegisterRequest) (*p
 		PhoneNumber:   req.PhoneNumber,
 		Address:       req.Address,
 		KTPNumber:     req.KtpNumber,
-		MonthlyIncome: req.MonthlyIncome,
+		MonthlyIncome: monthlyIncome,
 		Status:        "active",
 	}
 
-	err := h.userUseCase.Register(ctx, user)
+	err = h.userUseCase.Register(ctx, user)
 	if err != nil {
 		code := codes.Internal
 		msg := "failed to register user"
@@ -127,10 +132,16 @@ func (h *UserHandler) UpdateProfile(ctx context.Context, req *pb.UpdateProfileRe
 	user.Address = req.Address
 	user.KTPNumber = req.KtpNumber
 	user.FullName = req.FullName
-	user.MonthlyIncome = req.MonthlyIncome
+	user.MonthlyIncome, err = parseMoney("monthly_income", req.MonthlyIncome)
+	if err != nil {
+		return nil, err
+	}
 
 	err = h.userUseCase.UpdateProfile(ctx, user)
 	if err != nil {
+		if errors.As(err, &usecase.ValidationError{}) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
 		h.logger.Error("Failed to update user profile", zap.Error(err))
 		return nil, status.Error(codes.Internal, "failed to update user profile")
 	}
@@ -144,7 +155,7 @@ func convertUserToUserInfo(user *model.User) *pb.UserInfo {
 		return nil
 	}
 
-	return &pb.UserInfo{
+	info := &pb.UserInfo{
 		Id:            user.ID,
 		Username:      user.Username,
 		Email:         user.Email,
@@ -153,8 +164,14 @@ func convertUserToUserInfo(user *model.User) *pb.UserInfo {
 		Address:       user.Address,
 		KtpNumber:     user.KTPNumber,
 		Status:        user.Status,
-		MonthlyIncome: user.MonthlyIncome,
+		MonthlyIncome: user.MonthlyIncome.String(),
 		CreatedAt:     timestamppb.New(user.CreatedAt),
 		UpdatedAt:     timestamppb.New(user.UpdatedAt),
+		Gender:        string(user.Gender),
 	}
+	if user.DateOfBirth != nil {
+		info.DateOfBirth = user.DateOfBirth.Format("2006-01-02")
+	}
+
+	return info
 }
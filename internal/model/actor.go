@@ -0,0 +1,34 @@
+package model
+
+import "context"
+
+// ActorRole represents the role of whoever performs an action on a loan
+type ActorRole string
+
+const (
+	ActorRoleCustomer ActorRole = "customer"
+	ActorRoleAdmin    ActorRole = "admin"
+	ActorRoleSystem   ActorRole = "system"
+)
+
+// Actor identifies the user or process performing an action
+type Actor struct {
+	ID   string
+	Role ActorRole
+}
+
+// SystemActor is used by batch jobs and other automated processes
+var SystemActor = Actor{Role: ActorRoleSystem}
+
+type actorContextKey struct{}
+
+// ContextWithActor returns a copy of ctx carrying actor
+func ContextWithActor(ctx context.Context, actor Actor) context.Context {
+	return context.WithValue(ctx, actorContextKey{}, actor)
+}
+
+// ActorFromContext returns the actor carried by ctx, or an empty actor without a role
+func ActorFromContext(ctx context.Context) Actor {
+	actor, _ := ctx.Value(actorContextKey{}).(Actor)
+	return actor
+}
@@ -0,0 +1,33 @@
+package model
+
+import "time"
+
+// BankAccountStatus represents the verification status of a customer bank account
+type BankAccountStatus string
+
+const (
+	BankAccountStatusPending  BankAccountStatus = "pending"
+	BankAccountStatusVerified BankAccountStatus = "verified"
+	BankAccountStatusRejected BankAccountStatus = "rejected"
+)
+
+// BankAccount is a customer account that loans can be disbursed to once it is verified
+type BankAccount struct {
+	ID              string            `gorm:"primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
+	UserID          string            `gorm:"not null" json:"user_id"`
+	BankCode        string            `gorm:"not null" json:"bank_code" validate:"required,numeric,len=3"`
+	AccountNumber   string            `gorm:"not null" json:"account_number" validate:"required,numeric,min=6,max=20"`
+	HolderName      string            `gorm:"not null" json:"holder_name" validate:"required,max=100"`
+	Status          BankAccountStatus `gorm:"not null;default:'pending'" json:"status"`
+	RejectionReason string            `gorm:"type:text" json:"rejection_reason,omitempty"`
+	VerifiedBy      string            `json:"verified_by,omitempty"`
+	VerifiedAt      *time.Time        `json:"verified_at"`
+	User            User              `gorm:"foreignKey:UserID" json:"-"`
+	CreatedAt       time.Time         `json:"created_at"`
+	UpdatedAt       time.Time         `json:"updated_at"`
+}
+
+// IsVerified reports whether funds may be sent to the account
+func (a *BankAccount) IsVerified() bool {
+	return a.Status == BankAccountStatusVerified
+}
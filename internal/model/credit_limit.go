@@ -0,0 +1,35 @@
+package model
+
+import "time"
+
+// CreditLimit is the amount a customer may borrow for a given tenor
+type CreditLimit struct {
+	ID           string    `gorm:"primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
+	UserID       string    `gorm:"not null;uniqueIndex:idx_credit_limits_user_tenure" json:"user_id"`
+	TenureMonths int       `gorm:"not null;uniqueIndex:idx_credit_limits_user_tenure" json:"tenure_months"`
+	LimitAmount  Money     `gorm:"type:decimal(15,2);not null" json:"limit_amount"`
+	UsedAmount   Money     `gorm:"type:decimal(15,2);not null;default:0" json:"used_amount"`
+	User         User      `gorm:"foreignKey:UserID" json:"-"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// RemainingAmount returns the part of the limit that is not drawn down, never below zero
+func (c *CreditLimit) RemainingAmount() Money {
+	if c.UsedAmount >= c.LimitAmount {
+		return 0
+	}
+	return c.LimitAmount - c.UsedAmount
+}
+
+// CreditLimitUsage records the amount of a credit limit drawn down by a loan or a
+// financing transaction until it is released
+type CreditLimitUsage struct {
+	ID            string     `gorm:"primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
+	CreditLimitID string     `gorm:"not null" json:"credit_limit_id"`
+	LoanID        *string    `gorm:"uniqueIndex" json:"loan_id,omitempty"`
+	TransactionID *string    `gorm:"uniqueIndex" json:"transaction_id,omitempty"`
+	Amount        Money      `gorm:"type:decimal(15,2);not null" json:"amount"`
+	ReleasedAt    *time.Time `json:"released_at"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
@@ -0,0 +1,35 @@
+package model
+
+// RiskGrade buckets a credit score for pricing and approval
+type RiskGrade string
+
+const (
+	RiskGradeA RiskGrade = "A"
+	RiskGradeB RiskGrade = "B"
+	RiskGradeC RiskGrade = "C"
+	RiskGradeD RiskGrade = "D"
+	RiskGradeE RiskGrade = "E"
+)
+
+// Approvable reports whether applications with this grade may be approved
+func (g RiskGrade) Approvable() bool {
+	return g != RiskGradeE
+}
+
+// Credit score reason codes explaining the factors that lowered or raised a score
+const (
+	ReasonLowIncome            = "LOW_INCOME"
+	ReasonHighIncome           = "HIGH_INCOME"
+	ReasonHighAmountToIncome   = "HIGH_AMOUNT_TO_INCOME"
+	ReasonLowAmountToIncome    = "LOW_AMOUNT_TO_INCOME"
+	ReasonLongTenure           = "LONG_TENURE"
+	ReasonShortTenure          = "SHORT_TENURE"
+	ReasonHighDTI              = "HIGH_DTI"
+	ReasonNoCreditHistory      = "NO_CREDIT_HISTORY"
+	ReasonGoodRepaymentHistory = "GOOD_REPAYMENT_HISTORY"
+	ReasonPriorDefault         = "PRIOR_DEFAULT"
+	ReasonPriorRestructuring   = "PRIOR_RESTRUCTURING"
+	ReasonCurrentDelinquency   = "CURRENT_DELINQUENCY"
+	ReasonNewAccount           = "NEW_ACCOUNT"
+	ReasonEstablishedAccount   = "ESTABLISHED_ACCOUNT"
+)
@@ -0,0 +1,48 @@
+package model
+
+import "time"
+
+// Collectibility represents the OJK loan quality classification (Kolektibilitas)
+type Collectibility int
+
+const (
+	CollectibilityCurrent        Collectibility = 1 // Kol 1 - Lancar
+	CollectibilitySpecialMention Collectibility = 2 // Kol 2 - Dalam Perhatian Khusus
+	CollectibilitySubstandard    Collectibility = 3 // Kol 3 - Kurang Lancar
+	CollectibilityDoubtful       Collectibility = 4 // Kol 4 - Diragukan
+	CollectibilityLoss           Collectibility = 5 // Kol 5 - Macet
+)
+
+// CollectibilityForDPD returns the OJK collectibility bucket for the given days past due
+func CollectibilityForDPD(daysPastDue int) Collectibility {
+	switch {
+	case daysPastDue <= 0:
+		return CollectibilityCurrent
+	case daysPastDue <= 90:
+		return CollectibilitySpecialMention
+	case daysPastDue <= 120:
+		return CollectibilitySubstandard
+	case daysPastDue <= 180:
+		return CollectibilityDoubtful
+	default:
+		return CollectibilityLoss
+	}
+}
+
+// DelinquencyRecord captures a change of collectibility or status made by a delinquency evaluation
+type DelinquencyRecord struct {
+	ID                     string         `gorm:"primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
+	LoanID                 string         `gorm:"not null" json:"loan_id"`
+	AsOfDate               time.Time      `gorm:"type:date;not null" json:"as_of_date"`
+	DaysPastDue            int            `gorm:"not null" json:"days_past_due"`
+	PreviousCollectibility Collectibility `gorm:"not null" json:"previous_collectibility"`
+	Collectibility         Collectibility `gorm:"not null" json:"collectibility"`
+	PreviousStatus         LoanStatus     `gorm:"not null" json:"previous_status"`
+	Status                 LoanStatus     `gorm:"not null" json:"status"`
+	CreatedAt              time.Time      `json:"created_at"`
+}
+
+// TableName specifies the table name for the DelinquencyRecord model
+func (DelinquencyRecord) TableName() string {
+	return "loan_delinquency_history"
+}
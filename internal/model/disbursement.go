@@ -0,0 +1,69 @@
+package model
+
+import (
+	"fmt"
+	"time"
+)
+
+// DisbursementStatus represents the progress of a disbursement instruction at the bank
+type DisbursementStatus string
+
+const (
+	DisbursementStatusPending   DisbursementStatus = "pending"
+	DisbursementStatusSent      DisbursementStatus = "sent"
+	DisbursementStatusSucceeded DisbursementStatus = "succeeded"
+	DisbursementStatusFailed    DisbursementStatus = "failed"
+)
+
+// disbursementStatusTransitions declares every allowed status change of a disbursement instruction
+var disbursementStatusTransitions = map[DisbursementStatus][]DisbursementStatus{
+	DisbursementStatusPending: {DisbursementStatusSent, DisbursementStatusFailed},
+	DisbursementStatusSent:    {DisbursementStatusSucceeded, DisbursementStatusFailed},
+}
+
+// IsFinal reports whether the instruction has reached a terminal status
+func (s DisbursementStatus) IsFinal() bool {
+	return s == DisbursementStatusSucceeded || s == DisbursementStatusFailed
+}
+
+// CheckDisbursementTransition reports whether an instruction may move from one status to another
+func CheckDisbursementTransition(from, to DisbursementStatus) error {
+	for _, allowed := range disbursementStatusTransitions[from] {
+		if allowed == to {
+			return nil
+		}
+	}
+	return fmt.Errorf("cannot change disbursement status from %s to %s", from, to)
+}
+
+// DisbursementInstruction tells the bank to transfer loan funds to a verified customer account
+type DisbursementInstruction struct {
+	ID            string             `gorm:"primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
+	LoanID        string             `gorm:"not null" json:"loan_id"`
+	BankAccountID string             `gorm:"not null" json:"bank_account_id"`
+	Amount        Money              `gorm:"type:decimal(15,2);not null" json:"amount"`
+	Status        DisbursementStatus `gorm:"not null;default:'pending'" json:"status"`
+	// BankReference is the transfer reference returned by the bank once the instruction is sent
+	BankReference string `json:"bank_reference,omitempty"`
+	// BatchReference identifies the bank batch file the instruction was sent in
+	BatchReference string      `json:"batch_reference,omitempty"`
+	FailureReason  string      `gorm:"type:text" json:"failure_reason,omitempty"`
+	SentAt         *time.Time  `json:"sent_at"`
+	CompletedAt    *time.Time  `json:"completed_at"`
+	Loan           Loan        `gorm:"foreignKey:LoanID" json:"-"`
+	BankAccount    BankAccount `gorm:"foreignKey:BankAccountID" json:"-"`
+	CreatedAt      time.Time   `json:"created_at"`
+	UpdatedAt      time.Time   `json:"updated_at"`
+}
+
+// Disbursement is a tranche of a loan that reached the borrower. A loan may be released in
+// several tranches that together add up to at most its approved amount.
+type Disbursement struct {
+	ID            string    `gorm:"primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
+	LoanID        string    `gorm:"not null" json:"loan_id"`
+	InstructionID string    `gorm:"column:disbursement_instruction_id;not null" json:"instruction_id"`
+	TrancheNumber int       `gorm:"not null" json:"tranche_number"`
+	Amount        Money     `gorm:"type:decimal(15,2);not null" json:"amount"`
+	DisbursedAt   time.Time `gorm:"not null" json:"disbursed_at"`
+	CreatedAt     time.Time `json:"created_at"`
+}
@@ -0,0 +1,65 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// InstallmentStatus represents the status of a scheduled installment
+type InstallmentStatus string
+
+const (
+	InstallmentStatusPending       InstallmentStatus = "pending"
+	InstallmentStatusPartiallyPaid InstallmentStatus = "partially_paid"
+	InstallmentStatusPaid          InstallmentStatus = "paid"
+	InstallmentStatusClosed        InstallmentStatus = "closed"
+)
+
+// Installment represents a single period of a loan repayment schedule
+type Installment struct {
+	ID                string            `gorm:"primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
+	LoanID            string            `gorm:"not null" json:"loan_id"`
+	InstallmentNumber int               `gorm:"not null" json:"installment_number"`
+	DueDate           time.Time         `gorm:"type:date;not null" json:"due_date"`
+	PrincipalAmount   Money             `gorm:"type:decimal(15,2);not null" json:"principal_amount"`
+	InterestAmount    Money             `gorm:"type:decimal(15,2);not null" json:"interest_amount"`
+	TotalAmount       Money             `gorm:"type:decimal(15,2);not null" json:"total_amount"`
+	RemainingBalance  Money             `gorm:"type:decimal(15,2);not null" json:"remaining_balance"`
+	FeeAmount         Money             `gorm:"type:decimal(15,2);not null;default:0" json:"fee_amount"`
+	FeePaid           Money             `gorm:"type:decimal(15,2);not null;default:0" json:"fee_paid"`
+	InterestPaid      Money             `gorm:"type:decimal(15,2);not null;default:0" json:"interest_paid"`
+	PrincipalPaid     Money             `gorm:"type:decimal(15,2);not null;default:0" json:"principal_paid"`
+	Status            InstallmentStatus `gorm:"not null;default:'pending'" json:"status"`
+	RestructuringID   *string           `gorm:"type:uuid" json:"restructuring_id,omitempty"`
+	PaidAt            *time.Time        `json:"paid_at"`
+	Loan              Loan              `gorm:"foreignKey:LoanID" json:"-"`
+	CreatedAt         time.Time         `json:"created_at"`
+	UpdatedAt         time.Time         `json:"updated_at"`
+	DeletedAt         gorm.DeletedAt    `gorm:"index" json:"-"`
+}
+
+// IsOpen reports whether the installment still expects payment
+func (i *Installment) IsOpen() bool {
+	return i.Status != InstallmentStatusPaid && i.Status != InstallmentStatusClosed
+}
+
+// OutstandingAmount returns the unpaid fee, interest and principal of the installment
+func (i *Installment) OutstandingAmount() Money {
+	return (i.FeeAmount - i.FeePaid) + (i.InterestAmount - i.InterestPaid) + (i.PrincipalAmount - i.PrincipalPaid)
+}
+
+// BeforeCreate hook for Installment
+func (i *Installment) BeforeCreate(tx *gorm.DB) error {
+	if i.ID == "" {
+		i.CreatedAt = time.Now()
+	}
+	i.UpdatedAt = time.Now()
+	return nil
+}
+
+// BeforeUpdate hook for Installment
+func (i *Installment) BeforeUpdate(tx *gorm.DB) error {
+	i.UpdatedAt = time.Now()
+	return nil
+}
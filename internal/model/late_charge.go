@@ -0,0 +1,16 @@
+package model
+
+import "time"
+
+// LateCharge represents a penalty (denda) accrued for one day on an overdue installment
+type LateCharge struct {
+	ID            string      `gorm:"primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
+	LoanID        string      `gorm:"not null" json:"loan_id"`
+	InstallmentID string      `gorm:"not null" json:"installment_id"`
+	AccrualDate   time.Time   `gorm:"type:date;not null" json:"accrual_date"`
+	DaysPastDue   int         `gorm:"not null" json:"days_past_due"`
+	OverdueAmount Money       `gorm:"type:decimal(15,2);not null" json:"overdue_amount"`
+	Amount        Money       `gorm:"type:decimal(15,2);not null" json:"amount"`
+	Installment   Installment `gorm:"foreignKey:InstallmentID" json:"-"`
+	CreatedAt     time.Time   `json:"created_at"`
+}
@@ -10,14 +10,18 @@ import (
 type LoanStatus string
 
 const (
-	LoanStatusPending      LoanStatus = "pending"
-	LoanStatusInReview     LoanStatus = "in_review"
-	LoanStatusApproved     LoanStatus = "approved"
-	LoanStatusRejected     LoanStatus = "rejected"
-	LoanStatusDisbursed    LoanStatus = "disbursed"
-	LoanStatusPaidOff      LoanStatus = "paid_off"
-	LoanStatusDefaulted    LoanStatus = "defaulted"
-	LoanStatusRestructured LoanStatus = "restructured"
+	LoanStatusPending            LoanStatus = "pending"
+	LoanStatusInReview           LoanStatus = "in_review"
+	LoanStatusDocumentsNeeded    LoanStatus = "documents_needed"
+	LoanStatusOffered            LoanStatus = "offered"
+	LoanStatusApproved           LoanStatus = "approved"
+	LoanStatusRejected           LoanStatus = "rejected"
+	LoanStatusDeclined           LoanStatus = "declined"
+	LoanStatusPartiallyDisbursed LoanStatus = "partially_disbursed"
+	LoanStatusDisbursed          LoanStatus = "disbursed"
+	LoanStatusPaidOff            LoanStatus = "paid_off"
+	LoanStatusDefaulted          LoanStatus = "defaulted"
+	LoanStatusRestructured       LoanStatus = "restructured"
 )
 
 // DocumentStatus represents the status of a required document
@@ -30,6 +34,35 @@ const (
 	DocumentStatusRejected DocumentStatus = "rejected"
 )
 
+// DocumentReasonCode explains why an analyst rejected a document
+type DocumentReasonCode string
+
+const (
+	DocumentReasonIllegible          DocumentReasonCode = "illegible"
+	DocumentReasonExpired            DocumentReasonCode = "expired"
+	DocumentReasonNameMismatch       DocumentReasonCode = "name_mismatch"
+	DocumentReasonIncomplete         DocumentReasonCode = "incomplete"
+	DocumentReasonWrongDocument      DocumentReasonCode = "wrong_document"
+	DocumentReasonSuspectedTampering DocumentReasonCode = "suspected_tampering"
+	DocumentReasonOther              DocumentReasonCode = "other"
+)
+
+// documentReasonCodes lists every reason code an analyst may give
+var documentReasonCodes = map[DocumentReasonCode]bool{
+	DocumentReasonIllegible:          true,
+	DocumentReasonExpired:            true,
+	DocumentReasonNameMismatch:       true,
+	DocumentReasonIncomplete:         true,
+	DocumentReasonWrongDocument:      true,
+	DocumentReasonSuspectedTampering: true,
+	DocumentReasonOther:              true,
+}
+
+// IsKnown reports whether c is one of the defined reason codes
+func (c DocumentReasonCode) IsKnown() bool {
+	return documentReasonCodes[c]
+}
+
 // DocumentType represents the type of document required for loan
 type DocumentType string
 
@@ -42,21 +75,37 @@ const (
 
 // Loan represents a loan application in the system
 type Loan struct {
-	ID              string         `gorm:"primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
-	UserID          string         `gorm:"not null" json:"user_id"`
-	Amount          float64        `gorm:"type:decimal(15,2);not null" json:"amount" validate:"required,min=1000000"`
-	TenureMonths    int            `gorm:"not null" json:"tenure_months" validate:"required,min=6,max=60"`
-	Purpose         string         `gorm:"not null" json:"purpose" validate:"required"`
-	Status          LoanStatus     `gorm:"not null;default:'pending'" json:"status"`
-	MonthlyPayment  float64        `gorm:"type:decimal(15,2)" json:"monthly_payment"`
-	InterestRate    float64        `gorm:"type:decimal(5,2);not null" json:"interest_rate"`
-	DisbursedAmount float64        `gorm:"type:decimal(15,2)" json:"disbursed_amount"`
-	DisbursedAt     *time.Time     `json:"disbursed_at"`
-	Documents       []Document     `gorm:"foreignKey:LoanID" json:"documents"`
-	User            User           `gorm:"foreignKey:UserID" json:"-"`
-	CreatedAt       time.Time      `json:"created_at"`
-	UpdatedAt       time.Time      `json:"updated_at"`
-	DeletedAt       gorm.DeletedAt `gorm:"index" json:"-"`
+	ID             string         `gorm:"primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
+	UserID         string         `gorm:"not null" json:"user_id"`
+	ProductCode    string         `gorm:"type:varchar(30);not null" json:"product_code"`
+	Amount         Money          `gorm:"type:decimal(15,2);not null" json:"amount" validate:"required,gt=0"`
+	TenureMonths   int            `gorm:"not null" json:"tenure_months" validate:"required,gt=0"`
+	Purpose        string         `gorm:"not null" json:"purpose" validate:"required"`
+	Status         LoanStatus     `gorm:"not null;default:'pending'" json:"status"`
+	MonthlyPayment Money          `gorm:"type:decimal(15,2)" json:"monthly_payment"`
+	InterestRate   float64        `gorm:"type:decimal(5,2);not null" json:"interest_rate"`
+	InterestMethod InterestMethod `gorm:"type:varchar(20);not null;default:'annuity'" json:"interest_method"`
+	// EffectiveAnnualRate is the compounded yearly cost of the repayment schedule, in percent
+	EffectiveAnnualRate float64 `gorm:"type:decimal(8,4);not null;default:0" json:"effective_annual_rate"`
+	// DisbursedAmount totals the tranches released so far and DisbursedAt is when the first one landed
+	DisbursedAmount    Money          `gorm:"type:decimal(15,2)" json:"disbursed_amount"`
+	DisbursedAt        *time.Time     `json:"disbursed_at"`
+	OutstandingBalance Money          `gorm:"type:decimal(15,2);not null;default:0" json:"outstanding_balance"`
+	DaysPastDue        int            `gorm:"not null;default:0" json:"days_past_due"`
+	Collectibility     Collectibility `gorm:"not null;default:1" json:"collectibility"`
+	DTIRatio           float64        `gorm:"column:dti_ratio;type:decimal(6,2);not null;default:0" json:"dti_ratio"`
+	// AffordabilityFlagged marks applications accepted above the maximum debt-to-income ratio
+	AffordabilityFlagged bool           `gorm:"not null;default:false" json:"affordability_flagged"`
+	CreditScore          int            `gorm:"not null;default:0" json:"credit_score"`
+	RiskGrade            RiskGrade      `gorm:"type:varchar(1);not null;default:''" json:"risk_grade"`
+	ScoreReasonCodes     []string       `gorm:"type:text[]" json:"score_reason_codes"`
+	ScoringModel         string         `gorm:"type:varchar(50);not null;default:''" json:"scoring_model"`
+	ScoredAt             *time.Time     `json:"scored_at"`
+	Documents            []Document     `gorm:"foreignKey:LoanID" json:"documents"`
+	User                 User           `gorm:"foreignKey:UserID" json:"-"`
+	CreatedAt            time.Time      `json:"created_at"`
+	UpdatedAt            time.Time      `json:"updated_at"`
+	DeletedAt            gorm.DeletedAt `gorm:"index" json:"-"`
 }
 
 // Document represents a document required for loan processing
@@ -72,6 +121,82 @@ type Document struct {
 	CreatedAt  time.Time      `json:"created_at"`
 	UpdatedAt  time.Time      `json:"updated_at"`
 	DeletedAt  gorm.DeletedAt `gorm:"index" json:"-"`
+	// StorageKey locates an uploaded file in the blob store; documents submitted as a URL have none
+	StorageKey  string `gorm:"type:varchar(255)" json:"-"`
+	ContentType string `gorm:"type:varchar(100)" json:"content_type,omitempty"`
+	SizeBytes   int64  `json:"size_bytes,omitempty"`
+	// SHA256 is the hex digest of the uploaded file, checked whenever it is read back
+	SHA256 string `gorm:"column:sha256;type:char(64)" json:"sha256,omitempty"`
+	// ReasonCode and ReviewNote record why the analyst ReviewedBy verified or rejected the document
+	ReasonCode DocumentReasonCode `gorm:"type:varchar(30)" json:"reason_code,omitempty"`
+	ReviewNote string             `gorm:"type:text" json:"review_note,omitempty"`
+	ReviewedBy string             `json:"reviewed_by,omitempty"`
+	ReviewedAt *time.Time         `json:"reviewed_at"`
+	// Required marks documents created as a checklist slot when the loan was applied for; the
+	// flag stays set once the slot is filled
+	Required bool `gorm:"not null;default:false" json:"required"`
+}
+
+// DocumentReview is an analyst's decision on a submitted document
+type DocumentReview struct {
+	Status     DocumentStatus
+	ReasonCode DocumentReasonCode
+	Note       string
+}
+
+// DocumentsToReupload returns the document types whose latest submission was rejected, in the
+// order they were submitted. Documents must be ordered from oldest to newest.
+func (l *Loan) DocumentsToReupload() []DocumentType {
+	latest := make(map[DocumentType]DocumentStatus)
+	var order []DocumentType
+	for _, doc := range l.Documents {
+		if doc.Status == DocumentStatusRequired {
+			continue
+		}
+		if _, seen := latest[doc.Type]; !seen {
+			order = append(order, doc.Type)
+		}
+		latest[doc.Type] = doc.Status
+	}
+
+	var types []DocumentType
+	for _, docType := range order {
+		if latest[docType] == DocumentStatusRejected {
+			types = append(types, docType)
+		}
+	}
+	return types
+}
+
+// RequiredDocumentTypes returns the document types on the loan's checklist, in checklist order
+func (l *Loan) RequiredDocumentTypes() []DocumentType {
+	seen := make(map[DocumentType]bool)
+	var types []DocumentType
+	for _, doc := range l.Documents {
+		if doc.Required && !seen[doc.Type] {
+			seen[doc.Type] = true
+			types = append(types, doc.Type)
+		}
+	}
+	return types
+}
+
+// HasVerifiedDocument reports whether the latest submitted document of the given type has been
+// verified; a verified document replaced by a newer upload no longer counts. Documents must be
+// ordered from oldest to newest.
+func (l *Loan) HasVerifiedDocument(docType DocumentType) bool {
+	var latest DocumentStatus
+	for _, doc := range l.Documents {
+		if doc.Type == docType && doc.Status != DocumentStatusRequired {
+			latest = doc.Status
+		}
+	}
+	return latest == DocumentStatusVerified
+}
+
+// UndisbursedAmount returns the part of the approved amount not released yet
+func (l *Loan) UndisbursedAmount() Money {
+	return l.Amount - l.DisbursedAmount
 }
 
 // BeforeCreate hook for Loan
@@ -0,0 +1,45 @@
+package model
+
+import (
+	"fmt"
+	"time"
+)
+
+// OpenApplicationStatuses are the statuses of applications still waiting for a decision of
+// the lender or, once offered, of the applicant
+var OpenApplicationStatuses = []LoanStatus{
+	LoanStatusPending,
+	LoanStatusInReview,
+	LoanStatusDocumentsNeeded,
+	LoanStatusOffered,
+}
+
+// ApplicationPolicy limits the loan applications a user can have at once
+type ApplicationPolicy struct {
+	// MaxOpenApplications is how many applications a user may have in OpenApplicationStatuses;
+	// zero means no limit
+	MaxOpenApplications int
+	// DuplicateWindow is how long an application blocks another one for the same product,
+	// amount, tenure and purpose; zero disables the check
+	DuplicateWindow time.Duration
+}
+
+// DuplicateApplicationError is returned when a user applies again with the same details
+// within the duplicate window
+type DuplicateApplicationError struct {
+	LoanID string
+}
+
+func (e *DuplicateApplicationError) Error() string {
+	return fmt.Sprintf("an identical application %s was submitted moments ago", e.LoanID)
+}
+
+// OpenApplicationLimitError is returned when a user already has the maximum number of open
+// applications
+type OpenApplicationLimitError struct {
+	Max int
+}
+
+func (e *OpenApplicationLimitError) Error() string {
+	return fmt.Sprintf("at most %d loan applications can be open at a time; wait for a decision on the open ones", e.Max)
+}
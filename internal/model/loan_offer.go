@@ -0,0 +1,74 @@
+package model
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+)
+
+// LoanOfferTerms are the final terms an approved loan is offered to the applicant on
+type LoanOfferTerms struct {
+	LoanID              string         `json:"loan_id"`
+	ProductCode         string         `json:"product_code"`
+	Amount              Money          `json:"amount"`
+	TenureMonths        int            `json:"tenure_months"`
+	InterestRate        float64        `json:"interest_rate"`
+	InterestMethod      InterestMethod `json:"interest_method"`
+	EffectiveAnnualRate float64        `json:"effective_annual_rate"`
+	MonthlyPayment      Money          `json:"monthly_payment"`
+}
+
+// OfferTerms returns the terms the loan is currently offered on. Once accepted they stay fixed
+// while the loan is disbursed, tranche by tranche, until it is restructured.
+func (l *Loan) OfferTerms() LoanOfferTerms {
+	return LoanOfferTerms{
+		LoanID:              l.ID,
+		ProductCode:         l.ProductCode,
+		Amount:              l.Amount,
+		TenureMonths:        l.TenureMonths,
+		InterestRate:        l.InterestRate,
+		InterestMethod:      l.InterestMethod,
+		EffectiveAnnualRate: l.EffectiveAnnualRate,
+		MonthlyPayment:      l.MonthlyPayment,
+	}
+}
+
+// Encode returns the canonical JSON form of the terms and the hex SHA-256 digest of it. The
+// digest identifies the exact terms shown to the applicant.
+func (t LoanOfferTerms) Encode() ([]byte, string, error) {
+	data, err := json.Marshal(t)
+	if err != nil {
+		return nil, "", err
+	}
+	sum := sha256.Sum256(data)
+	return data, hex.EncodeToString(sum[:]), nil
+}
+
+// LoanOfferAcceptance records the applicant's consent to the terms of a loan offer
+type LoanOfferAcceptance struct {
+	ID     string `gorm:"primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
+	LoanID string `gorm:"not null;uniqueIndex" json:"loan_id"`
+	UserID string `gorm:"not null" json:"user_id"`
+	// Terms is the canonical JSON of the LoanOfferTerms accepted, stored byte for byte, and
+	// TermsHash its SHA-256 digest
+	Terms      json.RawMessage `gorm:"type:text;not null" json:"terms"`
+	TermsHash  string          `gorm:"type:char(64);not null" json:"terms_hash"`
+	AcceptedAt time.Time       `gorm:"not null" json:"accepted_at"`
+	// IPAddress and UserAgent identify the client the offer was accepted from
+	IPAddress string    `gorm:"type:varchar(45)" json:"ip_address"`
+	UserAgent string    `gorm:"type:text" json:"user_agent"`
+	Loan      Loan      `gorm:"foreignKey:LoanID" json:"-"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// TermsIntact reports whether the stored terms still hash to TermsHash
+func (a *LoanOfferAcceptance) TermsIntact() bool {
+	sum := sha256.Sum256(a.Terms)
+	return hex.EncodeToString(sum[:]) == a.TermsHash
+}
+
+// TableName specifies the table name for the LoanOfferAcceptance model
+func (LoanOfferAcceptance) TableName() string {
+	return "loan_offer_acceptances"
+}
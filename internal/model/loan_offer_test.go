@@ -0,0 +1,52 @@
+package model
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoanOfferTerms_Encode(t *testing.T) {
+	loan := &Loan{
+		ID: "loan-1", ProductCode: "PERSONAL", Amount: NewMoney(12000000), TenureMonths: 12,
+		InterestRate: 18, InterestMethod: InterestMethodAnnuity, EffectiveAnnualRate: 19.5618,
+		MonthlyPayment: MustParseMoney("1100159.91"),
+	}
+
+	terms, hash, err := loan.OfferTerms().Encode()
+	require.NoError(t, err)
+	assert.Len(t, hash, 64)
+
+	var decoded LoanOfferTerms
+	require.NoError(t, json.Unmarshal(terms, &decoded))
+	assert.Equal(t, loan.OfferTerms(), decoded)
+
+	_, again, err := loan.OfferTerms().Encode()
+	require.NoError(t, err)
+	assert.Equal(t, hash, again)
+
+	loan.InterestRate = 20
+	_, repriced, err := loan.OfferTerms().Encode()
+	require.NoError(t, err)
+	assert.NotEqual(t, hash, repriced)
+}
+
+func TestLoanOfferAcceptance_TermsIntact(t *testing.T) {
+	loan := &Loan{ID: "loan-1", Amount: NewMoney(12000000), TenureMonths: 12, InterestRate: 18}
+	terms, hash, err := loan.OfferTerms().Encode()
+	require.NoError(t, err)
+
+	acceptance := &LoanOfferAcceptance{Terms: terms, TermsHash: hash}
+	assert.True(t, acceptance.TermsIntact())
+
+	// The same terms with keys reordered, as a JSONB column would return them, no longer match
+	var fields map[string]interface{}
+	require.NoError(t, json.Unmarshal(terms, &fields))
+	reordered, err := json.Marshal(fields)
+	require.NoError(t, err)
+	require.NotEqual(t, string(terms), string(reordered))
+	acceptance.Terms = reordered
+	assert.False(t, acceptance.TermsIntact())
+}
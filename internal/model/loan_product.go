@@ -0,0 +1,59 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// InterestMethod determines how the interest of a loan is calculated
+type InterestMethod string
+
+const (
+	InterestMethodAnnuity   InterestMethod = "annuity"
+	InterestMethodFlat      InterestMethod = "flat"
+	InterestMethodEffective InterestMethod = "effective"
+)
+
+// LoanProduct defines the bounds, pricing and document requirements of a loan offering
+type LoanProduct struct {
+	ID                  string         `gorm:"primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
+	Code                string         `gorm:"uniqueIndex;not null" json:"code"`
+	Name                string         `gorm:"not null" json:"name"`
+	Description         string         `gorm:"type:text" json:"description"`
+	MinAmount           Money          `gorm:"type:decimal(15,2);not null" json:"min_amount"`
+	MaxAmount           Money          `gorm:"type:decimal(15,2);not null" json:"max_amount"`
+	TenureOptions       []int          `gorm:"type:integer[];not null" json:"tenure_options"`
+	InterestMethod      InterestMethod `gorm:"not null;default:'annuity'" json:"interest_method"`
+	MinInterestRate     float64        `gorm:"type:decimal(5,2);not null" json:"min_interest_rate"`
+	MaxInterestRate     float64        `gorm:"type:decimal(5,2);not null" json:"max_interest_rate"`
+	AdminFee            Money          `gorm:"type:decimal(15,2);not null;default:0" json:"admin_fee"`
+	ProvisionFeePercent float64        `gorm:"type:decimal(5,2);not null;default:0" json:"provision_fee_percent"`
+	// EarlyTerminationFeePercent is charged on the outstanding principal when a loan is paid off early
+	EarlyTerminationFeePercent float64        `gorm:"type:decimal(5,2);not null;default:0" json:"early_termination_fee_percent"`
+	RequiredDocuments          []DocumentType `gorm:"type:text[];not null" json:"required_documents"`
+	IsActive                   bool           `gorm:"not null;default:true" json:"is_active"`
+	CreatedAt                  time.Time      `json:"created_at"`
+	UpdatedAt                  time.Time      `json:"updated_at"`
+	DeletedAt                  gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// TableName specifies the table name for the LoanProduct model
+func (LoanProduct) TableName() string {
+	return "loan_products"
+}
+
+// AllowsTenure reports whether the product offers the given tenure
+func (p *LoanProduct) AllowsTenure(tenureMonths int) bool {
+	for _, option := range p.TenureOptions {
+		if option == tenureMonths {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowsInterestRate reports whether rate is within the product's pricing range
+func (p *LoanProduct) AllowsInterestRate(rate float64) bool {
+	return rate >= p.MinInterestRate && rate <= p.MaxInterestRate
+}
@@ -0,0 +1,43 @@
+package model
+
+import "time"
+
+// LoanSortField is a loan attribute search results can be ordered by
+type LoanSortField string
+
+const (
+	LoanSortCreatedAt LoanSortField = "created_at"
+	LoanSortAmount    LoanSortField = "amount"
+)
+
+// LoanSort orders search results; loans with equal values are ordered by ID in the same direction
+type LoanSort struct {
+	Field      LoanSortField
+	Descending bool
+}
+
+// LoanSearchFilter narrows a back-office loan search. Zero values leave a criterion open, and
+// date ranges include From but exclude To.
+type LoanSearchFilter struct {
+	Statuses      []LoanStatus
+	UserID        string
+	ProductCode   string
+	MinAmount     Money
+	MaxAmount     Money
+	CreatedFrom   *time.Time
+	CreatedTo     *time.Time
+	DisbursedFrom *time.Time
+	DisbursedTo   *time.Time
+}
+
+// LoanSearchKey is the position of a loan in the sort order; a page continues after it
+type LoanSearchKey struct {
+	CreatedAt time.Time
+	Amount    Money
+	ID        string
+}
+
+// SearchKey returns the position of the loan in the sort order
+func (l *Loan) SearchKey() LoanSearchKey {
+	return LoanSearchKey{CreatedAt: l.CreatedAt, Amount: l.Amount, ID: l.ID}
+}
@@ -0,0 +1,103 @@
+package model
+
+import (
+	"fmt"
+	"time"
+)
+
+// loanStatusTransitions declares every allowed status change and the roles that may perform it.
+// Loans are only paid off by payments posted by staff or the payment channel, never by the borrower.
+var loanStatusTransitions = map[LoanStatus]map[LoanStatus][]ActorRole{
+	LoanStatusPending: {
+		LoanStatusInReview: {ActorRoleCustomer, ActorRoleAdmin},
+		LoanStatusRejected: {ActorRoleAdmin, ActorRoleSystem},
+	},
+	LoanStatusInReview: {
+		LoanStatusOffered:         {ActorRoleAdmin, ActorRoleSystem},
+		LoanStatusRejected:        {ActorRoleAdmin, ActorRoleSystem},
+		LoanStatusDocumentsNeeded: {ActorRoleAdmin, ActorRoleSystem},
+	},
+	LoanStatusDocumentsNeeded: {
+		LoanStatusInReview: {ActorRoleCustomer, ActorRoleAdmin},
+		LoanStatusRejected: {ActorRoleAdmin, ActorRoleSystem},
+	},
+	// Only the applicant can accept or decline the offer made on approval
+	LoanStatusOffered: {
+		LoanStatusApproved: {ActorRoleCustomer},
+		LoanStatusDeclined: {ActorRoleCustomer},
+	},
+	LoanStatusApproved: {
+		LoanStatusPartiallyDisbursed: {ActorRoleAdmin, ActorRoleSystem},
+		LoanStatusDisbursed:          {ActorRoleAdmin, ActorRoleSystem},
+	},
+	LoanStatusPartiallyDisbursed: {
+		LoanStatusDisbursed: {ActorRoleAdmin, ActorRoleSystem},
+		LoanStatusPaidOff:   {ActorRoleAdmin, ActorRoleSystem},
+		LoanStatusDefaulted: {ActorRoleAdmin, ActorRoleSystem},
+	},
+	LoanStatusDisbursed: {
+		LoanStatusPaidOff:      {ActorRoleAdmin, ActorRoleSystem},
+		LoanStatusDefaulted:    {ActorRoleAdmin, ActorRoleSystem},
+		LoanStatusRestructured: {ActorRoleAdmin},
+	},
+	LoanStatusRestructured: {
+		LoanStatusPaidOff:   {ActorRoleAdmin, ActorRoleSystem},
+		LoanStatusDefaulted: {ActorRoleAdmin, ActorRoleSystem},
+	},
+	LoanStatusDefaulted: {
+		LoanStatusPaidOff:      {ActorRoleAdmin, ActorRoleSystem},
+		LoanStatusRestructured: {ActorRoleAdmin},
+	},
+}
+
+// StatusTransitionError is returned when a loan status change is not allowed
+type StatusTransitionError struct {
+	From LoanStatus
+	To   LoanStatus
+	Role ActorRole
+}
+
+func (e *StatusTransitionError) Error() string {
+	if e.Role == "" {
+		return fmt.Sprintf("cannot change loan status from %s to %s", e.From, e.To)
+	}
+	return fmt.Sprintf("%s cannot change loan status from %s to %s", e.Role, e.From, e.To)
+}
+
+// CheckLoanTransition reports whether role may move a loan from one status to another.
+// Staying in the same status is always allowed.
+func CheckLoanTransition(from, to LoanStatus, role ActorRole) error {
+	if from == to {
+		return nil
+	}
+
+	roles, ok := loanStatusTransitions[from][to]
+	if !ok {
+		return &StatusTransitionError{From: from, To: to}
+	}
+	for _, allowed := range roles {
+		if allowed == role {
+			return nil
+		}
+	}
+
+	return &StatusTransitionError{From: from, To: to, Role: role}
+}
+
+// LoanStatusHistory records a single status change of a loan
+type LoanStatusHistory struct {
+	ID         string     `gorm:"primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
+	LoanID     string     `gorm:"not null" json:"loan_id"`
+	FromStatus LoanStatus `gorm:"not null" json:"from_status"`
+	ToStatus   LoanStatus `gorm:"not null" json:"to_status"`
+	ActorID    string     `json:"actor_id,omitempty"`
+	ActorRole  ActorRole  `gorm:"not null" json:"actor_role"`
+	Reason     string     `gorm:"type:text" json:"reason"`
+	Loan       Loan       `gorm:"foreignKey:LoanID" json:"-"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// TableName specifies the table name for the LoanStatusHistory model
+func (LoanStatusHistory) TableName() string {
+	return "loan_status_history"
+}
@@ -0,0 +1,44 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoan_HasVerifiedDocument(t *testing.T) {
+	tests := []struct {
+		name      string
+		documents []Document
+		verified  bool
+	}{
+		{"nothing submitted", []Document{{Type: DocumentTypeKTP, Status: DocumentStatusRequired}}, false},
+		{"verified", []Document{
+			{Type: DocumentTypeKTP, Status: DocumentStatusRequired},
+			{Type: DocumentTypeKTP, Status: DocumentStatusVerified},
+		}, true},
+		{"verified then rejected re-upload", []Document{
+			{Type: DocumentTypeKTP, Status: DocumentStatusVerified},
+			{Type: DocumentTypeKTP, Status: DocumentStatusRejected},
+		}, false},
+		{"verified then re-uploaded awaiting review", []Document{
+			{Type: DocumentTypeKTP, Status: DocumentStatusVerified},
+			{Type: DocumentTypeKTP, Status: DocumentStatusUploaded},
+		}, false},
+		{"rejected then verified re-upload", []Document{
+			{Type: DocumentTypeKTP, Status: DocumentStatusRejected},
+			{Type: DocumentTypeKTP, Status: DocumentStatusVerified},
+		}, true},
+		{"only another type verified", []Document{
+			{Type: DocumentTypeKTP, Status: DocumentStatusRejected},
+			{Type: DocumentTypePayslip, Status: DocumentStatusVerified},
+		}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loan := &Loan{Documents: tt.documents}
+			assert.Equal(t, tt.verified, loan.HasVerifiedDocument(DocumentTypeKTP))
+		})
+	}
+}
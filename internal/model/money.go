@@ -0,0 +1,250 @@
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Money is an exact amount of Rupiah held in sen (1/100 Rupiah), matching DECIMAL(15,2) columns.
+// Every operation that can produce fractions of a sen rounds half away from zero.
+type Money int64
+
+// senPerRupiah is the number of sen in one Rupiah
+const senPerRupiah = 100
+
+// NewMoney returns an amount of whole Rupiah
+func NewMoney(rupiah int64) Money {
+	return Money(rupiah * senPerRupiah)
+}
+
+// MoneyFromFloat converts a Rupiah amount, rounding to the nearest sen
+func MoneyFromFloat(rupiah float64) Money {
+	return Money(math.Round(rupiah * senPerRupiah))
+}
+
+// ParseMoney parses a decimal Rupiah amount such as "1500000", "-12.5" or "1000.25".
+// More than two decimal places are rejected rather than silently rounded.
+func ParseMoney(s string) (Money, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, fmt.Errorf("invalid amount %q", s)
+	}
+
+	negative := false
+	digits := s
+	switch digits[0] {
+	case '-':
+		negative = true
+		digits = digits[1:]
+	case '+':
+		digits = digits[1:]
+	}
+
+	whole, fraction, hasFraction := strings.Cut(digits, ".")
+	if whole == "" && fraction == "" || hasFraction && fraction == "" || len(fraction) > 2 {
+		return 0, fmt.Errorf("invalid amount %q", s)
+	}
+	for len(fraction) < 2 {
+		fraction += "0"
+	}
+	if whole == "" {
+		whole = "0"
+	}
+
+	sen, err := strconv.ParseInt(whole+fraction, 10, 64)
+	if err != nil || strings.ContainsAny(whole+fraction, "+-") {
+		return 0, fmt.Errorf("invalid amount %q", s)
+	}
+	if negative {
+		sen = -sen
+	}
+
+	return Money(sen), nil
+}
+
+// MustParseMoney parses an amount and panics if it is invalid; intended for constants and tests
+func MustParseMoney(s string) Money {
+	m, err := ParseMoney(s)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// Sen returns the amount in sen
+func (m Money) Sen() int64 {
+	return int64(m)
+}
+
+// String formats the amount with exactly two decimal places, e.g. "1500000.00"
+func (m Money) String() string {
+	sign := ""
+	sen := int64(m)
+	if sen < 0 {
+		sign = "-"
+		sen = -sen
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, sen/senPerRupiah, sen%senPerRupiah)
+}
+
+// IsZero reports whether the amount is exactly zero
+func (m Money) IsZero() bool {
+	return m == 0
+}
+
+// IsPositive reports whether the amount is greater than zero
+func (m Money) IsPositive() bool {
+	return m > 0
+}
+
+// Mul multiplies the amount by a factor, rounding to the nearest sen.
+// The factor is taken as the decimal it prints as, so 0.1 is exactly one tenth.
+func (m Money) Mul(factor float64) Money {
+	return m.mulRat(decimalRat(factor))
+}
+
+// Percent returns percent percent of the amount, rounding to the nearest sen
+func (m Money) Percent(percent float64) Money {
+	rate := decimalRat(percent)
+	return m.mulRat(rate.Quo(rate, big.NewRat(100, 1)))
+}
+
+// MulRatio multiplies the amount by num/den, rounding to the nearest sen
+func (m Money) MulRatio(num, den int64) Money {
+	if den == 0 {
+		return 0
+	}
+	return m.mulRat(big.NewRat(num, den))
+}
+
+// Div divides the amount into n parts, rounding to the nearest sen
+func (m Money) Div(n int64) Money {
+	return m.MulRatio(1, n)
+}
+
+// RoundRupiah rounds the amount to whole Rupiah, half away from zero
+func (m Money) RoundRupiah() Money {
+	return Money(roundRat(big.NewRat(int64(m), senPerRupiah))) * senPerRupiah
+}
+
+func (m Money) mulRat(factor *big.Rat) Money {
+	product := new(big.Rat).Mul(big.NewRat(int64(m), 1), factor)
+	return Money(roundRat(product))
+}
+
+// MinMoney returns the smaller of two amounts
+func MinMoney(a, b Money) Money {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// MaxMoney returns the larger of two amounts
+func MaxMoney(a, b Money) Money {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// SumMoney adds up amounts
+func SumMoney(amounts ...Money) Money {
+	var total Money
+	for _, amount := range amounts {
+		total += amount
+	}
+	return total
+}
+
+// Scan implements sql.Scanner for DECIMAL columns, which the driver returns as text
+func (m *Money) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*m = 0
+		return nil
+	case []byte:
+		return m.scanDecimal(string(v))
+	case string:
+		return m.scanDecimal(v)
+	case int64:
+		*m = NewMoney(v)
+		return nil
+	case float64:
+		*m = MoneyFromFloat(v)
+		return nil
+	default:
+		return fmt.Errorf("cannot scan %T into Money", value)
+	}
+}
+
+// scanDecimal parses a column value, rounding columns with more scale than two decimals
+func (m *Money) scanDecimal(s string) error {
+	if parsed, err := ParseMoney(s); err == nil {
+		*m = parsed
+		return nil
+	}
+
+	rat, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return fmt.Errorf("cannot scan %q into Money", s)
+	}
+	*m = Money(roundRat(rat.Mul(rat, big.NewRat(senPerRupiah, 1))))
+	return nil
+}
+
+// Value implements driver.Valuer, passing the amount as an exact decimal string
+func (m Money) Value() (driver.Value, error) {
+	return m.String(), nil
+}
+
+// MarshalJSON encodes the amount as a decimal string so clients never see binary floating point
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.String())
+}
+
+// UnmarshalJSON accepts a decimal string or a JSON number
+func (m *Money) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		s = string(data)
+	}
+	parsed, err := ParseMoney(s)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+// decimalRat converts f to the exact decimal it prints as
+func decimalRat(f float64) *big.Rat {
+	rat, ok := new(big.Rat).SetString(strconv.FormatFloat(f, 'f', -1, 64))
+	if !ok {
+		return new(big.Rat)
+	}
+	return rat
+}
+
+// roundRat rounds r to the nearest integer, half away from zero
+func roundRat(r *big.Rat) int64 {
+	num := new(big.Int).Set(r.Num())
+	den := r.Denom()
+
+	negative := num.Sign() < 0
+	num.Abs(num)
+
+	quotient, remainder := new(big.Int).QuoRem(num, den, new(big.Int))
+	if remainder.Mul(remainder, big.NewInt(2)).Cmp(den) >= 0 {
+		quotient.Add(quotient, big.NewInt(1))
+	}
+	if negative {
+		quotient.Neg(quotient)
+	}
+	return quotient.Int64()
+}
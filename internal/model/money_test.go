@@ -0,0 +1,88 @@
+package model
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseMoney(t *testing.T) {
+	valid := map[string]Money{
+		"1500000":    NewMoney(1500000),
+		"1000.25":    Money(100025),
+		"0.5":        Money(50),
+		"-12.5":      Money(-1250),
+		" 42.00 ":    NewMoney(42),
+		".75":        Money(75),
+		"+3":         NewMoney(3),
+		"1066185.46": Money(106618546),
+	}
+	for input, want := range valid {
+		got, err := ParseMoney(input)
+		assert.NoError(t, err, input)
+		assert.Equal(t, want, got, input)
+	}
+
+	for _, input := range []string{"", "abc", "1.234", "1.", "--1", "1e6", "1,000"} {
+		_, err := ParseMoney(input)
+		assert.Error(t, err, input)
+	}
+}
+
+func TestMoney_String(t *testing.T) {
+	assert.Equal(t, "1500000.00", NewMoney(1500000).String())
+	assert.Equal(t, "0.05", Money(5).String())
+	assert.Equal(t, "-12.50", Money(-1250).String())
+}
+
+func TestMoney_Rounding(t *testing.T) {
+	// 0.1 + 0.2 is exact, unlike float64
+	assert.Equal(t, MustParseMoney("0.30"), MustParseMoney("0.10")+MustParseMoney("0.20"))
+
+	// Halves round away from zero
+	assert.Equal(t, Money(1), Money(5).Percent(10))
+	assert.Equal(t, Money(-1), Money(-5).Percent(10))
+	assert.Equal(t, MustParseMoney("3333333.33"), NewMoney(10000000).Div(3))
+	assert.Equal(t, MustParseMoney("0.67"), NewMoney(2).MulRatio(1, 3))
+	assert.Equal(t, MustParseMoney("1234.57"), MustParseMoney("12345.67").Mul(0.1))
+
+	assert.Equal(t, NewMoney(1235), MustParseMoney("1234.50").RoundRupiah())
+	assert.Equal(t, NewMoney(1234), MustParseMoney("1234.49").RoundRupiah())
+	assert.Equal(t, NewMoney(-1235), MustParseMoney("-1234.50").RoundRupiah())
+}
+
+func TestMoney_SQL(t *testing.T) {
+	var m Money
+	assert.NoError(t, m.Scan([]byte("1066185.46")))
+	assert.Equal(t, Money(106618546), m)
+
+	// Columns with more scale are rounded to the sen
+	assert.NoError(t, m.Scan("10.005"))
+	assert.Equal(t, Money(1001), m)
+
+	assert.NoError(t, m.Scan(nil))
+	assert.Equal(t, Money(0), m)
+
+	assert.Error(t, m.Scan("abc"))
+	assert.Error(t, m.Scan(true))
+
+	value, err := MustParseMoney("650000.10").Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "650000.10", value)
+}
+
+func TestMoney_JSON(t *testing.T) {
+	data, err := json.Marshal(struct {
+		Amount Money `json:"amount"`
+	}{Amount: MustParseMoney("1500.5")})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"amount":"1500.50"}`, string(data))
+
+	var m Money
+	assert.NoError(t, json.Unmarshal([]byte(`"99.99"`), &m))
+	assert.Equal(t, Money(9999), m)
+	assert.NoError(t, json.Unmarshal([]byte(`250`), &m))
+	assert.Equal(t, NewMoney(250), m)
+	assert.Error(t, json.Unmarshal([]byte(`"1.001"`), &m))
+}
@@ -0,0 +1,56 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// PaymentComponent represents the part of an installment a payment is allocated to
+type PaymentComponent string
+
+const (
+	PaymentComponentFee       PaymentComponent = "fee"
+	PaymentComponentInterest  PaymentComponent = "interest"
+	PaymentComponentPrincipal PaymentComponent = "principal"
+)
+
+// Payment represents a repayment received for a loan
+type Payment struct {
+	ID            string              `gorm:"primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
+	LoanID        string              `gorm:"not null" json:"loan_id"`
+	Amount        Money               `gorm:"type:decimal(15,2);not null" json:"amount" validate:"required,gt=0"`
+	PaymentMethod string              `gorm:"not null" json:"payment_method" validate:"required"`
+	Reference     string              `json:"reference"`
+	PaidAt        time.Time           `gorm:"not null" json:"paid_at"`
+	Allocations   []PaymentAllocation `gorm:"foreignKey:PaymentID" json:"allocations"`
+	Loan          Loan                `gorm:"foreignKey:LoanID" json:"-"`
+	CreatedAt     time.Time           `json:"created_at"`
+	UpdatedAt     time.Time           `json:"updated_at"`
+	DeletedAt     gorm.DeletedAt      `gorm:"index" json:"-"`
+}
+
+// PaymentAllocation represents the portion of a payment applied to one installment component
+type PaymentAllocation struct {
+	ID            string           `gorm:"primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
+	PaymentID     string           `gorm:"not null" json:"payment_id"`
+	InstallmentID string           `gorm:"not null" json:"installment_id"`
+	Component     PaymentComponent `gorm:"not null" json:"component"`
+	Amount        Money            `gorm:"type:decimal(15,2);not null" json:"amount"`
+	CreatedAt     time.Time        `json:"created_at"`
+}
+
+// BeforeCreate hook for Payment
+func (p *Payment) BeforeCreate(tx *gorm.DB) error {
+	if p.ID == "" {
+		p.CreatedAt = time.Now()
+	}
+	p.UpdatedAt = time.Now()
+	return nil
+}
+
+// BeforeUpdate hook for Payment
+func (p *Payment) BeforeUpdate(tx *gorm.DB) error {
+	p.UpdatedAt = time.Now()
+	return nil
+}
@@ -0,0 +1,35 @@
+package model
+
+import "time"
+
+// PayoffQuoteStatus represents the status of an early payoff quote
+type PayoffQuoteStatus string
+
+const (
+	PayoffQuoteStatusActive  PayoffQuoteStatus = "active"
+	PayoffQuoteStatusSettled PayoffQuoteStatus = "settled"
+)
+
+// PayoffQuote is the amount required to close a loan early, valid until ExpiresAt
+type PayoffQuote struct {
+	ID                   string            `gorm:"primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
+	LoanID               string            `gorm:"not null" json:"loan_id"`
+	OutstandingPrincipal Money             `gorm:"type:decimal(15,2);not null" json:"outstanding_principal"`
+	AccruedInterest      Money             `gorm:"type:decimal(15,2);not null" json:"accrued_interest"`
+	OutstandingFees      Money             `gorm:"type:decimal(15,2);not null" json:"outstanding_fees"`
+	EarlyTerminationFee  Money             `gorm:"type:decimal(15,2);not null" json:"early_termination_fee"`
+	TotalAmount          Money             `gorm:"type:decimal(15,2);not null" json:"total_amount"`
+	Status               PayoffQuoteStatus `gorm:"not null;default:'active'" json:"status"`
+	QuotedAt             time.Time         `gorm:"not null" json:"quoted_at"`
+	ExpiresAt            time.Time         `gorm:"not null" json:"expires_at"`
+	PaymentID            *string           `gorm:"type:uuid" json:"payment_id,omitempty"`
+	SettledAt            *time.Time        `json:"settled_at"`
+	Loan                 Loan              `gorm:"foreignKey:LoanID" json:"-"`
+	CreatedAt            time.Time         `json:"created_at"`
+	UpdatedAt            time.Time         `json:"updated_at"`
+}
+
+// IsExpired reports whether the quote can no longer be settled at t
+func (q *PayoffQuote) IsExpired(t time.Time) bool {
+	return t.After(q.ExpiresAt)
+}
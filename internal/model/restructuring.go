@@ -0,0 +1,23 @@
+package model
+
+import "time"
+
+// LoanRestructuring records the terms of a loan before and after a restructuring
+type LoanRestructuring struct {
+	ID                     string        `gorm:"primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
+	LoanID                 string        `gorm:"not null" json:"loan_id"`
+	PreviousStatus         LoanStatus    `gorm:"not null" json:"previous_status"`
+	PreviousTenureMonths   int           `gorm:"not null" json:"previous_tenure_months"`
+	PreviousInterestRate   float64       `gorm:"type:decimal(5,2);not null" json:"previous_interest_rate"`
+	PreviousMonthlyPayment Money         `gorm:"type:decimal(15,2)" json:"previous_monthly_payment"`
+	OutstandingPrincipal   Money         `gorm:"type:decimal(15,2);not null" json:"outstanding_principal"`
+	CapitalizedAmount      Money         `gorm:"type:decimal(15,2);not null;default:0" json:"capitalized_amount"`
+	NewTenureMonths        int           `gorm:"not null" json:"new_tenure_months"`
+	NewInterestRate        float64       `gorm:"type:decimal(5,2);not null" json:"new_interest_rate"`
+	GracePeriodMonths      int           `gorm:"not null;default:0" json:"grace_period_months"`
+	NewMonthlyPayment      Money         `gorm:"type:decimal(15,2);not null" json:"new_monthly_payment"`
+	Reason                 string        `gorm:"type:text" json:"reason"`
+	Installments           []Installment `gorm:"foreignKey:RestructuringID" json:"installments,omitempty"`
+	Loan                   Loan          `gorm:"foreignKey:LoanID" json:"-"`
+	CreatedAt              time.Time     `json:"created_at"`
+}
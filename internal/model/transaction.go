@@ -0,0 +1,40 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Transaction represents a consumer financing contract for the purchase of an asset
+type Transaction struct {
+	ID                string         `gorm:"primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
+	UserID            string         `gorm:"not null" json:"user_id"`
+	ContractNumber    string         `gorm:"uniqueIndex;not null" json:"contract_number"`
+	TenureMonths      int            `gorm:"not null" json:"tenure_months" validate:"required,min=1,max=60"`
+	OTRPrice          Money          `gorm:"column:otr_price;type:decimal(15,2);not null" json:"otr_price" validate:"required,gt=0"`
+	AdminFee          Money          `gorm:"type:decimal(15,2);not null;default:0" json:"admin_fee" validate:"min=0"`
+	InstallmentAmount Money          `gorm:"type:decimal(15,2);not null" json:"installment_amount"`
+	InterestAmount    Money          `gorm:"type:decimal(15,2);not null" json:"interest_amount"`
+	AssetName         string         `gorm:"not null" json:"asset_name" validate:"required"`
+	SalesChannel      string         `gorm:"not null" json:"sales_channel" validate:"required"`
+	User              User           `gorm:"foreignKey:UserID" json:"-"`
+	CreatedAt         time.Time      `json:"created_at"`
+	UpdatedAt         time.Time      `json:"updated_at"`
+	DeletedAt         gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// BeforeCreate hook for Transaction
+func (t *Transaction) BeforeCreate(tx *gorm.DB) error {
+	if t.ID == "" {
+		t.CreatedAt = time.Now()
+	}
+	t.UpdatedAt = time.Now()
+	return nil
+}
+
+// BeforeUpdate hook for Transaction
+func (t *Transaction) BeforeUpdate(tx *gorm.DB) error {
+	t.UpdatedAt = time.Now()
+	return nil
+}
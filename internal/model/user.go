@@ -6,7 +6,16 @@ import (
 	"gorm.io/gorm"
 )
 
-// User represents the user entity in the database
+// Gender of a user as encoded in their NIK
+type Gender string
+
+const (
+	GenderMale   Gender = "male"
+	GenderFemale Gender = "female"
+)
+
+// User represents the user entity in the database. DateOfBirth and Gender are derived from
+// the KTP number.
 type User struct {
 	ID                  string         `gorm:"primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
 	Username            string         `gorm:"uniqueIndex;not null" json:"username" validate:"required,min=3,max=50"`
@@ -16,8 +25,11 @@ type User struct {
 	PhoneNumber         string         `gorm:"unique;not null" json:"phone_number" validate:"required,e164"`
 	Address             string         `gorm:"type:text;not null" json:"address" validate:"required"`
 	KTPNumber           string         `gorm:"unique;not null" json:"ktp_number" validate:"required,len=16"`
+	DateOfBirth         *time.Time     `gorm:"type:date" json:"date_of_birth,omitempty"`
+	Gender              Gender         `gorm:"type:varchar(10)" json:"gender,omitempty"`
 	Status              string         `gorm:"not null;default:'active'" json:"status" validate:"required,oneof=active inactive suspended"`
-	MonthlyIncome       float64        `gorm:"type:decimal(15,2);not null" json:"monthly_income" validate:"required,min=0"`
+	Role                ActorRole      `gorm:"not null;default:'customer'" json:"role"`
+	MonthlyIncome       Money          `gorm:"type:decimal(15,2);not null" json:"monthly_income" validate:"required,min=0"`
 	FailedLoginAttempts int            `gorm:"default:0" json:"failed_login_attempts"`
 	LastFailedLogin     *time.Time     `json:"last_failed_login,omitempty"`
 	LockedUntil         *time.Time     `json:"locked_until,omitempty"`
@@ -0,0 +1,22 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/edosulai/pt-xyz-multifinance/internal/model"
+)
+
+// BankAccountRepository defines the interface for customer bank account data access
+type BankAccountRepository interface {
+	// Create a bank account, failing with ErrBankAccountExists when the user already registered it
+	Create(ctx context.Context, account *model.BankAccount) error
+
+	// Get bank account by ID
+	GetByID(ctx context.Context, id string) (*model.BankAccount, error)
+
+	// Get the bank accounts of a user, newest first
+	GetByUserID(ctx context.Context, userID string) ([]model.BankAccount, error)
+
+	// Update the verification status, reason and verifier of a bank account
+	UpdateVerification(ctx context.Context, account *model.BankAccount) error
+}
@@ -0,0 +1,151 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/edosulai/pt-xyz-multifinance/internal/model"
+	"github.com/edosulai/pt-xyz-multifinance/pkg/database"
+	"github.com/lib/pq"
+)
+
+// ErrBankAccountExists is returned when a user registers the same bank account twice
+var ErrBankAccountExists = errors.New("bank account already registered")
+
+// bankAccountColumns lists the bank account columns in the order scanBankAccount reads them
+const bankAccountColumns = `
+			id, user_id, bank_code, account_number, holder_name, status,
+			rejection_reason, verified_by, verified_at, created_at, updated_at`
+
+// BankAccountRepositoryImpl implements BankAccountRepository interface using native SQL
+type BankAccountRepositoryImpl struct {
+	db *database.DB
+}
+
+// NewBankAccountRepository creates a new bank account repository instance
+func NewBankAccountRepository(db *database.DB) BankAccountRepository {
+	return &BankAccountRepositoryImpl{db: db}
+}
+
+// Create inserts a new bank account
+func (r *BankAccountRepositoryImpl) Create(ctx context.Context, account *model.BankAccount) error {
+	query := `
+		INSERT INTO bank_accounts (
+			user_id, bank_code, account_number, holder_name, status, created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id`
+
+	now := time.Now()
+	account.CreatedAt = now
+	account.UpdatedAt = now
+
+	err := r.db.QueryRowContext(ctx, query,
+		account.UserID, account.BankCode, account.AccountNumber, account.HolderName, account.Status,
+		account.CreatedAt, account.UpdatedAt,
+	).Scan(&account.ID)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" { // unique_violation
+			return ErrBankAccountExists
+		}
+		return fmt.Errorf("failed to create bank account: %v", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a bank account by ID
+func (r *BankAccountRepositoryImpl) GetByID(ctx context.Context, id string) (*model.BankAccount, error) {
+	query := `
+		SELECT ` + bankAccountColumns + `
+		FROM bank_accounts
+		WHERE id = $1`
+
+	account := &model.BankAccount{}
+	err := scanBankAccount(r.db.QueryRowContext(ctx, query, id), account)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bank account: %v", err)
+	}
+
+	return account, nil
+}
+
+// GetByUserID retrieves the bank accounts of a user
+func (r *BankAccountRepositoryImpl) GetByUserID(ctx context.Context, userID string) ([]model.BankAccount, error) {
+	query := `
+		SELECT ` + bankAccountColumns + `
+		FROM bank_accounts
+		WHERE user_id = $1
+		ORDER BY created_at DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bank accounts: %v", err)
+	}
+	defer rows.Close()
+
+	var accounts []model.BankAccount
+	for rows.Next() {
+		var account model.BankAccount
+		if err := scanBankAccount(rows, &account); err != nil {
+			return nil, fmt.Errorf("failed to scan bank account: %v", err)
+		}
+		accounts = append(accounts, account)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating bank accounts: %v", err)
+	}
+
+	return accounts, nil
+}
+
+// UpdateVerification records the outcome of verifying a bank account
+func (r *BankAccountRepositoryImpl) UpdateVerification(ctx context.Context, account *model.BankAccount) error {
+	query := `
+		UPDATE bank_accounts
+		SET status = $1, rejection_reason = $2, verified_by = $3, verified_at = $4, updated_at = $5
+		WHERE id = $6`
+
+	account.UpdatedAt = time.Now()
+
+	result, err := r.db.ExecContext(ctx, query,
+		account.Status, account.RejectionReason, account.VerifiedBy, account.VerifiedAt, account.UpdatedAt,
+		account.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update bank account: %v", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %v", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("bank account not found")
+	}
+
+	return nil
+}
+
+func scanBankAccount(row rowScanner, account *model.BankAccount) error {
+	var verifiedAt sql.NullTime
+	err := row.Scan(
+		&account.ID, &account.UserID, &account.BankCode, &account.AccountNumber, &account.HolderName, &account.Status,
+		&account.RejectionReason, &account.VerifiedBy, &verifiedAt, &account.CreatedAt, &account.UpdatedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	if verifiedAt.Valid {
+		account.VerifiedAt = &verifiedAt.Time
+	}
+
+	return nil
+}
@@ -0,0 +1,25 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/edosulai/pt-xyz-multifinance/internal/model"
+)
+
+// CreditLimitRepository defines the interface for customer credit limit data access
+type CreditLimitRepository interface {
+	// Get all credit limits of a user ordered by tenor
+	GetByUserID(ctx context.Context, userID string) ([]model.CreditLimit, error)
+
+	// Get the credit limit of a user for one tenor
+	GetByUserAndTenure(ctx context.Context, userID string, tenureMonths int) (*model.CreditLimit, error)
+
+	// Create or replace the limit amount of a user for one tenor, keeping the used amount
+	Upsert(ctx context.Context, limit *model.CreditLimit) error
+
+	// Draw down the limit of a user for a loan, failing with ErrCreditLimitExceeded when it is not sufficient
+	Consume(ctx context.Context, userID string, tenureMonths int, loanID string, amount model.Money) error
+
+	// Return the amount drawn down by a loan to its credit limit
+	Release(ctx context.Context, loanID string) error
+}
@@ -0,0 +1,182 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/edosulai/pt-xyz-multifinance/internal/model"
+	"github.com/edosulai/pt-xyz-multifinance/pkg/database"
+)
+
+// ErrCreditLimitExceeded is returned when a draw down is larger than the remaining credit limit
+var ErrCreditLimitExceeded = errors.New("credit limit exceeded")
+
+// CreditLimitRepositoryImpl implements CreditLimitRepository interface using native SQL
+type CreditLimitRepositoryImpl struct {
+	db *database.DB
+}
+
+// NewCreditLimitRepository creates a new credit limit repository instance
+func NewCreditLimitRepository(db *database.DB) CreditLimitRepository {
+	return &CreditLimitRepositoryImpl{db: db}
+}
+
+// GetByUserID retrieves all credit limits of a user
+func (r *CreditLimitRepositoryImpl) GetByUserID(ctx context.Context, userID string) ([]model.CreditLimit, error) {
+	query := `
+		SELECT id, user_id, tenure_months, limit_amount, used_amount, created_at, updated_at
+		FROM credit_limits
+		WHERE user_id = $1
+		ORDER BY tenure_months ASC`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get credit limits: %v", err)
+	}
+	defer rows.Close()
+
+	var limits []model.CreditLimit
+	for rows.Next() {
+		var limit model.CreditLimit
+		err := rows.Scan(
+			&limit.ID, &limit.UserID, &limit.TenureMonths, &limit.LimitAmount,
+			&limit.UsedAmount, &limit.CreatedAt, &limit.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan credit limit: %v", err)
+		}
+		limits = append(limits, limit)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating credit limits: %v", err)
+	}
+
+	return limits, nil
+}
+
+// GetByUserAndTenure retrieves the credit limit of a user for one tenor
+func (r *CreditLimitRepositoryImpl) GetByUserAndTenure(ctx context.Context, userID string, tenureMonths int) (*model.CreditLimit, error) {
+	query := `
+		SELECT id, user_id, tenure_months, limit_amount, used_amount, created_at, updated_at
+		FROM credit_limits
+		WHERE user_id = $1 AND tenure_months = $2`
+
+	limit := &model.CreditLimit{}
+	err := r.db.QueryRowContext(ctx, query, userID, tenureMonths).Scan(
+		&limit.ID, &limit.UserID, &limit.TenureMonths, &limit.LimitAmount,
+		&limit.UsedAmount, &limit.CreatedAt, &limit.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get credit limit: %v", err)
+	}
+
+	return limit, nil
+}
+
+// Upsert sets the limit amount of a user for one tenor
+func (r *CreditLimitRepositoryImpl) Upsert(ctx context.Context, limit *model.CreditLimit) error {
+	query := `
+		INSERT INTO credit_limits (
+			user_id, tenure_months, limit_amount, created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $4)
+		ON CONFLICT (user_id, tenure_months)
+		DO UPDATE SET limit_amount = EXCLUDED.limit_amount, updated_at = EXCLUDED.updated_at
+		RETURNING id, used_amount, created_at, updated_at`
+
+	err := r.db.QueryRowContext(ctx, query,
+		limit.UserID, limit.TenureMonths, limit.LimitAmount, time.Now(),
+	).Scan(&limit.ID, &limit.UsedAmount, &limit.CreatedAt, &limit.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to set credit limit: %v", err)
+	}
+
+	return nil
+}
+
+// Consume draws down the credit limit of a user for a loan and records the usage
+func (r *CreditLimitRepositoryImpl) Consume(ctx context.Context, userID string, tenureMonths int, loanID string, amount model.Money) error {
+	return r.db.WithTx(ctx, func(tx *sql.Tx) error {
+		limitID, err := consumeCreditLimit(ctx, tx, userID, tenureMonths, amount)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO credit_limit_usages (credit_limit_id, loan_id, amount, created_at)
+			VALUES ($1, $2, $3, $4)`,
+			limitID, loanID, amount, time.Now(),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to record credit limit usage: %v", err)
+		}
+
+		return nil
+	})
+}
+
+// consumeCreditLimit draws down the limit of a user for one tenor within tx and returns the limit ID.
+// It returns ErrCreditLimitExceeded when the limit is missing or not sufficient.
+func consumeCreditLimit(ctx context.Context, tx *sql.Tx, userID string, tenureMonths int, amount model.Money) (string, error) {
+	var limitID string
+	err := tx.QueryRowContext(ctx, `
+		UPDATE credit_limits
+		SET used_amount = used_amount + $1, updated_at = $2
+		WHERE user_id = $3 AND tenure_months = $4 AND used_amount + $1 <= limit_amount
+		RETURNING id`,
+		amount, time.Now(), userID, tenureMonths,
+	).Scan(&limitID)
+	if err == sql.ErrNoRows {
+		return "", ErrCreditLimitExceeded
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to consume credit limit: %v", err)
+	}
+
+	return limitID, nil
+}
+
+// Release returns the amount drawn down by a loan to its credit limit
+func (r *CreditLimitRepositoryImpl) Release(ctx context.Context, loanID string) error {
+	return r.db.WithTx(ctx, func(tx *sql.Tx) error {
+		return releaseCreditLimit(ctx, tx, loanID)
+	})
+}
+
+// releaseCreditLimit releases the unreleased usages of a loan within tx. It is a no-op when there are none.
+func releaseCreditLimit(ctx context.Context, tx *sql.Tx, loanID string) error {
+	return releaseCreditLimitUsages(ctx, tx, "loan_id", loanID)
+}
+
+// releaseCreditLimitUsages marks the unreleased usages where column equals value as released and
+// returns their amounts to the credit limits they were drawn from
+func releaseCreditLimitUsages(ctx context.Context, tx *sql.Tx, column, value string) error {
+	_, err := tx.ExecContext(ctx, `
+		WITH released AS (
+			UPDATE credit_limit_usages
+			SET released_at = $1
+			WHERE `+column+` = $2 AND released_at IS NULL
+			RETURNING credit_limit_id, amount
+		)
+		UPDATE credit_limits cl
+		SET used_amount = GREATEST(cl.used_amount - r.amount, 0), updated_at = $1
+		FROM (
+			SELECT credit_limit_id, SUM(amount) AS amount
+			FROM released
+			GROUP BY credit_limit_id
+		) r
+		WHERE cl.id = r.credit_limit_id`,
+		time.Now(), value,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to release credit limit: %v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,36 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/edosulai/pt-xyz-multifinance/internal/model"
+)
+
+// DisbursementRepository defines the interface for disbursement instruction data access
+type DisbursementRepository interface {
+	// Create a new disbursement instruction and reserve its amount on the borrower's credit limit
+	Create(ctx context.Context, instruction *model.DisbursementInstruction, loan *model.Loan) error
+
+	// Get disbursement instruction by ID
+	GetByID(ctx context.Context, id string) (*model.DisbursementInstruction, error)
+
+	// Get the disbursement instructions of a loan, oldest first
+	GetByLoanID(ctx context.Context, loanID string) ([]model.DisbursementInstruction, error)
+
+	// Move an instruction to its new status, failing with ErrDisbursementStatusChanged
+	// when it is no longer in the from status
+	UpdateStatus(ctx context.Context, instruction *model.DisbursementInstruction, from model.DisbursementStatus) error
+
+	// Mark every pending instruction as sent in batchReference and pass them, with their bank
+	// accounts, to fn. The instructions go back to pending when fn fails.
+	ClaimPending(ctx context.Context, batchReference string, fn func(instructions []model.DisbursementInstruction) error) error
+
+	// Mark an instruction as succeeded, record the tranche it released and reschedule the loan
+	Complete(ctx context.Context, instruction *model.DisbursementInstruction, from model.DisbursementStatus, tranche *model.Disbursement, loan *model.Loan, installments []model.Installment) error
+
+	// Get the tranches released for a loan, in order
+	GetTranches(ctx context.Context, loanID string) ([]model.Disbursement, error)
+
+	// Mark an instruction as failed and release the credit limit it reserved
+	Fail(ctx context.Context, instruction *model.DisbursementInstruction, from model.DisbursementStatus) error
+}
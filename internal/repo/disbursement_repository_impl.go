@@ -0,0 +1,374 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/edosulai/pt-xyz-multifinance/internal/model"
+	"github.com/edosulai/pt-xyz-multifinance/pkg/database"
+	"github.com/lib/pq"
+)
+
+var (
+	// ErrDisbursementStatusChanged is returned when an instruction was updated concurrently
+	ErrDisbursementStatusChanged = errors.New("disbursement status was modified concurrently")
+	// ErrDisbursementInProgress is returned when a loan already has a disbursement pending or sent
+	ErrDisbursementInProgress = errors.New("loan already has a disbursement in progress")
+)
+
+// disbursementColumns lists the disbursement instruction columns in the order scanDisbursement reads them
+const disbursementColumns = `
+			id, loan_id, bank_account_id, amount, status, bank_reference,
+			batch_reference, failure_reason, sent_at, completed_at, created_at, updated_at`
+
+// DisbursementRepositoryImpl implements DisbursementRepository interface using native SQL
+type DisbursementRepositoryImpl struct {
+	db *database.DB
+}
+
+// NewDisbursementRepository creates a new disbursement repository instance
+func NewDisbursementRepository(db *database.DB) DisbursementRepository {
+	return &DisbursementRepositoryImpl{db: db}
+}
+
+// Create inserts a new disbursement instruction, drawing its amount down from the borrower's
+// credit limit in the same transaction. A failed disbursement releases the amount again.
+// Only one instruction of a loan may be pending or sent at a time.
+func (r *DisbursementRepositoryImpl) Create(ctx context.Context, instruction *model.DisbursementInstruction, loan *model.Loan) error {
+	return r.db.WithTx(ctx, func(tx *sql.Tx) error {
+		limitID, err := consumeCreditLimit(ctx, tx, loan.UserID, loan.TenureMonths, instruction.Amount)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		instruction.CreatedAt = now
+		instruction.UpdatedAt = now
+
+		err = tx.QueryRowContext(ctx, `
+			INSERT INTO disbursement_instructions (
+				loan_id, bank_account_id, amount, status, created_at, updated_at
+			) VALUES ($1, $2, $3, $4, $5, $6)
+			RETURNING id`,
+			instruction.LoanID, instruction.BankAccountID, instruction.Amount, instruction.Status,
+			instruction.CreatedAt, instruction.UpdatedAt,
+		).Scan(&instruction.ID)
+		if err != nil {
+			if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" { // unique_violation
+				return ErrDisbursementInProgress
+			}
+			return fmt.Errorf("failed to create disbursement instruction: %v", err)
+		}
+
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO credit_limit_usages (credit_limit_id, loan_id, disbursement_instruction_id, amount, created_at)
+			VALUES ($1, $2, $3, $4, $5)`,
+			limitID, instruction.LoanID, instruction.ID, instruction.Amount, now,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to record credit limit usage: %v", err)
+		}
+
+		return nil
+	})
+}
+
+// GetByID retrieves a disbursement instruction by ID
+func (r *DisbursementRepositoryImpl) GetByID(ctx context.Context, id string) (*model.DisbursementInstruction, error) {
+	query := `
+		SELECT ` + disbursementColumns + `
+		FROM disbursement_instructions
+		WHERE id = $1`
+
+	instruction := &model.DisbursementInstruction{}
+	err := scanDisbursement(r.db.QueryRowContext(ctx, query, id), instruction)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get disbursement instruction: %v", err)
+	}
+
+	return instruction, nil
+}
+
+// GetByLoanID retrieves the disbursement instructions of a loan
+func (r *DisbursementRepositoryImpl) GetByLoanID(ctx context.Context, loanID string) ([]model.DisbursementInstruction, error) {
+	query := `
+		SELECT ` + disbursementColumns + `
+		FROM disbursement_instructions
+		WHERE loan_id = $1
+		ORDER BY created_at ASC`
+
+	rows, err := r.db.QueryContext(ctx, query, loanID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get disbursement instructions: %v", err)
+	}
+	defer rows.Close()
+
+	var instructions []model.DisbursementInstruction
+	for rows.Next() {
+		var instruction model.DisbursementInstruction
+		if err := scanDisbursement(rows, &instruction); err != nil {
+			return nil, fmt.Errorf("failed to scan disbursement instruction: %v", err)
+		}
+		instructions = append(instructions, instruction)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating disbursement instructions: %v", err)
+	}
+
+	return instructions, nil
+}
+
+// UpdateStatus moves an instruction out of the from status, so two updates racing on the
+// same instruction cannot both succeed
+func (r *DisbursementRepositoryImpl) UpdateStatus(ctx context.Context, instruction *model.DisbursementInstruction, from model.DisbursementStatus) error {
+	return r.db.WithTx(ctx, func(tx *sql.Tx) error {
+		return updateDisbursementStatus(ctx, tx, instruction, from)
+	})
+}
+
+// ClaimPending marks the pending instructions as sent and hands them to fn, typically to write
+// the bank batch file. Rows are claimed with a conditional update, so concurrent exports never
+// send the same instruction twice.
+func (r *DisbursementRepositoryImpl) ClaimPending(ctx context.Context, batchReference string, fn func(instructions []model.DisbursementInstruction) error) error {
+	return r.db.WithTx(ctx, func(tx *sql.Tx) error {
+		now := time.Now()
+
+		rows, err := tx.QueryContext(ctx, `
+			UPDATE disbursement_instructions
+			SET status = $1, batch_reference = $2, sent_at = $3, updated_at = $3
+			WHERE status = $4
+			RETURNING `+disbursementColumns,
+			model.DisbursementStatusSent, batchReference, now, model.DisbursementStatusPending,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to claim pending disbursement instructions: %v", err)
+		}
+
+		var instructions []model.DisbursementInstruction
+		var accountIDs []string
+		for rows.Next() {
+			var instruction model.DisbursementInstruction
+			if err := scanDisbursement(rows, &instruction); err != nil {
+				rows.Close()
+				return fmt.Errorf("failed to scan disbursement instruction: %v", err)
+			}
+			instructions = append(instructions, instruction)
+			accountIDs = append(accountIDs, instruction.BankAccountID)
+		}
+		rows.Close()
+		if err = rows.Err(); err != nil {
+			return fmt.Errorf("error iterating disbursement instructions: %v", err)
+		}
+
+		accounts, err := getBankAccounts(ctx, tx, accountIDs)
+		if err != nil {
+			return err
+		}
+		for i := range instructions {
+			instructions[i].BankAccount = accounts[instructions[i].BankAccountID]
+		}
+
+		// Keep the file order stable across reruns of the same data
+		sort.Slice(instructions, func(i, j int) bool {
+			return instructions[i].CreatedAt.Before(instructions[j].CreatedAt)
+		})
+
+		return fn(instructions)
+	})
+}
+
+// Complete marks an instruction as succeeded, records the tranche it released and reschedules
+// the loan in a single transaction. The tranche is added to the disbursed amount and outstanding
+// balance of the loan, and installments replace the schedule from their first number onward.
+// Transitions not allowed for the actor in ctx return a *model.StatusTransitionError.
+func (r *DisbursementRepositoryImpl) Complete(ctx context.Context, instruction *model.DisbursementInstruction, from model.DisbursementStatus, tranche *model.Disbursement, loan *model.Loan, installments []model.Installment) error {
+	return r.db.WithTx(ctx, func(tx *sql.Tx) error {
+		if err := updateDisbursementStatus(ctx, tx, instruction, from); err != nil {
+			return err
+		}
+
+		reason := "loan disbursed"
+		if loan.Status == model.LoanStatusPartiallyDisbursed {
+			reason = "loan tranche disbursed"
+		}
+		if err := changeLoanStatus(ctx, tx, loan.ID, loan.Status, reason); err != nil {
+			return err
+		}
+
+		// The loan row is locked, so the tranche number cannot be taken twice
+		now := time.Now()
+		tranche.LoanID = loan.ID
+		tranche.InstructionID = instruction.ID
+		tranche.CreatedAt = now
+		err := tx.QueryRowContext(ctx, `
+			INSERT INTO disbursements (
+				loan_id, disbursement_instruction_id, tranche_number, amount, disbursed_at, created_at
+			)
+			SELECT $1, $2, COALESCE(MAX(tranche_number), 0) + 1, $3, $4, $5
+			FROM disbursements
+			WHERE loan_id = $1
+			RETURNING id, tranche_number`,
+			tranche.LoanID, tranche.InstructionID, tranche.Amount, tranche.DisbursedAt, tranche.CreatedAt,
+		).Scan(&tranche.ID, &tranche.TrancheNumber)
+		if err != nil {
+			return fmt.Errorf("failed to record disbursement tranche: %v", err)
+		}
+
+		loan.UpdatedAt = now
+		var disbursedAt sql.NullTime
+		err = tx.QueryRowContext(ctx, `
+			UPDATE loans
+			SET status = $1, monthly_payment = $2, effective_annual_rate = $3,
+				disbursed_amount = disbursed_amount + $4, outstanding_balance = outstanding_balance + $4,
+				disbursed_at = COALESCE(disbursed_at, $5), updated_at = $6
+			WHERE id = $7 AND disbursed_amount + $4 <= amount AND deleted_at IS NULL
+			RETURNING disbursed_amount, outstanding_balance, disbursed_at`,
+			loan.Status, loan.MonthlyPayment, loan.EffectiveAnnualRate,
+			tranche.Amount, tranche.DisbursedAt, loan.UpdatedAt, loan.ID,
+		).Scan(&loan.DisbursedAmount, &loan.OutstandingBalance, &disbursedAt)
+		if err == sql.ErrNoRows {
+			return ErrLoanBalanceChanged
+		}
+		if err != nil {
+			return fmt.Errorf("failed to update loan: %v", err)
+		}
+		if disbursedAt.Valid {
+			loan.DisbursedAt = &disbursedAt.Time
+		}
+
+		return rescheduleInstallments(ctx, tx, loan.ID, installments)
+	})
+}
+
+// GetTranches retrieves the tranches released for a loan, in order
+func (r *DisbursementRepositoryImpl) GetTranches(ctx context.Context, loanID string) ([]model.Disbursement, error) {
+	query := `
+		SELECT id, loan_id, disbursement_instruction_id, tranche_number, amount, disbursed_at, created_at
+		FROM disbursements
+		WHERE loan_id = $1
+		ORDER BY tranche_number ASC`
+
+	rows, err := r.db.QueryContext(ctx, query, loanID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get disbursement tranches: %v", err)
+	}
+	defer rows.Close()
+
+	var tranches []model.Disbursement
+	for rows.Next() {
+		var tranche model.Disbursement
+		err := rows.Scan(
+			&tranche.ID, &tranche.LoanID, &tranche.InstructionID, &tranche.TrancheNumber,
+			&tranche.Amount, &tranche.DisbursedAt, &tranche.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan disbursement tranche: %v", err)
+		}
+		tranches = append(tranches, tranche)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating disbursement tranches: %v", err)
+	}
+
+	return tranches, nil
+}
+
+// Fail marks an instruction as failed and releases the credit limit reserved when it was created.
+// Tranches released earlier keep their share of the limit.
+func (r *DisbursementRepositoryImpl) Fail(ctx context.Context, instruction *model.DisbursementInstruction, from model.DisbursementStatus) error {
+	return r.db.WithTx(ctx, func(tx *sql.Tx) error {
+		if err := updateDisbursementStatus(ctx, tx, instruction, from); err != nil {
+			return err
+		}
+
+		return releaseCreditLimitUsages(ctx, tx, "disbursement_instruction_id", instruction.ID)
+	})
+}
+
+// updateDisbursementStatus moves an instruction out of the from status within tx.
+// It returns ErrDisbursementStatusChanged when the instruction is no longer in that status.
+func updateDisbursementStatus(ctx context.Context, tx *sql.Tx, instruction *model.DisbursementInstruction, from model.DisbursementStatus) error {
+	instruction.UpdatedAt = time.Now()
+
+	result, err := tx.ExecContext(ctx, `
+		UPDATE disbursement_instructions
+		SET status = $1, bank_reference = $2, failure_reason = $3, sent_at = $4, completed_at = $5, updated_at = $6
+		WHERE id = $7 AND status = $8`,
+		instruction.Status, instruction.BankReference, instruction.FailureReason, instruction.SentAt,
+		instruction.CompletedAt, instruction.UpdatedAt, instruction.ID, from,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update disbursement instruction: %v", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %v", err)
+	}
+	if rows == 0 {
+		return ErrDisbursementStatusChanged
+	}
+
+	return nil
+}
+
+// getBankAccounts loads bank accounts by ID within tx, keyed by ID
+func getBankAccounts(ctx context.Context, tx *sql.Tx, ids []string) (map[string]model.BankAccount, error) {
+	accounts := make(map[string]model.BankAccount, len(ids))
+	if len(ids) == 0 {
+		return accounts, nil
+	}
+
+	rows, err := tx.QueryContext(ctx, `
+		SELECT `+bankAccountColumns+`
+		FROM bank_accounts
+		WHERE id = ANY($1)`, pq.Array(ids))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bank accounts: %v", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var account model.BankAccount
+		if err := scanBankAccount(rows, &account); err != nil {
+			return nil, fmt.Errorf("failed to scan bank account: %v", err)
+		}
+		accounts[account.ID] = account
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating bank accounts: %v", err)
+	}
+
+	return accounts, nil
+}
+
+func scanDisbursement(row rowScanner, instruction *model.DisbursementInstruction) error {
+	var sentAt, completedAt sql.NullTime
+	err := row.Scan(
+		&instruction.ID, &instruction.LoanID, &instruction.BankAccountID, &instruction.Amount, &instruction.Status,
+		&instruction.BankReference, &instruction.BatchReference, &instruction.FailureReason, &sentAt, &completedAt,
+		&instruction.CreatedAt, &instruction.UpdatedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	if sentAt.Valid {
+		instruction.SentAt = &sentAt.Time
+	}
+	if completedAt.Valid {
+		instruction.CompletedAt = &completedAt.Time
+	}
+
+	return nil
+}
@@ -0,0 +1,20 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"github.com/edosulai/pt-xyz-multifinance/internal/model"
+)
+
+// InstallmentRepository defines the interface for repayment schedule data access
+type InstallmentRepository interface {
+	// Replace the active schedule of a loan with the given installments
+	ReplaceForLoan(ctx context.Context, loanID string, installments []model.Installment) error
+
+	// Get installments by loan ID ordered by installment number
+	GetByLoanID(ctx context.Context, loanID string) ([]model.Installment, error)
+
+	// Get unpaid installments due before the given date on active loans
+	GetOverdue(ctx context.Context, asOf time.Time) ([]model.Installment, error)
+}
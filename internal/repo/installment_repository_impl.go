@@ -0,0 +1,172 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/edosulai/pt-xyz-multifinance/internal/model"
+	"github.com/edosulai/pt-xyz-multifinance/pkg/database"
+)
+
+// InstallmentRepositoryImpl implements InstallmentRepository interface using native SQL
+type InstallmentRepositoryImpl struct {
+	db *database.DB
+}
+
+// NewInstallmentRepository creates a new installment repository instance
+func NewInstallmentRepository(db *database.DB) InstallmentRepository {
+	return &InstallmentRepositoryImpl{db: db}
+}
+
+// ReplaceForLoan soft-deletes the current schedule of a loan and inserts the new one atomically
+func (r *InstallmentRepositoryImpl) ReplaceForLoan(ctx context.Context, loanID string, installments []model.Installment) error {
+	return r.db.WithTx(ctx, func(tx *sql.Tx) error {
+		now := time.Now()
+
+		_, err := tx.ExecContext(ctx, `
+			UPDATE installments
+			SET deleted_at = $1
+			WHERE loan_id = $2 AND deleted_at IS NULL`, now, loanID)
+		if err != nil {
+			return fmt.Errorf("failed to remove existing installments: %v", err)
+		}
+
+		return insertInstallments(ctx, tx, loanID, installments, now)
+	})
+}
+
+// rescheduleInstallments replaces the part of a schedule from the first of installments onward
+// within tx. Only untouched pending installments are replaced; ErrLoanBalanceChanged is returned
+// when one of them has received a payment in the meantime.
+func rescheduleInstallments(ctx context.Context, tx *sql.Tx, loanID string, installments []model.Installment) error {
+	if len(installments) == 0 {
+		return nil
+	}
+	now := time.Now()
+	firstNumber := installments[0].InstallmentNumber
+
+	_, err := tx.ExecContext(ctx, `
+		UPDATE installments
+		SET deleted_at = $1
+		WHERE loan_id = $2 AND installment_number >= $3 AND status = $4
+			AND fee_paid = 0 AND interest_paid = 0 AND principal_paid = 0
+			AND deleted_at IS NULL`,
+		now, loanID, firstNumber, model.InstallmentStatusPending,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to remove rescheduled installments: %v", err)
+	}
+
+	var paid bool
+	err = tx.QueryRowContext(ctx, `
+		SELECT EXISTS (
+			SELECT 1 FROM installments
+			WHERE loan_id = $1 AND installment_number >= $2 AND deleted_at IS NULL
+		)`, loanID, firstNumber,
+	).Scan(&paid)
+	if err != nil {
+		return fmt.Errorf("failed to check rescheduled installments: %v", err)
+	}
+	if paid {
+		return ErrLoanBalanceChanged
+	}
+
+	return insertInstallments(ctx, tx, loanID, installments, now)
+}
+
+// insertInstallments inserts the installments of a loan within tx
+func insertInstallments(ctx context.Context, tx *sql.Tx, loanID string, installments []model.Installment, now time.Time) error {
+	query := `
+		INSERT INTO installments (
+			loan_id, installment_number, due_date, principal_amount,
+			interest_amount, total_amount, remaining_balance, status,
+			created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING id`
+
+	for i := range installments {
+		inst := &installments[i]
+		inst.LoanID = loanID
+		inst.CreatedAt = now
+		inst.UpdatedAt = now
+
+		err := tx.QueryRowContext(ctx, query,
+			inst.LoanID, inst.InstallmentNumber, inst.DueDate, inst.PrincipalAmount,
+			inst.InterestAmount, inst.TotalAmount, inst.RemainingBalance, inst.Status,
+			inst.CreatedAt, inst.UpdatedAt,
+		).Scan(&inst.ID)
+		if err != nil {
+			return fmt.Errorf("failed to create installment: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// GetByLoanID retrieves the active schedule of a loan
+func (r *InstallmentRepositoryImpl) GetByLoanID(ctx context.Context, loanID string) ([]model.Installment, error) {
+	query := `
+		SELECT
+			id, loan_id, installment_number, due_date, principal_amount,
+			interest_amount, total_amount, remaining_balance, fee_amount,
+			fee_paid, interest_paid, principal_paid, status, paid_at,
+			restructuring_id, created_at, updated_at
+		FROM installments
+		WHERE loan_id = $1 AND deleted_at IS NULL
+		ORDER BY installment_number ASC`
+
+	return r.queryInstallments(ctx, query, loanID)
+}
+
+// GetOverdue retrieves unpaid installments due before asOf on loans that are being repaid
+func (r *InstallmentRepositoryImpl) GetOverdue(ctx context.Context, asOf time.Time) ([]model.Installment, error) {
+	query := `
+		SELECT
+			i.id, i.loan_id, i.installment_number, i.due_date, i.principal_amount,
+			i.interest_amount, i.total_amount, i.remaining_balance, i.fee_amount,
+			i.fee_paid, i.interest_paid, i.principal_paid, i.status, i.paid_at,
+			i.restructuring_id, i.created_at, i.updated_at
+		FROM installments i
+		JOIN loans l ON l.id = i.loan_id
+		WHERE i.due_date < $1
+			AND i.status NOT IN ($2, $3)
+			AND i.deleted_at IS NULL
+			AND l.status IN ($4, $5, $6, $7)
+			AND l.deleted_at IS NULL
+		ORDER BY i.loan_id, i.installment_number ASC`
+
+	return r.queryInstallments(ctx, query, asOf,
+		model.InstallmentStatusPaid, model.InstallmentStatusClosed,
+		model.LoanStatusPartiallyDisbursed, model.LoanStatusDisbursed, model.LoanStatusRestructured, model.LoanStatusDefaulted)
+}
+
+func (r *InstallmentRepositoryImpl) queryInstallments(ctx context.Context, query string, args ...interface{}) ([]model.Installment, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get installments: %v", err)
+	}
+	defer rows.Close()
+
+	var installments []model.Installment
+	for rows.Next() {
+		var inst model.Installment
+		err := rows.Scan(
+			&inst.ID, &inst.LoanID, &inst.InstallmentNumber, &inst.DueDate, &inst.PrincipalAmount,
+			&inst.InterestAmount, &inst.TotalAmount, &inst.RemainingBalance, &inst.FeeAmount,
+			&inst.FeePaid, &inst.InterestPaid, &inst.PrincipalPaid, &inst.Status, &inst.PaidAt,
+			&inst.RestructuringID, &inst.CreatedAt, &inst.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan installment: %v", err)
+		}
+		installments = append(installments, inst)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating installments: %v", err)
+	}
+
+	return installments, nil
+}
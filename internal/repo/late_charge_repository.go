@@ -0,0 +1,20 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"github.com/edosulai/pt-xyz-multifinance/internal/model"
+)
+
+// LateChargeRepository defines the interface for penalty accrual data access
+type LateChargeRepository interface {
+	// Persist accrued charges and add them to the fee amount of their installments
+	CreateBatch(ctx context.Context, charges []model.LateCharge) (int, error)
+
+	// Get the total accrued and the last accrual date for an installment
+	GetAccrualSummary(ctx context.Context, installmentID string) (model.Money, *time.Time, error)
+
+	// Get late charges by loan ID
+	GetByLoanID(ctx context.Context, loanID string) ([]model.LateCharge, error)
+}
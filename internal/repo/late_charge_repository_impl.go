@@ -0,0 +1,123 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/edosulai/pt-xyz-multifinance/internal/model"
+	"github.com/edosulai/pt-xyz-multifinance/pkg/database"
+)
+
+// LateChargeRepositoryImpl implements LateChargeRepository interface using native SQL
+type LateChargeRepositoryImpl struct {
+	db *database.DB
+}
+
+// NewLateChargeRepository creates a new late charge repository instance
+func NewLateChargeRepository(db *database.DB) LateChargeRepository {
+	return &LateChargeRepositoryImpl{db: db}
+}
+
+// CreateBatch inserts the charges in one transaction and returns how many were newly created.
+// Charges already accrued for the same installment and date are skipped so reruns are safe.
+func (r *LateChargeRepositoryImpl) CreateBatch(ctx context.Context, charges []model.LateCharge) (int, error) {
+	created := 0
+	err := r.db.WithTx(ctx, func(tx *sql.Tx) error {
+		now := time.Now()
+		for i := range charges {
+			charge := &charges[i]
+			charge.CreatedAt = now
+
+			err := tx.QueryRowContext(ctx, `
+				INSERT INTO late_charges (
+					loan_id, installment_id, accrual_date, days_past_due,
+					overdue_amount, amount, created_at
+				) VALUES ($1, $2, $3, $4, $5, $6, $7)
+				ON CONFLICT (installment_id, accrual_date) DO NOTHING
+				RETURNING id`,
+				charge.LoanID, charge.InstallmentID, charge.AccrualDate, charge.DaysPastDue,
+				charge.OverdueAmount, charge.Amount, charge.CreatedAt,
+			).Scan(&charge.ID)
+			if err == sql.ErrNoRows {
+				continue
+			}
+			if err != nil {
+				return fmt.Errorf("failed to create late charge: %v", err)
+			}
+
+			_, err = tx.ExecContext(ctx, `
+				UPDATE installments
+				SET fee_amount = fee_amount + $1, updated_at = $2
+				WHERE id = $3 AND deleted_at IS NULL`,
+				charge.Amount, now, charge.InstallmentID,
+			)
+			if err != nil {
+				return fmt.Errorf("failed to update installment fee: %v", err)
+			}
+			created++
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return created, nil
+}
+
+// GetAccrualSummary returns the total charges accrued for an installment and the latest accrual date
+func (r *LateChargeRepositoryImpl) GetAccrualSummary(ctx context.Context, installmentID string) (model.Money, *time.Time, error) {
+	query := `
+		SELECT COALESCE(SUM(amount), 0), MAX(accrual_date)
+		FROM late_charges
+		WHERE installment_id = $1`
+
+	var total model.Money
+	var lastAccrual sql.NullTime
+	if err := r.db.QueryRowContext(ctx, query, installmentID).Scan(&total, &lastAccrual); err != nil {
+		return 0, nil, fmt.Errorf("failed to get late charge summary: %v", err)
+	}
+
+	if !lastAccrual.Valid {
+		return total, nil, nil
+	}
+	return total, &lastAccrual.Time, nil
+}
+
+// GetByLoanID retrieves all late charges for a loan
+func (r *LateChargeRepositoryImpl) GetByLoanID(ctx context.Context, loanID string) ([]model.LateCharge, error) {
+	query := `
+		SELECT
+			id, loan_id, installment_id, accrual_date, days_past_due,
+			overdue_amount, amount, created_at
+		FROM late_charges
+		WHERE loan_id = $1
+		ORDER BY accrual_date ASC`
+
+	rows, err := r.db.QueryContext(ctx, query, loanID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get late charges: %v", err)
+	}
+	defer rows.Close()
+
+	var charges []model.LateCharge
+	for rows.Next() {
+		var charge model.LateCharge
+		err := rows.Scan(
+			&charge.ID, &charge.LoanID, &charge.InstallmentID, &charge.AccrualDate, &charge.DaysPastDue,
+			&charge.OverdueAmount, &charge.Amount, &charge.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan late charge: %v", err)
+		}
+		charges = append(charges, charge)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating late charges: %v", err)
+	}
+
+	return charges, nil
+}
@@ -0,0 +1,16 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/edosulai/pt-xyz-multifinance/internal/model"
+)
+
+// LoanProductRepository defines the interface for loan product catalog data access
+type LoanProductRepository interface {
+	// Get a product by its code, including inactive products
+	GetByCode(ctx context.Context, code string) (*model.LoanProduct, error)
+
+	// List the products open for new applications ordered by code
+	ListActive(ctx context.Context) ([]model.LoanProduct, error)
+}
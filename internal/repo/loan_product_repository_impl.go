@@ -0,0 +1,101 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/edosulai/pt-xyz-multifinance/internal/model"
+	"github.com/edosulai/pt-xyz-multifinance/pkg/database"
+	"github.com/lib/pq"
+)
+
+// loanProductColumns lists the loan product columns read by scanLoanProduct, in scan order
+const loanProductColumns = `
+			id, code, name, COALESCE(description, ''), min_amount, max_amount, tenure_options,
+			interest_method, min_interest_rate, max_interest_rate, admin_fee, provision_fee_percent,
+			early_termination_fee_percent, required_documents, is_active, created_at, updated_at`
+
+// scanLoanProduct scans a row selected with loanProductColumns into product
+func scanLoanProduct(row rowScanner, product *model.LoanProduct) error {
+	var tenures pq.Int64Array
+	var documents pq.StringArray
+	err := row.Scan(
+		&product.ID, &product.Code, &product.Name, &product.Description, &product.MinAmount, &product.MaxAmount, &tenures,
+		&product.InterestMethod, &product.MinInterestRate, &product.MaxInterestRate, &product.AdminFee, &product.ProvisionFeePercent,
+		&product.EarlyTerminationFeePercent, &documents, &product.IsActive, &product.CreatedAt, &product.UpdatedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	product.TenureOptions = make([]int, 0, len(tenures))
+	for _, tenure := range tenures {
+		product.TenureOptions = append(product.TenureOptions, int(tenure))
+	}
+	product.RequiredDocuments = make([]model.DocumentType, 0, len(documents))
+	for _, doc := range documents {
+		product.RequiredDocuments = append(product.RequiredDocuments, model.DocumentType(doc))
+	}
+
+	return nil
+}
+
+// LoanProductRepositoryImpl implements LoanProductRepository interface using native SQL
+type LoanProductRepositoryImpl struct {
+	db *database.DB
+}
+
+// NewLoanProductRepository creates a new loan product repository instance
+func NewLoanProductRepository(db *database.DB) LoanProductRepository {
+	return &LoanProductRepositoryImpl{db: db}
+}
+
+// GetByCode retrieves a loan product by its code
+func (r *LoanProductRepositoryImpl) GetByCode(ctx context.Context, code string) (*model.LoanProduct, error) {
+	query := `
+		SELECT ` + loanProductColumns + `
+		FROM loan_products
+		WHERE code = $1 AND deleted_at IS NULL`
+
+	product := &model.LoanProduct{}
+	err := scanLoanProduct(r.db.QueryRowContext(ctx, query, code), product)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get loan product: %v", err)
+	}
+
+	return product, nil
+}
+
+// ListActive retrieves the active loan products
+func (r *LoanProductRepositoryImpl) ListActive(ctx context.Context) ([]model.LoanProduct, error) {
+	query := `
+		SELECT ` + loanProductColumns + `
+		FROM loan_products
+		WHERE is_active = TRUE AND deleted_at IS NULL
+		ORDER BY code ASC`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list loan products: %v", err)
+	}
+	defer rows.Close()
+
+	var products []model.LoanProduct
+	for rows.Next() {
+		var product model.LoanProduct
+		if err := scanLoanProduct(rows, &product); err != nil {
+			return nil, fmt.Errorf("failed to scan loan product: %v", err)
+		}
+		products = append(products, product)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating loan products: %v", err)
+	}
+
+	return products, nil
+}
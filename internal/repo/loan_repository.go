@@ -2,34 +2,60 @@ package repo
 
 import (
 	"context"
+	"time"
 
 	"github.com/edosulai/pt-xyz-multifinance/internal/model"
 )
 
 // LoanRepository defines the interface for loan data access
-type LoanRepository interface { // Create a new loan application
-	Create(ctx context.Context, loan *model.Loan) error
+type LoanRepository interface {
+	// Create a new loan application with the checklist slots in its Documents, atomically
+	// enforcing policy; violations return a *model.DuplicateApplicationError or
+	// *model.OpenApplicationLimitError
+	Create(ctx context.Context, loan *model.Loan, policy model.ApplicationPolicy) error
 	// Get loan by ID
 	GetByID(ctx context.Context, id string) (*model.Loan, error)
 
 	// Get loans by user ID
 	GetUserLoans(ctx context.Context, userID string, page, pageSize int) ([]model.Loan, int64, error)
 
-	// Update loan status
-	UpdateLoanStatus(ctx context.Context, id string, status model.LoanStatus) error
+	// Update loan status, rejecting transitions not allowed for the actor in ctx
+	UpdateLoanStatus(ctx context.Context, id string, status model.LoanStatus, reason string) error
 
-	// Update loan
-	UpdateLoan(ctx context.Context, loan *model.Loan) error
+	// Update loan, rejecting status transitions not allowed for the actor in ctx
+	UpdateLoan(ctx context.Context, loan *model.Loan, reason string) error
 
-	// Add document to loan
+	// Approve an offered loan and record the acceptance of its terms, returning ErrLoanOfferChanged
+	// if the loan changed since it was read at loanUpdatedAt
+	AcceptOffer(ctx context.Context, acceptance *model.LoanOfferAcceptance, loanUpdatedAt time.Time, reason string) error
+
+	// Get the acceptance of a loan's offer; nil if the offer has not been accepted
+	GetOfferAcceptance(ctx context.Context, loanID string) (*model.LoanOfferAcceptance, error)
+
+	// Get the status change history of a loan
+	GetStatusHistory(ctx context.Context, loanID string) ([]model.LoanStatusHistory, error)
+
+	// Add document to loan, filling the open checklist slot of its type if there is one
 	AddDocument(ctx context.Context, doc *model.Document) error
 
-	// Update document status
-	UpdateDocumentStatus(ctx context.Context, id string, status model.DocumentStatus) error
+	// Store the review of a document and move its loan to loanStatus, rejecting status transitions not allowed for the actor in ctx
+	ReviewDocument(ctx context.Context, doc *model.Document, loanStatus model.LoanStatus, reason string) error
 
 	// Get document by ID
 	GetDocumentByID(ctx context.Context, id string) (*model.Document, error)
 
 	// Get documents by loan ID
 	GetDocumentsByLoanID(ctx context.Context, loanID string) ([]model.Document, error)
+
+	// Get loans in any of the given statuses
+	GetLoansByStatus(ctx context.Context, statuses ...model.LoanStatus) ([]model.Loan, error)
+
+	// Search all loans, returning at most limit loans that follow after in the sort order
+	SearchLoans(ctx context.Context, filter model.LoanSearchFilter, sort model.LoanSort, after *model.LoanSearchKey, limit int) ([]model.Loan, error)
+
+	// Get a user's loans in any of the given statuses
+	GetUserLoansByStatus(ctx context.Context, userID string, statuses ...model.LoanStatus) ([]model.Loan, error)
+
+	// Update days past due, collectibility and status, recording the change when record is not nil
+	UpdateDelinquency(ctx context.Context, loan *model.Loan, record *model.DelinquencyRecord) error
 }
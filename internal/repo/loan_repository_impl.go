@@ -5,12 +5,74 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/edosulai/pt-xyz-multifinance/internal/model"
 	"github.com/edosulai/pt-xyz-multifinance/pkg/database"
+	"github.com/lib/pq"
 )
 
+// ErrLoanOfferChanged is returned when a loan offer was repriced or withdrawn while it was being accepted
+var ErrLoanOfferChanged = errors.New("loan offer was modified concurrently")
+
+// loanColumns lists the loan columns read by scanLoan, in scan order
+const loanColumns = `
+			l.id, l.user_id, l.product_code, l.amount, l.tenure_months, l.purpose, l.status,
+			l.monthly_payment, l.interest_rate, l.interest_method, l.effective_annual_rate, l.disbursed_amount, l.disbursed_at,
+			l.outstanding_balance, l.days_past_due, l.collectibility,
+			l.dti_ratio, l.affordability_flagged, l.credit_score, l.risk_grade,
+			l.score_reason_codes, l.scoring_model, l.scored_at,
+			l.created_at, l.updated_at`
+
+// documentColumns lists the document columns read by scanDocument, in scan order
+const documentColumns = `
+			id, loan_id, type, name, status, COALESCE(url, ''), uploaded_at,
+			COALESCE(storage_key, ''), COALESCE(content_type, ''), COALESCE(size_bytes, 0), COALESCE(sha256, ''),
+			COALESCE(reason_code, ''), COALESCE(review_note, ''), COALESCE(reviewed_by::text, ''), reviewed_at,
+			required, created_at, updated_at`
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanLoan scans a row selected with loanColumns into loan
+func scanLoan(row rowScanner, loan *model.Loan) error {
+	var nullDisbursedAt sql.NullTime
+	var nullScoredAt sql.NullTime
+	err := row.Scan(
+		&loan.ID, &loan.UserID, &loan.ProductCode, &loan.Amount, &loan.TenureMonths, &loan.Purpose, &loan.Status,
+		&loan.MonthlyPayment, &loan.InterestRate, &loan.InterestMethod, &loan.EffectiveAnnualRate, &loan.DisbursedAmount, &nullDisbursedAt,
+		&loan.OutstandingBalance, &loan.DaysPastDue, &loan.Collectibility,
+		&loan.DTIRatio, &loan.AffordabilityFlagged, &loan.CreditScore, &loan.RiskGrade,
+		pq.Array(&loan.ScoreReasonCodes), &loan.ScoringModel, &nullScoredAt,
+		&loan.CreatedAt, &loan.UpdatedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	if nullDisbursedAt.Valid {
+		loan.DisbursedAt = &nullDisbursedAt.Time
+	}
+	if nullScoredAt.Valid {
+		loan.ScoredAt = &nullScoredAt.Time
+	}
+
+	return nil
+}
+
+// scanDocument scans a row selected with documentColumns into doc
+func scanDocument(row rowScanner, doc *model.Document) error {
+	return row.Scan(
+		&doc.ID, &doc.LoanID, &doc.Type, &doc.Name, &doc.Status, &doc.URL, &doc.UploadedAt,
+		&doc.StorageKey, &doc.ContentType, &doc.SizeBytes, &doc.SHA256,
+		&doc.ReasonCode, &doc.ReviewNote, &doc.ReviewedBy, &doc.ReviewedAt,
+		&doc.Required, &doc.CreatedAt, &doc.UpdatedAt,
+	)
+}
+
 // LoanRepositoryImpl implements LoanRepository interface using native SQL
 type LoanRepositoryImpl struct {
 	db *database.DB
@@ -21,57 +83,127 @@ func NewLoanRepository(db *database.DB) LoanRepository {
 	return &LoanRepositoryImpl{db: db}
 }
 
-// Create creates a new loan application
-func (r *LoanRepositoryImpl) Create(ctx context.Context, loan *model.Loan) error {
+// Create creates a new loan application together with its document checklist slots. The
+// application is checked against policy in the same transaction, with the applicant's user row
+// locked so that concurrent applications of one user cannot both pass.
+func (r *LoanRepositoryImpl) Create(ctx context.Context, loan *model.Loan, policy model.ApplicationPolicy) error {
 	query := `
 		INSERT INTO loans (
-			user_id, amount, tenure_months, purpose, status, 
-			monthly_payment, interest_rate, created_at, updated_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+			user_id, product_code, amount, tenure_months, purpose, status,
+			monthly_payment, interest_rate, interest_method, effective_annual_rate,
+			dti_ratio, affordability_flagged,
+			credit_score, risk_grade, score_reason_codes, scoring_model, scored_at,
+			created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, COALESCE(NULLIF($9, ''), 'annuity'), $10,
+			$11, $12, $13, $14, $15, $16, $17, $18, $19)
 		RETURNING id`
 
 	now := time.Now()
 	loan.CreatedAt = now
 	loan.UpdatedAt = now
 
-	err := r.db.QueryRowContext(ctx, query,
-		loan.UserID, loan.Amount, loan.TenureMonths, loan.Purpose, loan.Status,
-		loan.MonthlyPayment, loan.InterestRate, loan.CreatedAt, loan.UpdatedAt,
-	).Scan(&loan.ID)
+	return r.db.WithTx(ctx, func(tx *sql.Tx) error {
+		if err := checkApplicationPolicy(ctx, tx, loan, policy); err != nil {
+			return err
+		}
+
+		err := tx.QueryRowContext(ctx, query,
+			loan.UserID, loan.ProductCode,
+			loan.Amount, loan.TenureMonths, loan.Purpose, loan.Status,
+			loan.MonthlyPayment, loan.InterestRate, loan.InterestMethod, loan.EffectiveAnnualRate,
+			loan.DTIRatio, loan.AffordabilityFlagged,
+			loan.CreditScore, loan.RiskGrade, pq.Array(loan.ScoreReasonCodes),
+			loan.ScoringModel, loan.ScoredAt,
+			loan.CreatedAt, loan.UpdatedAt,
+		).Scan(&loan.ID)
+		if err != nil {
+			return fmt.Errorf("failed to create loan: %v", err)
+		}
+
+		for i := range loan.Documents {
+			loan.Documents[i].LoanID = loan.ID
+			if err := insertDocument(ctx, tx, &loan.Documents[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// checkApplicationPolicy locks the applicant's user row, serializing the applications of one
+// user, and rejects the loan if it duplicates a recent application or exceeds the open limit
+func checkApplicationPolicy(ctx context.Context, tx *sql.Tx, loan *model.Loan, policy model.ApplicationPolicy) error {
+	if policy.MaxOpenApplications <= 0 && policy.DuplicateWindow <= 0 {
+		return nil
+	}
 
+	var userID string
+	err := tx.QueryRowContext(ctx, `
+		SELECT id FROM users
+		WHERE id = $1 AND deleted_at IS NULL
+		FOR UPDATE`,
+		loan.UserID,
+	).Scan(&userID)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("user not found")
+	}
 	if err != nil {
-		return fmt.Errorf("failed to create loan: %v", err)
+		return fmt.Errorf("failed to lock user: %v", err)
+	}
+
+	if policy.DuplicateWindow > 0 {
+		var duplicateID string
+		err := tx.QueryRowContext(ctx, `
+			SELECT id FROM loans
+			WHERE user_id = $1 AND product_code = $2 AND amount = $3
+				AND tenure_months = $4 AND purpose = $5 AND created_at > $6 AND deleted_at IS NULL
+			ORDER BY created_at DESC
+			LIMIT 1`,
+			loan.UserID, loan.ProductCode, loan.Amount,
+			loan.TenureMonths, loan.Purpose, loan.CreatedAt.Add(-policy.DuplicateWindow),
+		).Scan(&duplicateID)
+		if err == nil {
+			return &model.DuplicateApplicationError{LoanID: duplicateID}
+		}
+		if err != sql.ErrNoRows {
+			return fmt.Errorf("failed to check for duplicate applications: %v", err)
+		}
+	}
+
+	if policy.MaxOpenApplications > 0 {
+		statuses := make([]string, 0, len(model.OpenApplicationStatuses))
+		for _, status := range model.OpenApplicationStatuses {
+			statuses = append(statuses, string(status))
+		}
+
+		var open int
+		err := tx.QueryRowContext(ctx, `
+			SELECT COUNT(*) FROM loans
+			WHERE user_id = $1 AND status::text = ANY($2) AND deleted_at IS NULL`,
+			loan.UserID, pq.Array(statuses),
+		).Scan(&open)
+		if err != nil {
+			return fmt.Errorf("failed to count open applications: %v", err)
+		}
+		if open >= policy.MaxOpenApplications {
+			return &model.OpenApplicationLimitError{Max: policy.MaxOpenApplications}
+		}
 	}
+
 	return nil
 }
 
 // GetByID retrieves a loan by its ID
 func (r *LoanRepositoryImpl) GetByID(ctx context.Context, id string) (*model.Loan, error) {
 	query := `
-		SELECT 
-			l.id, l.user_id, l.amount, l.tenure_months, l.purpose, l.status,
-			l.monthly_payment, l.interest_rate, l.disbursed_amount, l.disbursed_at,
-			l.created_at, l.updated_at
+		SELECT ` + loanColumns + `
 		FROM loans l
 		WHERE l.id = $1 AND l.deleted_at IS NULL`
 	loan := &model.Loan{}
-	var nullDisbursedAmount sql.NullFloat64
-	var nullDisbursedAt sql.NullTime
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
-		&loan.ID, &loan.UserID, &loan.Amount, &loan.TenureMonths, &loan.Purpose, &loan.Status,
-		&loan.MonthlyPayment, &loan.InterestRate, &nullDisbursedAmount,
-		&nullDisbursedAt, &loan.CreatedAt, &loan.UpdatedAt,
-	)
-
-	if nullDisbursedAmount.Valid {
-		loan.DisbursedAmount = nullDisbursedAmount.Float64
-	}
-	if nullDisbursedAt.Valid {
-		loan.DisbursedAt = &nullDisbursedAt.Time
-	}
+	err := scanLoan(r.db.QueryRowContext(ctx, query, id), loan)
 
 	if err == sql.ErrNoRows {
-		return nil, errors.New("loan not found")
+		return nil, nil
 	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to get loan: %v", err)
@@ -102,10 +234,7 @@ func (r *LoanRepositoryImpl) GetUserLoans(ctx context.Context, userID string, pa
 
 	offset := (page - 1) * pageSize
 	query := `
-		SELECT 
-			l.id, l.user_id, l.amount, l.tenure_months, l.purpose, l.status,
-			l.monthly_payment, l.interest_rate, l.disbursed_amount, l.disbursed_at,
-			l.created_at, l.updated_at
+		SELECT ` + loanColumns + `
 		FROM loans l
 		WHERE l.user_id = $1 AND l.deleted_at IS NULL
 		ORDER BY l.created_at DESC
@@ -120,11 +249,7 @@ func (r *LoanRepositoryImpl) GetUserLoans(ctx context.Context, userID string, pa
 	var loans []model.Loan
 	for rows.Next() {
 		var loan model.Loan
-		err := rows.Scan(
-			&loan.ID, &loan.UserID, &loan.Amount, &loan.TenureMonths, &loan.Purpose, &loan.Status,
-			&loan.MonthlyPayment, &loan.InterestRate, &loan.DisbursedAmount,
-			&loan.DisbursedAt, &loan.CreatedAt, &loan.UpdatedAt,
-		)
+		err := scanLoan(rows, &loan)
 		if err != nil {
 			return nil, 0, fmt.Errorf("failed to scan loan: %v", err)
 		}
@@ -146,76 +271,211 @@ func (r *LoanRepositoryImpl) GetUserLoans(ctx context.Context, userID string, pa
 	return loans, total, nil
 }
 
-// UpdateLoanStatus updates the status of a loan
-func (r *LoanRepositoryImpl) UpdateLoanStatus(ctx context.Context, id string, status model.LoanStatus) error {
-	query := `
-		UPDATE loans 
-		SET status = $1, updated_at = $2
-		WHERE id = $3 AND deleted_at IS NULL`
+// UpdateLoanStatus moves a loan to a new status and records the change.
+// Transitions not allowed for the actor in ctx return a *model.StatusTransitionError.
+func (r *LoanRepositoryImpl) UpdateLoanStatus(ctx context.Context, id string, status model.LoanStatus, reason string) error {
+	return r.db.WithTx(ctx, func(tx *sql.Tx) error {
+		if err := changeLoanStatus(ctx, tx, id, status, reason); err != nil {
+			return err
+		}
 
-	result, err := r.db.ExecContext(ctx, query, status, time.Now(), id)
-	if err != nil {
-		return fmt.Errorf("failed to update loan status: %v", err)
-	}
+		_, err := tx.ExecContext(ctx, `
+			UPDATE loans
+			SET status = $1, updated_at = $2
+			WHERE id = $3 AND deleted_at IS NULL`,
+			status, time.Now(), id,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to update loan status: %v", err)
+		}
 
-	rows, err := result.RowsAffected()
-	if err != nil {
-		return fmt.Errorf("failed to get affected rows: %v", err)
-	}
-	if rows == 0 {
-		return fmt.Errorf("loan not found")
-	}
+		return nil
+	})
+}
 
-	return nil
+// UpdateLoan updates a loan record, recording any status change.
+// Transitions not allowed for the actor in ctx return a *model.StatusTransitionError.
+func (r *LoanRepositoryImpl) UpdateLoan(ctx context.Context, loan *model.Loan, reason string) error {
+	return r.db.WithTx(ctx, func(tx *sql.Tx) error {
+		if err := changeLoanStatus(ctx, tx, loan.ID, loan.Status, reason); err != nil {
+			return err
+		}
+
+		loan.UpdatedAt = time.Now()
+
+		_, err := tx.ExecContext(ctx, `
+			UPDATE loans
+			SET user_id = $1, amount = $2, tenure_months = $3, purpose = $4,
+				status = $5, monthly_payment = $6, interest_rate = $7,
+				disbursed_amount = $8, disbursed_at = $9, outstanding_balance = $10,
+				credit_score = $11, risk_grade = $12, score_reason_codes = $13,
+				scoring_model = $14, scored_at = $15, updated_at = $16,
+				interest_method = COALESCE(NULLIF($18, ''), interest_method), effective_annual_rate = $19
+			WHERE id = $17 AND deleted_at IS NULL`,
+			loan.UserID, loan.Amount, loan.TenureMonths, loan.Purpose,
+			loan.Status, loan.MonthlyPayment, loan.InterestRate,
+			loan.DisbursedAmount, loan.DisbursedAt, loan.OutstandingBalance,
+			loan.CreditScore, loan.RiskGrade, pq.Array(loan.ScoreReasonCodes),
+			loan.ScoringModel, loan.ScoredAt, loan.UpdatedAt, loan.ID,
+			loan.InterestMethod, loan.EffectiveAnnualRate,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to update loan: %v", err)
+		}
+
+		return nil
+	})
+}
+
+// AcceptOffer approves an offered loan and records the applicant's acceptance of its terms. The
+// loan must not have changed since it was read at loanUpdatedAt, so that the accepted terms are
+// the ones it is approved on; otherwise ErrLoanOfferChanged is returned.
+func (r *LoanRepositoryImpl) AcceptOffer(ctx context.Context, acceptance *model.LoanOfferAcceptance, loanUpdatedAt time.Time, reason string) error {
+	return r.db.WithTx(ctx, func(tx *sql.Tx) error {
+		if err := changeLoanStatus(ctx, tx, acceptance.LoanID, model.LoanStatusApproved, reason); err != nil {
+			return err
+		}
+
+		result, err := tx.ExecContext(ctx, `
+			UPDATE loans
+			SET status = $1, updated_at = $2
+			WHERE id = $3 AND status = $4 AND updated_at = $5 AND deleted_at IS NULL`,
+			model.LoanStatusApproved, acceptance.AcceptedAt, acceptance.LoanID, model.LoanStatusOffered, loanUpdatedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to update loan status: %v", err)
+		}
+		rows, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to get affected rows: %v", err)
+		}
+		if rows == 0 {
+			return ErrLoanOfferChanged
+		}
+
+		err = tx.QueryRowContext(ctx, `
+			INSERT INTO loan_offer_acceptances (
+				loan_id, user_id, terms, terms_hash, accepted_at, ip_address, user_agent
+			) VALUES ($1, $2, $3, $4, $5, NULLIF($6, ''), NULLIF($7, ''))
+			RETURNING id, created_at`,
+			acceptance.LoanID, acceptance.UserID, string(acceptance.Terms), acceptance.TermsHash,
+			acceptance.AcceptedAt, acceptance.IPAddress, acceptance.UserAgent,
+		).Scan(&acceptance.ID, &acceptance.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to record offer acceptance: %v", err)
+		}
+
+		return nil
+	})
 }
 
-// UpdateLoan updates a loan record
-func (r *LoanRepositoryImpl) UpdateLoan(ctx context.Context, loan *model.Loan) error {
+// GetOfferAcceptance retrieves the acceptance of a loan's offer, or nil if it was not accepted
+func (r *LoanRepositoryImpl) GetOfferAcceptance(ctx context.Context, loanID string) (*model.LoanOfferAcceptance, error) {
 	query := `
-		UPDATE loans 
-		SET user_id = $1, amount = $2, tenure_months = $3, purpose = $4,
-			status = $5, monthly_payment = $6, interest_rate = $7,
-			disbursed_amount = $8, disbursed_at = $9, updated_at = $10
-		WHERE id = $11 AND deleted_at IS NULL`
-
-	loan.UpdatedAt = time.Now()
-
-	result, err := r.db.ExecContext(ctx, query,
-		loan.UserID, loan.Amount, loan.TenureMonths, loan.Purpose,
-		loan.Status, loan.MonthlyPayment, loan.InterestRate,
-		loan.DisbursedAmount, loan.DisbursedAt, loan.UpdatedAt,
-		loan.ID,
+		SELECT id, loan_id, user_id, terms, terms_hash, accepted_at,
+			COALESCE(ip_address, ''), COALESCE(user_agent, ''), created_at
+		FROM loan_offer_acceptances
+		WHERE loan_id = $1`
+
+	acceptance := &model.LoanOfferAcceptance{}
+	var terms []byte
+	err := r.db.QueryRowContext(ctx, query, loanID).Scan(
+		&acceptance.ID, &acceptance.LoanID, &acceptance.UserID, &terms, &acceptance.TermsHash,
+		&acceptance.AcceptedAt, &acceptance.IPAddress, &acceptance.UserAgent, &acceptance.CreatedAt,
 	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
 	if err != nil {
-		return fmt.Errorf("failed to update loan: %v", err)
+		return nil, fmt.Errorf("failed to get offer acceptance: %v", err)
 	}
+	acceptance.Terms = terms
+
+	return acceptance, nil
+}
+
+// GetStatusHistory retrieves the status changes of a loan from oldest to newest
+func (r *LoanRepositoryImpl) GetStatusHistory(ctx context.Context, loanID string) ([]model.LoanStatusHistory, error) {
+	query := `
+		SELECT id, loan_id, from_status, to_status, COALESCE(actor_id::text, ''),
+			actor_role, COALESCE(reason, ''), created_at
+		FROM loan_status_history
+		WHERE loan_id = $1
+		ORDER BY created_at ASC`
 
-	rows, err := result.RowsAffected()
+	rows, err := r.db.QueryContext(ctx, query, loanID)
 	if err != nil {
-		return fmt.Errorf("failed to get affected rows: %v", err)
+		return nil, fmt.Errorf("failed to get loan status history: %v", err)
 	}
-	if rows == 0 {
-		return fmt.Errorf("loan not found")
+	defer rows.Close()
+
+	var history []model.LoanStatusHistory
+	for rows.Next() {
+		var h model.LoanStatusHistory
+		err := rows.Scan(
+			&h.ID, &h.LoanID, &h.FromStatus, &h.ToStatus, &h.ActorID,
+			&h.ActorRole, &h.Reason, &h.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan loan status history: %v", err)
+		}
+		history = append(history, h)
 	}
 
-	return nil
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating loan status history: %v", err)
+	}
+
+	return history, nil
 }
 
-// AddDocument adds a document to a loan
+// AddDocument adds a document to a loan, filling the open checklist slot of its type when the
+// loan has one and adding a new row otherwise
 func (r *LoanRepositoryImpl) AddDocument(ctx context.Context, doc *model.Document) error {
+	return r.db.WithTx(ctx, func(tx *sql.Tx) error {
+		now := time.Now()
+		doc.UpdatedAt = now
+
+		// Fill the open checklist slot of the same type, if any
+		err := tx.QueryRowContext(ctx, `
+			UPDATE documents
+			SET name = $3, status = $4, url = $5, uploaded_at = $6,
+				storage_key = NULLIF($7, ''), content_type = NULLIF($8, ''), size_bytes = NULLIF($9::bigint, 0), sha256 = NULLIF($10, ''),
+				updated_at = $11
+			WHERE loan_id = $1 AND type = $2 AND status = 'required' AND deleted_at IS NULL
+			RETURNING id, required, created_at`,
+			doc.LoanID, doc.Type, doc.Name, doc.Status, doc.URL, doc.UploadedAt,
+			doc.StorageKey, doc.ContentType, doc.SizeBytes, doc.SHA256,
+			doc.UpdatedAt,
+		).Scan(&doc.ID, &doc.Required, &doc.CreatedAt)
+		if err == nil {
+			return nil
+		}
+		if err != sql.ErrNoRows {
+			return fmt.Errorf("failed to add document: %v", err)
+		}
+
+		return insertDocument(ctx, tx, doc)
+	})
+}
+
+// insertDocument inserts doc as a new row of its loan
+func insertDocument(ctx context.Context, tx *sql.Tx, doc *model.Document) error {
 	query := `
 		INSERT INTO documents (
-			loan_id, type, name, status, url, uploaded_at, created_at, updated_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+			loan_id, type, name, status, url, uploaded_at,
+			storage_key, content_type, size_bytes, sha256, required, created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, ''), NULLIF($8, ''), NULLIF($9::bigint, 0), NULLIF($10, ''), $11, $12, $13)
 		RETURNING id`
 
 	now := time.Now()
 	doc.CreatedAt = now
 	doc.UpdatedAt = now
 
-	err := r.db.QueryRowContext(ctx, query,
-		doc.LoanID, doc.Type, doc.Name, doc.Status, doc.URL,
-		doc.UploadedAt, doc.CreatedAt, doc.UpdatedAt,
+	err := tx.QueryRowContext(ctx, query,
+		doc.LoanID, doc.Type, doc.Name, doc.Status, doc.URL, doc.UploadedAt,
+		doc.StorageKey, doc.ContentType, doc.SizeBytes, doc.SHA256, doc.Required,
+		doc.CreatedAt, doc.UpdatedAt,
 	).Scan(&doc.ID)
 
 	if err != nil {
@@ -224,43 +484,63 @@ func (r *LoanRepositoryImpl) AddDocument(ctx context.Context, doc *model.Documen
 	return nil
 }
 
-// UpdateDocumentStatus updates the status of a document
-func (r *LoanRepositoryImpl) UpdateDocumentStatus(ctx context.Context, id string, status model.DocumentStatus) error {
-	query := `
-		UPDATE documents 
-		SET status = $1, updated_at = $2
-		WHERE id = $3 AND deleted_at IS NULL`
+// ReviewDocument stores the analyst review of a document and moves its loan to loanStatus in
+// one transaction, recording reason in the status history when the status changes.
+// Transitions not allowed for the actor in ctx return a *model.StatusTransitionError.
+func (r *LoanRepositoryImpl) ReviewDocument(ctx context.Context, doc *model.Document, loanStatus model.LoanStatus, reason string) error {
+	return r.db.WithTx(ctx, func(tx *sql.Tx) error {
+		if err := changeLoanStatus(ctx, tx, doc.LoanID, loanStatus, reason); err != nil {
+			return err
+		}
 
-	result, err := r.db.ExecContext(ctx, query, status, time.Now(), id)
-	if err != nil {
-		return fmt.Errorf("failed to update document status: %v", err)
-	}
+		now := time.Now()
+		_, err := tx.ExecContext(ctx, `
+			UPDATE loans
+			SET status = $1, updated_at = $2
+			WHERE id = $3 AND status <> $1 AND deleted_at IS NULL`,
+			loanStatus, now, doc.LoanID,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to update loan status: %v", err)
+		}
 
-	rows, err := result.RowsAffected()
-	if err != nil {
-		return fmt.Errorf("failed to get affected rows: %v", err)
-	}
-	if rows == 0 {
-		return fmt.Errorf("document not found")
-	}
+		var reviewedBy interface{}
+		if doc.ReviewedBy != "" {
+			reviewedBy = doc.ReviewedBy
+		}
+		result, err := tx.ExecContext(ctx, `
+			UPDATE documents
+			SET status = $1, reason_code = NULLIF($2, ''), review_note = NULLIF($3, ''),
+				reviewed_by = $4, reviewed_at = $5, updated_at = $5
+			WHERE id = $6 AND loan_id = $7 AND deleted_at IS NULL`,
+			doc.Status, doc.ReasonCode, doc.ReviewNote, reviewedBy, doc.ReviewedAt, doc.ID, doc.LoanID,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to review document: %v", err)
+		}
 
-	return nil
+		rows, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to get affected rows: %v", err)
+		}
+		if rows == 0 {
+			return fmt.Errorf("document not found")
+		}
+
+		doc.UpdatedAt = now
+		return nil
+	})
 }
 
 // GetDocumentByID retrieves a document by its ID
 func (r *LoanRepositoryImpl) GetDocumentByID(ctx context.Context, id string) (*model.Document, error) {
 	query := `
-		SELECT 
-			id, loan_id, type, name, status, url, uploaded_at,
-			created_at, updated_at
+		SELECT ` + documentColumns + `
 		FROM documents
 		WHERE id = $1 AND deleted_at IS NULL`
 
 	doc := &model.Document{}
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
-		&doc.ID, &doc.LoanID, &doc.Type, &doc.Name, &doc.Status,
-		&doc.URL, &doc.UploadedAt, &doc.CreatedAt, &doc.UpdatedAt,
-	)
+	err := scanDocument(r.db.QueryRowContext(ctx, query, id), doc)
 
 	if err == sql.ErrNoRows {
 		return nil, nil
@@ -275,9 +555,7 @@ func (r *LoanRepositoryImpl) GetDocumentByID(ctx context.Context, id string) (*m
 // GetDocumentsByLoanID retrieves all documents for a loan
 func (r *LoanRepositoryImpl) GetDocumentsByLoanID(ctx context.Context, loanID string) ([]model.Document, error) {
 	query := `
-		SELECT 
-			id, loan_id, type, name, status, url, uploaded_at,
-			created_at, updated_at
+		SELECT ` + documentColumns + `
 		FROM documents
 		WHERE loan_id = $1 AND deleted_at IS NULL
 		ORDER BY created_at ASC`
@@ -291,11 +569,7 @@ func (r *LoanRepositoryImpl) GetDocumentsByLoanID(ctx context.Context, loanID st
 	var documents []model.Document
 	for rows.Next() {
 		var doc model.Document
-		err := rows.Scan(
-			&doc.ID, &doc.LoanID, &doc.Type, &doc.Name, &doc.Status,
-			&doc.URL, &doc.UploadedAt, &doc.CreatedAt, &doc.UpdatedAt,
-		)
-		if err != nil {
+		if err := scanDocument(rows, &doc); err != nil {
 			return nil, fmt.Errorf("failed to scan document: %v", err)
 		}
 		documents = append(documents, doc)
@@ -307,3 +581,189 @@ func (r *LoanRepositoryImpl) GetDocumentsByLoanID(ctx context.Context, loanID st
 
 	return documents, nil
 }
+
+// GetLoansByStatus retrieves all loans in any of the given statuses without their documents
+func (r *LoanRepositoryImpl) GetLoansByStatus(ctx context.Context, statuses ...model.LoanStatus) ([]model.Loan, error) {
+	values := make([]string, 0, len(statuses))
+	for _, status := range statuses {
+		values = append(values, string(status))
+	}
+
+	query := `
+		SELECT ` + loanColumns + `
+		FROM loans l
+		WHERE l.status::text = ANY($1) AND l.deleted_at IS NULL
+		ORDER BY l.created_at ASC`
+
+	return r.queryLoans(ctx, query, pq.Array(values))
+}
+
+// GetUserLoansByStatus retrieves the loans of a user that are in any of the given statuses
+func (r *LoanRepositoryImpl) GetUserLoansByStatus(ctx context.Context, userID string, statuses ...model.LoanStatus) ([]model.Loan, error) {
+	values := make([]string, 0, len(statuses))
+	for _, status := range statuses {
+		values = append(values, string(status))
+	}
+
+	query := `
+		SELECT ` + loanColumns + `
+		FROM loans l
+		WHERE l.user_id = $1 AND l.status::text = ANY($2) AND l.deleted_at IS NULL
+		ORDER BY l.created_at ASC`
+
+	return r.queryLoans(ctx, query, userID, pq.Array(values))
+}
+
+// loanSortColumns maps each sort field to the loans column it orders by
+var loanSortColumns = map[model.LoanSortField]string{
+	model.LoanSortCreatedAt: "l.created_at",
+	model.LoanSortAmount:    "l.amount",
+}
+
+// SearchLoans retrieves one page of loans matching filter in a single keyset-paginated query,
+// without their documents. A nil after starts from the first loan in the sort order.
+func (r *LoanRepositoryImpl) SearchLoans(ctx context.Context, filter model.LoanSearchFilter, sort model.LoanSort, after *model.LoanSearchKey, limit int) ([]model.Loan, error) {
+	column, ok := loanSortColumns[sort.Field]
+	if !ok {
+		return nil, fmt.Errorf("unknown loan sort field %q", sort.Field)
+	}
+
+	conditions := []string{"l.deleted_at IS NULL"}
+	var args []interface{}
+	where := func(condition string, arg interface{}) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if len(filter.Statuses) > 0 {
+		values := make([]string, 0, len(filter.Statuses))
+		for _, status := range filter.Statuses {
+			values = append(values, string(status))
+		}
+		where("l.status::text = ANY($%d)", pq.Array(values))
+	}
+	if filter.UserID != "" {
+		where("l.user_id = $%d", filter.UserID)
+	}
+	if filter.ProductCode != "" {
+		where("l.product_code = $%d", filter.ProductCode)
+	}
+	if filter.MinAmount > 0 {
+		where("l.amount >= $%d", filter.MinAmount)
+	}
+	if filter.MaxAmount > 0 {
+		where("l.amount <= $%d", filter.MaxAmount)
+	}
+	if filter.CreatedFrom != nil {
+		where("l.created_at >= $%d", *filter.CreatedFrom)
+	}
+	if filter.CreatedTo != nil {
+		where("l.created_at < $%d", *filter.CreatedTo)
+	}
+	if filter.DisbursedFrom != nil {
+		where("l.disbursed_at >= $%d", *filter.DisbursedFrom)
+	}
+	if filter.DisbursedTo != nil {
+		where("l.disbursed_at < $%d", *filter.DisbursedTo)
+	}
+
+	direction, comparison := "ASC", ">"
+	if sort.Descending {
+		direction, comparison = "DESC", "<"
+	}
+
+	if after != nil {
+		var value interface{} = after.CreatedAt
+		if sort.Field == model.LoanSortAmount {
+			value = after.Amount
+		}
+		args = append(args, value, after.ID)
+		conditions = append(conditions, fmt.Sprintf("(%s, l.id) %s ($%d, $%d)", column, comparison, len(args)-1, len(args)))
+	}
+
+	args = append(args, limit)
+	query := fmt.Sprintf(`
+		SELECT `+loanColumns+`
+		FROM loans l
+		WHERE %s
+		ORDER BY %s %s, l.id %s
+		LIMIT $%d`,
+		strings.Join(conditions, " AND "), column, direction, direction, len(args))
+
+	return r.queryLoans(ctx, query, args...)
+}
+
+// queryLoans runs a query selecting loanColumns and scans every row, without documents
+func (r *LoanRepositoryImpl) queryLoans(ctx context.Context, query string, args ...interface{}) ([]model.Loan, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get loans: %v", err)
+	}
+	defer rows.Close()
+
+	var loans []model.Loan
+	for rows.Next() {
+		var loan model.Loan
+		if err := scanLoan(rows, &loan); err != nil {
+			return nil, fmt.Errorf("failed to scan loan: %v", err)
+		}
+		loans = append(loans, loan)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating loans: %v", err)
+	}
+
+	return loans, nil
+}
+
+// UpdateDelinquency stores the evaluated delinquency of a loan and its history record atomically
+func (r *LoanRepositoryImpl) UpdateDelinquency(ctx context.Context, loan *model.Loan, record *model.DelinquencyRecord) error {
+	return r.db.WithTx(ctx, func(tx *sql.Tx) error {
+		reason := fmt.Sprintf("%d days past due", loan.DaysPastDue)
+		if err := changeLoanStatus(ctx, tx, loan.ID, loan.Status, reason); err != nil {
+			return err
+		}
+
+		now := time.Now()
+		loan.UpdatedAt = now
+
+		result, err := tx.ExecContext(ctx, `
+			UPDATE loans
+			SET days_past_due = $1, collectibility = $2, status = $3, updated_at = $4
+			WHERE id = $5 AND deleted_at IS NULL`,
+			loan.DaysPastDue, loan.Collectibility, loan.Status, loan.UpdatedAt, loan.ID,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to update loan delinquency: %v", err)
+		}
+		rows, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to get affected rows: %v", err)
+		}
+		if rows == 0 {
+			return fmt.Errorf("loan not found")
+		}
+
+		if record == nil {
+			return nil
+		}
+
+		record.LoanID = loan.ID
+		record.CreatedAt = now
+		err = tx.QueryRowContext(ctx, `
+			INSERT INTO loan_delinquency_history (
+				loan_id, as_of_date, days_past_due, previous_collectibility,
+				collectibility, previous_status, status, created_at
+			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+			RETURNING id`,
+			record.LoanID, record.AsOfDate, record.DaysPastDue, record.PreviousCollectibility,
+			record.Collectibility, record.PreviousStatus, record.Status, record.CreatedAt,
+		).Scan(&record.ID)
+		if err != nil {
+			return fmt.Errorf("failed to record delinquency history: %v", err)
+		}
+
+		return nil
+	})
+}
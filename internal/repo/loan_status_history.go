@@ -0,0 +1,54 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/edosulai/pt-xyz-multifinance/internal/model"
+)
+
+// changeLoanStatus locks the loan row and validates the move from its current status to the given one
+// for the actor carried by ctx. Every actual change is recorded in loan_status_history within tx.
+func changeLoanStatus(ctx context.Context, tx *sql.Tx, loanID string, to model.LoanStatus, reason string) error {
+	var from model.LoanStatus
+	err := tx.QueryRowContext(ctx, `
+		SELECT status FROM loans
+		WHERE id = $1 AND deleted_at IS NULL
+		FOR UPDATE`,
+		loanID,
+	).Scan(&from)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("loan not found")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to lock loan: %v", err)
+	}
+
+	if from == to {
+		return nil
+	}
+
+	actor := model.ActorFromContext(ctx)
+	if err := model.CheckLoanTransition(from, to, actor.Role); err != nil {
+		return err
+	}
+
+	var actorID interface{}
+	if actor.ID != "" {
+		actorID = actor.ID
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO loan_status_history (
+			loan_id, from_status, to_status, actor_id, actor_role, reason, created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+		loanID, from, to, actorID, actor.Role, reason, time.Now(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to record loan status history: %v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,16 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/edosulai/pt-xyz-multifinance/internal/model"
+)
+
+// PaymentRepository defines the interface for loan repayment data access
+type PaymentRepository interface {
+	// Persist a payment with its allocations, the installments it settled and the loan balance
+	Create(ctx context.Context, payment *model.Payment, installments []model.Installment, loan *model.Loan, previousBalance model.Money) error
+
+	// Get payments by loan ID
+	GetByLoanID(ctx context.Context, loanID string) ([]model.Payment, error)
+}
@@ -0,0 +1,208 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/edosulai/pt-xyz-multifinance/internal/model"
+	"github.com/edosulai/pt-xyz-multifinance/pkg/database"
+	"github.com/lib/pq"
+)
+
+// ErrLoanBalanceChanged is returned when the loan balance was modified by a concurrent payment
+var ErrLoanBalanceChanged = errors.New("loan balance was modified concurrently")
+
+// ErrDuplicatePaymentReference is returned when a payment reference was already posted for the loan
+var ErrDuplicatePaymentReference = errors.New("payment reference already recorded")
+
+// PaymentRepositoryImpl implements PaymentRepository interface using native SQL
+type PaymentRepositoryImpl struct {
+	db *database.DB
+}
+
+// NewPaymentRepository creates a new payment repository instance
+func NewPaymentRepository(db *database.DB) PaymentRepository {
+	return &PaymentRepositoryImpl{db: db}
+}
+
+// Create records a payment and applies its allocations in a single transaction.
+// The loan row is only updated if its balance still equals previousBalance.
+func (r *PaymentRepositoryImpl) Create(ctx context.Context, payment *model.Payment, installments []model.Installment, loan *model.Loan, previousBalance model.Money) error {
+	return r.db.WithTx(ctx, func(tx *sql.Tx) error {
+		return postPayment(ctx, tx, payment, installments, loan, previousBalance, "outstanding balance repaid")
+	})
+}
+
+// postPayment writes a payment, its allocations, the settled installments and the loan balance within tx.
+// reason is recorded in the loan status history when the payment changes the loan status,
+// and a loan that becomes paid off returns its drawn down amount to the credit limit.
+func postPayment(ctx context.Context, tx *sql.Tx, payment *model.Payment, installments []model.Installment, loan *model.Loan, previousBalance model.Money, reason string) error {
+	if err := changeLoanStatus(ctx, tx, loan.ID, loan.Status, reason); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	loan.UpdatedAt = now
+
+	result, err := tx.ExecContext(ctx, `
+		UPDATE loans
+		SET outstanding_balance = $1, status = $2, updated_at = $3
+		WHERE id = $4 AND outstanding_balance = $5 AND deleted_at IS NULL`,
+		loan.OutstandingBalance, loan.Status, loan.UpdatedAt, loan.ID, previousBalance,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update loan balance: %v", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %v", err)
+	}
+	if rows == 0 {
+		return ErrLoanBalanceChanged
+	}
+
+	if loan.Status == model.LoanStatusPaidOff {
+		if err := releaseCreditLimit(ctx, tx, loan.ID); err != nil {
+			return err
+		}
+	}
+
+	payment.LoanID = loan.ID
+	payment.CreatedAt = now
+	payment.UpdatedAt = now
+
+	var reference interface{}
+	if payment.Reference != "" {
+		reference = payment.Reference
+	}
+
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO payments (
+			loan_id, amount, payment_method, reference, paid_at, created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id`,
+		payment.LoanID, payment.Amount, payment.PaymentMethod, reference,
+		payment.PaidAt, payment.CreatedAt, payment.UpdatedAt,
+	).Scan(&payment.ID)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" { // unique_violation
+			return ErrDuplicatePaymentReference
+		}
+		return fmt.Errorf("failed to create payment: %v", err)
+	}
+
+	for i := range payment.Allocations {
+		alloc := &payment.Allocations[i]
+		alloc.PaymentID = payment.ID
+		alloc.CreatedAt = now
+
+		err := tx.QueryRowContext(ctx, `
+			INSERT INTO payment_allocations (
+				payment_id, installment_id, component, amount, created_at
+			) VALUES ($1, $2, $3, $4, $5)
+			RETURNING id`,
+			alloc.PaymentID, alloc.InstallmentID, alloc.Component, alloc.Amount, alloc.CreatedAt,
+		).Scan(&alloc.ID)
+		if err != nil {
+			return fmt.Errorf("failed to create payment allocation: %v", err)
+		}
+	}
+
+	for i := range installments {
+		inst := &installments[i]
+		inst.UpdatedAt = now
+
+		_, err := tx.ExecContext(ctx, `
+			UPDATE installments
+			SET fee_paid = $1, interest_paid = $2, principal_paid = $3,
+				status = $4, paid_at = $5, updated_at = $6
+			WHERE id = $7 AND deleted_at IS NULL`,
+			inst.FeePaid, inst.InterestPaid, inst.PrincipalPaid,
+			inst.Status, inst.PaidAt, inst.UpdatedAt, inst.ID,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to update installment: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// GetByLoanID retrieves all payments for a loan including their allocations
+func (r *PaymentRepositoryImpl) GetByLoanID(ctx context.Context, loanID string) ([]model.Payment, error) {
+	query := `
+		SELECT
+			id, loan_id, amount, payment_method, COALESCE(reference, ''),
+			paid_at, created_at, updated_at
+		FROM payments
+		WHERE loan_id = $1 AND deleted_at IS NULL
+		ORDER BY paid_at ASC, created_at ASC`
+
+	rows, err := r.db.QueryContext(ctx, query, loanID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get payments: %v", err)
+	}
+	defer rows.Close()
+
+	var payments []model.Payment
+	for rows.Next() {
+		var payment model.Payment
+		err := rows.Scan(
+			&payment.ID, &payment.LoanID, &payment.Amount, &payment.PaymentMethod, &payment.Reference,
+			&payment.PaidAt, &payment.CreatedAt, &payment.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan payment: %v", err)
+		}
+		payments = append(payments, payment)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating payments: %v", err)
+	}
+
+	for i := range payments {
+		allocations, err := r.getAllocations(ctx, payments[i].ID)
+		if err != nil {
+			return nil, err
+		}
+		payments[i].Allocations = allocations
+	}
+
+	return payments, nil
+}
+
+func (r *PaymentRepositoryImpl) getAllocations(ctx context.Context, paymentID string) ([]model.PaymentAllocation, error) {
+	query := `
+		SELECT id, payment_id, installment_id, component, amount, created_at
+		FROM payment_allocations
+		WHERE payment_id = $1
+		ORDER BY created_at ASC`
+
+	rows, err := r.db.QueryContext(ctx, query, paymentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get payment allocations: %v", err)
+	}
+	defer rows.Close()
+
+	var allocations []model.PaymentAllocation
+	for rows.Next() {
+		var alloc model.PaymentAllocation
+		err := rows.Scan(
+			&alloc.ID, &alloc.PaymentID, &alloc.InstallmentID, &alloc.Component, &alloc.Amount, &alloc.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan payment allocation: %v", err)
+		}
+		allocations = append(allocations, alloc)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating payment allocations: %v", err)
+	}
+
+	return allocations, nil
+}
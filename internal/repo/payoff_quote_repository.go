@@ -0,0 +1,19 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/edosulai/pt-xyz-multifinance/internal/model"
+)
+
+// PayoffQuoteRepository defines the interface for early payoff quote data access
+type PayoffQuoteRepository interface {
+	// Create a new payoff quote
+	Create(ctx context.Context, quote *model.PayoffQuote) error
+
+	// Get payoff quote by ID
+	GetByID(ctx context.Context, id string) (*model.PayoffQuote, error)
+
+	// Post the settlement payment and mark the quote as settled in a single transaction
+	Settle(ctx context.Context, quote *model.PayoffQuote, payment *model.Payment, installments []model.Installment, loan *model.Loan, previousBalance model.Money) error
+}
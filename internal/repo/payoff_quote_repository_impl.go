@@ -0,0 +1,121 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/edosulai/pt-xyz-multifinance/internal/model"
+	"github.com/edosulai/pt-xyz-multifinance/pkg/database"
+)
+
+// ErrPayoffQuoteAlreadySettled is returned when a quote was settled concurrently
+var ErrPayoffQuoteAlreadySettled = errors.New("payoff quote already settled")
+
+// PayoffQuoteRepositoryImpl implements PayoffQuoteRepository interface using native SQL
+type PayoffQuoteRepositoryImpl struct {
+	db *database.DB
+}
+
+// NewPayoffQuoteRepository creates a new payoff quote repository instance
+func NewPayoffQuoteRepository(db *database.DB) PayoffQuoteRepository {
+	return &PayoffQuoteRepositoryImpl{db: db}
+}
+
+// Create inserts a new payoff quote
+func (r *PayoffQuoteRepositoryImpl) Create(ctx context.Context, quote *model.PayoffQuote) error {
+	query := `
+		INSERT INTO payoff_quotes (
+			loan_id, outstanding_principal, accrued_interest, outstanding_fees,
+			early_termination_fee, total_amount, status, quoted_at, expires_at,
+			created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		RETURNING id`
+
+	now := time.Now()
+	quote.CreatedAt = now
+	quote.UpdatedAt = now
+
+	err := r.db.QueryRowContext(ctx, query,
+		quote.LoanID, quote.OutstandingPrincipal, quote.AccruedInterest, quote.OutstandingFees,
+		quote.EarlyTerminationFee, quote.TotalAmount, quote.Status, quote.QuotedAt, quote.ExpiresAt,
+		quote.CreatedAt, quote.UpdatedAt,
+	).Scan(&quote.ID)
+	if err != nil {
+		return fmt.Errorf("failed to create payoff quote: %v", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a payoff quote by ID
+func (r *PayoffQuoteRepositoryImpl) GetByID(ctx context.Context, id string) (*model.PayoffQuote, error) {
+	query := `
+		SELECT
+			id, loan_id, outstanding_principal, accrued_interest, outstanding_fees,
+			early_termination_fee, total_amount, status, quoted_at, expires_at,
+			payment_id, settled_at, created_at, updated_at
+		FROM payoff_quotes
+		WHERE id = $1`
+
+	quote := &model.PayoffQuote{}
+	var paymentID sql.NullString
+	var settledAt sql.NullTime
+	err := r.db.QueryRowContext(ctx, query, id).Scan(
+		&quote.ID, &quote.LoanID, &quote.OutstandingPrincipal, &quote.AccruedInterest, &quote.OutstandingFees,
+		&quote.EarlyTerminationFee, &quote.TotalAmount, &quote.Status, &quote.QuotedAt, &quote.ExpiresAt,
+		&paymentID, &settledAt, &quote.CreatedAt, &quote.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get payoff quote: %v", err)
+	}
+
+	if paymentID.Valid {
+		quote.PaymentID = &paymentID.String
+	}
+	if settledAt.Valid {
+		quote.SettledAt = &settledAt.Time
+	}
+
+	return quote, nil
+}
+
+// Settle records the settlement payment against the loan and closes the quote.
+// The quote is only settled once; a concurrent settlement returns ErrPayoffQuoteAlreadySettled.
+func (r *PayoffQuoteRepositoryImpl) Settle(ctx context.Context, quote *model.PayoffQuote, payment *model.Payment, installments []model.Installment, loan *model.Loan, previousBalance model.Money) error {
+	return r.db.WithTx(ctx, func(tx *sql.Tx) error {
+		if err := postPayment(ctx, tx, payment, installments, loan, previousBalance, "settled with payoff quote"); err != nil {
+			return err
+		}
+
+		now := time.Now()
+		result, err := tx.ExecContext(ctx, `
+			UPDATE payoff_quotes
+			SET status = $1, payment_id = $2, settled_at = $3, updated_at = $3
+			WHERE id = $4 AND status = $5`,
+			model.PayoffQuoteStatusSettled, payment.ID, now, quote.ID, model.PayoffQuoteStatusActive,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to settle payoff quote: %v", err)
+		}
+		rows, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to get affected rows: %v", err)
+		}
+		if rows == 0 {
+			return ErrPayoffQuoteAlreadySettled
+		}
+
+		quote.Status = model.PayoffQuoteStatusSettled
+		quote.PaymentID = &payment.ID
+		quote.SettledAt = &now
+		quote.UpdatedAt = now
+
+		return nil
+	})
+}
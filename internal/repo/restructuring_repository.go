@@ -0,0 +1,16 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/edosulai/pt-xyz-multifinance/internal/model"
+)
+
+// RestructuringRepository defines the interface for loan restructuring data access
+type RestructuringRepository interface {
+	// Close the open installments of the loan, store the restructuring and its new schedule, and update the loan terms
+	Create(ctx context.Context, restructuring *model.LoanRestructuring, loan *model.Loan, installments []model.Installment) error
+
+	// Get restructurings by loan ID ordered from oldest to newest
+	GetByLoanID(ctx context.Context, loanID string) ([]model.LoanRestructuring, error)
+}
@@ -0,0 +1,154 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/edosulai/pt-xyz-multifinance/internal/model"
+	"github.com/edosulai/pt-xyz-multifinance/pkg/database"
+)
+
+// ErrLoanStatusChanged is returned when the loan status was modified concurrently
+var ErrLoanStatusChanged = errors.New("loan status was modified concurrently")
+
+// RestructuringRepositoryImpl implements RestructuringRepository interface using native SQL
+type RestructuringRepositoryImpl struct {
+	db *database.DB
+}
+
+// NewRestructuringRepository creates a new restructuring repository instance
+func NewRestructuringRepository(db *database.DB) RestructuringRepository {
+	return &RestructuringRepositoryImpl{db: db}
+}
+
+// Create applies a restructuring in a single transaction.
+// The loan is only updated while it is still in restructuring.PreviousStatus.
+func (r *RestructuringRepositoryImpl) Create(ctx context.Context, restructuring *model.LoanRestructuring, loan *model.Loan, installments []model.Installment) error {
+	return r.db.WithTx(ctx, func(tx *sql.Tx) error {
+		if err := changeLoanStatus(ctx, tx, loan.ID, loan.Status, restructuring.Reason); err != nil {
+			return err
+		}
+
+		now := time.Now()
+		loan.UpdatedAt = now
+
+		result, err := tx.ExecContext(ctx, `
+			UPDATE loans
+			SET tenure_months = $1, interest_rate = $2, monthly_payment = $3,
+				outstanding_balance = $4, status = $5, updated_at = $6, effective_annual_rate = $9
+			WHERE id = $7 AND status = $8 AND deleted_at IS NULL`,
+			loan.TenureMonths, loan.InterestRate, loan.MonthlyPayment,
+			loan.OutstandingBalance, loan.Status, loan.UpdatedAt,
+			loan.ID, restructuring.PreviousStatus, loan.EffectiveAnnualRate,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to update loan terms: %v", err)
+		}
+		rows, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to get affected rows: %v", err)
+		}
+		if rows == 0 {
+			return ErrLoanStatusChanged
+		}
+
+		restructuring.LoanID = loan.ID
+		restructuring.CreatedAt = now
+		err = tx.QueryRowContext(ctx, `
+			INSERT INTO loan_restructurings (
+				loan_id, previous_status, previous_tenure_months, previous_interest_rate,
+				previous_monthly_payment, outstanding_principal, capitalized_amount,
+				new_tenure_months, new_interest_rate, grace_period_months,
+				new_monthly_payment, reason, created_at
+			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+			RETURNING id`,
+			restructuring.LoanID, restructuring.PreviousStatus, restructuring.PreviousTenureMonths,
+			restructuring.PreviousInterestRate, restructuring.PreviousMonthlyPayment,
+			restructuring.OutstandingPrincipal, restructuring.CapitalizedAmount,
+			restructuring.NewTenureMonths, restructuring.NewInterestRate, restructuring.GracePeriodMonths,
+			restructuring.NewMonthlyPayment, restructuring.Reason, restructuring.CreatedAt,
+		).Scan(&restructuring.ID)
+		if err != nil {
+			return fmt.Errorf("failed to create restructuring: %v", err)
+		}
+
+		_, err = tx.ExecContext(ctx, `
+			UPDATE installments
+			SET status = $1, updated_at = $2
+			WHERE loan_id = $3 AND status NOT IN ($4, $1) AND deleted_at IS NULL`,
+			model.InstallmentStatusClosed, now, loan.ID, model.InstallmentStatusPaid,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to close installments: %v", err)
+		}
+
+		for i := range installments {
+			inst := &installments[i]
+			inst.LoanID = loan.ID
+			inst.RestructuringID = &restructuring.ID
+			inst.CreatedAt = now
+			inst.UpdatedAt = now
+
+			err := tx.QueryRowContext(ctx, `
+				INSERT INTO installments (
+					loan_id, installment_number, due_date, principal_amount,
+					interest_amount, total_amount, remaining_balance, status,
+					restructuring_id, created_at, updated_at
+				) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+				RETURNING id`,
+				inst.LoanID, inst.InstallmentNumber, inst.DueDate, inst.PrincipalAmount,
+				inst.InterestAmount, inst.TotalAmount, inst.RemainingBalance, inst.Status,
+				inst.RestructuringID, inst.CreatedAt, inst.UpdatedAt,
+			).Scan(&inst.ID)
+			if err != nil {
+				return fmt.Errorf("failed to create installment: %v", err)
+			}
+		}
+		restructuring.Installments = installments
+
+		return nil
+	})
+}
+
+// GetByLoanID retrieves the restructuring history of a loan
+func (r *RestructuringRepositoryImpl) GetByLoanID(ctx context.Context, loanID string) ([]model.LoanRestructuring, error) {
+	query := `
+		SELECT
+			id, loan_id, previous_status, previous_tenure_months, previous_interest_rate,
+			COALESCE(previous_monthly_payment, 0), outstanding_principal, capitalized_amount,
+			new_tenure_months, new_interest_rate, grace_period_months,
+			new_monthly_payment, COALESCE(reason, ''), created_at
+		FROM loan_restructurings
+		WHERE loan_id = $1
+		ORDER BY created_at ASC`
+
+	rows, err := r.db.QueryContext(ctx, query, loanID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get restructurings: %v", err)
+	}
+	defer rows.Close()
+
+	var restructurings []model.LoanRestructuring
+	for rows.Next() {
+		var rs model.LoanRestructuring
+		err := rows.Scan(
+			&rs.ID, &rs.LoanID, &rs.PreviousStatus, &rs.PreviousTenureMonths, &rs.PreviousInterestRate,
+			&rs.PreviousMonthlyPayment, &rs.OutstandingPrincipal, &rs.CapitalizedAmount,
+			&rs.NewTenureMonths, &rs.NewInterestRate, &rs.GracePeriodMonths,
+			&rs.NewMonthlyPayment, &rs.Reason, &rs.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan restructuring: %v", err)
+		}
+		restructurings = append(restructurings, rs)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating restructurings: %v", err)
+	}
+
+	return restructurings, nil
+}
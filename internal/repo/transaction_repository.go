@@ -0,0 +1,16 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/edosulai/pt-xyz-multifinance/internal/model"
+)
+
+// TransactionRepository defines the interface for financing transaction data access
+type TransactionRepository interface {
+	// Create a transaction with a generated contract number and draw down the user's credit limit for its tenor
+	Create(ctx context.Context, transaction *model.Transaction) error
+
+	// Get transactions by user ID
+	GetUserTransactions(ctx context.Context, userID string, page, pageSize int) ([]model.Transaction, int64, error)
+}
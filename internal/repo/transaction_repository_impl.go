@@ -0,0 +1,119 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/edosulai/pt-xyz-multifinance/internal/model"
+	"github.com/edosulai/pt-xyz-multifinance/pkg/database"
+)
+
+// transactionColumns lists the transaction columns in the order they are scanned
+const transactionColumns = `
+			id, user_id, contract_number, tenure_months, otr_price, admin_fee,
+			installment_amount, interest_amount, asset_name, sales_channel,
+			created_at, updated_at`
+
+// TransactionRepositoryImpl implements TransactionRepository interface using native SQL
+type TransactionRepositoryImpl struct {
+	db *database.DB
+}
+
+// NewTransactionRepository creates a new transaction repository instance
+func NewTransactionRepository(db *database.DB) TransactionRepository {
+	return &TransactionRepositoryImpl{db: db}
+}
+
+// Create inserts a transaction and consumes the credit limit for its OTR price in a single transaction.
+// Contract numbers are generated by the database as XYZ-YYYYMMDD-NNNNNNNN.
+func (r *TransactionRepositoryImpl) Create(ctx context.Context, transaction *model.Transaction) error {
+	return r.db.WithTx(ctx, func(tx *sql.Tx) error {
+		limitID, err := consumeCreditLimit(ctx, tx, transaction.UserID, transaction.TenureMonths, transaction.OTRPrice)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		transaction.CreatedAt = now
+		transaction.UpdatedAt = now
+
+		err = tx.QueryRowContext(ctx, `
+			INSERT INTO transactions (
+				user_id, contract_number, tenure_months, otr_price, admin_fee,
+				installment_amount, interest_amount, asset_name, sales_channel,
+				created_at, updated_at
+			) VALUES (
+				$1, 'XYZ-' || to_char($9::timestamp, 'YYYYMMDD') || '-' || lpad(nextval('transaction_contract_seq')::text, 8, '0'),
+				$2, $3, $4, $5, $6, $7, $8, $9, $10
+			)
+			RETURNING id, contract_number`,
+			transaction.UserID, transaction.TenureMonths, transaction.OTRPrice, transaction.AdminFee,
+			transaction.InstallmentAmount, transaction.InterestAmount, transaction.AssetName, transaction.SalesChannel,
+			transaction.CreatedAt, transaction.UpdatedAt,
+		).Scan(&transaction.ID, &transaction.ContractNumber)
+		if err != nil {
+			return fmt.Errorf("failed to create transaction: %v", err)
+		}
+
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO credit_limit_usages (credit_limit_id, transaction_id, amount, created_at)
+			VALUES ($1, $2, $3, $4)`,
+			limitID, transaction.ID, transaction.OTRPrice, now,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to record credit limit usage: %v", err)
+		}
+
+		return nil
+	})
+}
+
+// GetUserTransactions retrieves all transactions for a user with pagination
+func (r *TransactionRepositoryImpl) GetUserTransactions(ctx context.Context, userID string, page, pageSize int) ([]model.Transaction, int64, error) {
+	var total int64
+	countQuery := `
+		SELECT COUNT(*)
+		FROM transactions
+		WHERE user_id = $1 AND deleted_at IS NULL`
+
+	err := r.db.QueryRowContext(ctx, countQuery, userID).Scan(&total)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count transactions: %v", err)
+	}
+
+	offset := (page - 1) * pageSize
+	query := `
+		SELECT ` + transactionColumns + `
+		FROM transactions
+		WHERE user_id = $1 AND deleted_at IS NULL
+		ORDER BY created_at DESC
+		LIMIT $2 OFFSET $3`
+
+	rows, err := r.db.QueryContext(ctx, query, userID, pageSize, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get transactions: %v", err)
+	}
+	defer rows.Close()
+
+	var transactions []model.Transaction
+	for rows.Next() {
+		var t model.Transaction
+		err := rows.Scan(
+			&t.ID, &t.UserID, &t.ContractNumber, &t.TenureMonths, &t.OTRPrice, &t.AdminFee,
+			&t.InstallmentAmount, &t.InterestAmount, &t.AssetName, &t.SalesChannel,
+			&t.CreatedAt, &t.UpdatedAt,
+		)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan transaction: %v", err)
+		}
+		transactions = append(transactions, t)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating transactions: %v", err)
+	}
+
+	return transactions, total, nil
+}
@@ -25,17 +25,21 @@ func (r *UserRepositoryImpl) Create(ctx context.Context, user *model.User) error
 	query := `
 		INSERT INTO users (
 			username, email, password, full_name, phone_number,
-			address, ktp_number, status, monthly_income,
+			address, ktp_number, date_of_birth, gender, status, role, monthly_income,
 			failed_login_attempts, created_at, updated_at
 		) VALUES (
-			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12
+			$1, $2, $3, $4, $5, $6, $7, $8, NULLIF($9, ''), $10, $11, $12, $13, $14, $15
 		) RETURNING id`
 
+	if user.Role == "" {
+		user.Role = model.ActorRoleCustomer
+	}
+
 	now := time.Now()
 	err := r.db.QueryRowContext(ctx, query,
 		user.Username, user.Email, user.Password, user.FullName,
-		user.PhoneNumber, user.Address, user.KTPNumber, user.Status,
-		user.MonthlyIncome, 0, now, now,
+		user.PhoneNumber, user.Address, user.KTPNumber, user.DateOfBirth, user.Gender, user.Status,
+		user.Role, user.MonthlyIncome, 0, now, now,
 	).Scan(&user.ID)
 
 	if err != nil {
@@ -57,7 +61,7 @@ func (r *UserRepositoryImpl) Create(ctx context.Context, user *model.User) error
 func (r *UserRepositoryImpl) GetByID(ctx context.Context, id string) (*model.User, error) {
 	query := `
 		SELECT id, username, email, password, full_name, phone_number,
-			   address, ktp_number, status, monthly_income,
+			   address, ktp_number, date_of_birth, COALESCE(gender, ''), status, role, monthly_income,
 			   failed_login_attempts, last_failed_login, locked_until,
 			   created_at, updated_at
 		FROM users
@@ -75,7 +79,7 @@ func (r *UserRepositoryImpl) GetByID(ctx context.Context, id string) (*model.Use
 func (r *UserRepositoryImpl) GetByUsername(ctx context.Context, username string) (*model.User, error) {
 	query := `
 		SELECT id, username, email, password, full_name, phone_number,
-			   address, ktp_number, status, monthly_income,
+			   address, ktp_number, date_of_birth, COALESCE(gender, ''), status, role, monthly_income,
 			   failed_login_attempts, last_failed_login, locked_until,
 			   created_at, updated_at
 		FROM users
@@ -94,7 +98,7 @@ func (r *UserRepositoryImpl) GetByUsername(ctx context.Context, username string)
 func (r *UserRepositoryImpl) GetByEmail(ctx context.Context, email string) (*model.User, error) {
 	query := `
 		SELECT id, username, email, password, full_name, phone_number,
-			   address, ktp_number, status, monthly_income,
+			   address, ktp_number, date_of_birth, COALESCE(gender, ''), status, role, monthly_income,
 			   failed_login_attempts, last_failed_login, locked_until,
 			   created_at, updated_at
 		FROM users
@@ -120,17 +124,19 @@ func (r *UserRepositoryImpl) Update(ctx context.Context, user *model.User) error
 			phone_number = $5,
 			address = $6,
 			ktp_number = $7,
-			status = $8,
-			monthly_income = $9,
-			failed_login_attempts = $10,
-			last_failed_login = $11,
-			locked_until = $12,
-			updated_at = $13
-		WHERE id = $14 AND deleted_at IS NULL`
+			date_of_birth = $8,
+			gender = NULLIF($9, ''),
+			status = $10,
+			monthly_income = $11,
+			failed_login_attempts = $12,
+			last_failed_login = $13,
+			locked_until = $14,
+			updated_at = $15
+		WHERE id = $16 AND deleted_at IS NULL`
 
 	result, err := r.db.ExecContext(ctx, query,
 		user.Username, user.Email, user.Password, user.FullName,
-		user.PhoneNumber, user.Address, user.KTPNumber, user.Status,
+		user.PhoneNumber, user.Address, user.KTPNumber, user.DateOfBirth, user.Gender, user.Status,
 		user.MonthlyIncome, user.FailedLoginAttempts,
 		user.LastFailedLogin, user.LockedUntil,
 		time.Now(), user.ID,
@@ -179,7 +185,7 @@ func (r *UserRepositoryImpl) scanSingleUser(ctx context.Context, query string, a
 	err := r.db.QueryRowContext(ctx, query, args...).Scan(
 		&user.ID, &user.Username, &user.Email, &user.Password,
 		&user.FullName, &user.PhoneNumber, &user.Address,
-		&user.KTPNumber, &user.Status, &user.MonthlyIncome,
+		&user.KTPNumber, &user.DateOfBirth, &user.Gender, &user.Status, &user.Role, &user.MonthlyIncome,
 		&user.FailedLoginAttempts, &user.LastFailedLogin,
 		&user.LockedUntil, &user.CreatedAt, &user.UpdatedAt,
 	)
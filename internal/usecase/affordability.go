@@ -0,0 +1,89 @@
+package usecase
+
+import (
+	"fmt"
+
+	"github.com/edosulai/pt-xyz-multifinance/internal/model"
+)
+
+// AffordabilityRule configures the debt-to-income check applied to new loan applications
+type AffordabilityRule struct {
+	// MaxDTIPercent is the highest share of monthly income that may go to loan payments
+	MaxDTIPercent float64
+	// EstimateInterestRate is the annual rate used to estimate the payment of a loan not yet priced
+	EstimateInterestRate float64
+	// RejectAboveMax rejects applications above MaxDTIPercent; otherwise they are only flagged
+	RejectAboveMax bool
+}
+
+// DefaultAffordabilityRule is used when no affordability rule is configured
+var DefaultAffordabilityRule = AffordabilityRule{
+	MaxDTIPercent:        30,
+	EstimateInterestRate: 12,
+	RejectAboveMax:       true,
+}
+
+// activeLoanStatuses are the statuses whose monthly payments count towards a user's debt
+var activeLoanStatuses = []model.LoanStatus{
+	model.LoanStatusOffered,
+	model.LoanStatusApproved,
+	model.LoanStatusPartiallyDisbursed,
+	model.LoanStatusDisbursed,
+	model.LoanStatusRestructured,
+	model.LoanStatusDefaulted,
+}
+
+// AffordabilityError is returned when an application would push the user's debt-to-income
+// ratio above the configured maximum. It unwraps to a ValidationError.
+type AffordabilityError struct {
+	MonthlyIncome    model.Money
+	ExistingPayments model.Money
+	EstimatedPayment model.Money
+	DTIPercent       float64
+	MaxDTIPercent    float64
+}
+
+func (e *AffordabilityError) Error() string {
+	return fmt.Sprintf("debt-to-income ratio of %.2f%% exceeds the maximum of %.2f%%: monthly payments would exceed the affordable amount of %s by %s",
+		e.DTIPercent, e.MaxDTIPercent, e.AffordablePayment(), e.Shortfall())
+}
+
+// AffordablePayment returns the total monthly payment the user's income supports
+func (e *AffordabilityError) AffordablePayment() model.Money {
+	return e.MonthlyIncome.Percent(e.MaxDTIPercent)
+}
+
+// Shortfall returns how much the monthly payments exceed the affordable amount
+func (e *AffordabilityError) Shortfall() model.Money {
+	return e.ExistingPayments + e.EstimatedPayment - e.AffordablePayment()
+}
+
+// Unwrap lets callers treat the error as a ValidationError
+func (e *AffordabilityError) Unwrap() error {
+	return ValidationError{Message: e.Error()}
+}
+
+// affordabilityAssessment is the outcome of checking an application against an AffordabilityRule
+type affordabilityAssessment struct {
+	dtiPercent float64
+	exceeded   bool
+}
+
+// assessAffordability computes the debt-to-income ratio after adding estimatedPayment to the
+// existing monthly payments. Income must be positive.
+func assessAffordability(rule AffordabilityRule, monthlyIncome, existingPayments, estimatedPayment model.Money) affordabilityAssessment {
+	dti := roundPercent(float64(existingPayments+estimatedPayment) / float64(monthlyIncome) * 100)
+	return affordabilityAssessment{
+		dtiPercent: dti,
+		exceeded:   dti > rule.MaxDTIPercent,
+	}
+}
+
+// existingMonthlyPayments sums the monthly payments of loans that are still being repaid
+func existingMonthlyPayments(loans []model.Loan) model.Money {
+	var total model.Money
+	for _, loan := range loans {
+		total += loan.MonthlyPayment
+	}
+	return total
+}
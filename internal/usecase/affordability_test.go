@@ -0,0 +1,82 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/edosulai/pt-xyz-multifinance/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAssessAffordability(t *testing.T) {
+	rule := AffordabilityRule{MaxDTIPercent: 30}
+
+	within := assessAffordability(rule, model.NewMoney(10000000), model.NewMoney(1000000), model.NewMoney(2000000))
+	assert.Equal(t, 30.0, within.dtiPercent)
+	assert.False(t, within.exceeded)
+
+	above := assessAffordability(rule, model.NewMoney(10000000), model.NewMoney(1500000), model.NewMoney(2000000))
+	assert.Equal(t, 35.0, above.dtiPercent)
+	assert.True(t, above.exceeded)
+}
+
+func TestApplyLoan_Affordability(t *testing.T) {
+	ctx := context.Background()
+	user := &model.User{ID: "user-1", MonthlyIncome: model.NewMoney(5000000)}
+	limit := &model.CreditLimit{TenureMonths: 12, LimitAmount: model.NewMoney(50000000)}
+	activeLoans := []model.Loan{{MonthlyPayment: model.NewMoney(1000000)}, {MonthlyPayment: model.NewMoney(200000)}}
+
+	setup := func() (*MockLoanRepository, *MockUserRepository, *MockCreditLimitRepository) {
+		loanRepo := new(MockLoanRepository)
+		userRepo := new(MockUserRepository)
+		limitRepo := new(MockCreditLimitRepository)
+		userRepo.On("GetByID", ctx, "user-1").Return(user, nil)
+		limitRepo.On("GetByUserAndTenure", ctx, "user-1", 12).Return(limit, nil)
+		loanRepo.On("GetUserLoansByStatus", ctx, "user-1", activeLoanStatuses).Return(activeLoans, nil)
+		loanRepo.On("GetUserLoans", ctx, "user-1", 1, creditHistoryPageSize).Return(activeLoans, int64(len(activeLoans)), nil)
+		return loanRepo, userRepo, limitRepo
+	}
+
+	t.Run("rejects above maximum ratio", func(t *testing.T) {
+		loanRepo, userRepo, limitRepo := setup()
+		uc := NewLoanUseCase(loanRepo, userRepo, nil, nil, nil, limitRepo, newProductRepo(ctx, personalLoanProduct()), nil, nil)
+
+		_, err := uc.ApplyLoan(ctx, "user-1", "PERSONAL", model.NewMoney(10000000), 12, "vehicle")
+
+		var affordabilityErr *AffordabilityError
+		assert.ErrorAs(t, err, &affordabilityErr)
+		assert.ErrorAs(t, err, &ValidationError{})
+		assert.Equal(t, model.NewMoney(1200000), affordabilityErr.ExistingPayments)
+		assert.Equal(t, model.NewMoney(1500000), affordabilityErr.AffordablePayment())
+		assert.Equal(t, model.NewMoney(1200000)+affordabilityErr.EstimatedPayment-model.NewMoney(1500000), affordabilityErr.Shortfall())
+		loanRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("flags instead of rejecting when configured", func(t *testing.T) {
+		loanRepo, userRepo, limitRepo := setup()
+		loanRepo.On("Create", ctx, mock.AnythingOfType("*model.Loan"), DefaultApplicationPolicy).Return(nil)
+		rule := DefaultAffordabilityRule
+		rule.RejectAboveMax = false
+		uc := NewLoanUseCase(loanRepo, userRepo, nil, nil, nil, limitRepo, newProductRepo(ctx, personalLoanProduct()), nil, nil, WithAffordabilityRule(rule))
+
+		loan, err := uc.ApplyLoan(ctx, "user-1", "PERSONAL", model.NewMoney(10000000), 12, "vehicle")
+
+		assert.NoError(t, err)
+		assert.True(t, loan.AffordabilityFlagged)
+		assert.Greater(t, loan.DTIRatio, 30.0)
+	})
+
+	t.Run("accepts within maximum ratio", func(t *testing.T) {
+		loanRepo, userRepo, limitRepo := setup()
+		loanRepo.On("Create", ctx, mock.AnythingOfType("*model.Loan"), DefaultApplicationPolicy).Return(nil)
+		uc := NewLoanUseCase(loanRepo, userRepo, nil, nil, nil, limitRepo, newProductRepo(ctx, personalLoanProduct()), nil, nil)
+
+		loan, err := uc.ApplyLoan(ctx, "user-1", "PERSONAL", model.NewMoney(2000000), 12, "education")
+
+		assert.NoError(t, err)
+		assert.False(t, loan.AffordabilityFlagged)
+		assert.LessOrEqual(t, loan.DTIRatio, 30.0)
+		assert.Equal(t, []model.DocumentType{model.DocumentTypeKTP}, loan.RequiredDocumentTypes())
+	})
+}
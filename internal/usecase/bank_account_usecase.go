@@ -0,0 +1,132 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/edosulai/pt-xyz-multifinance/internal/model"
+	"github.com/edosulai/pt-xyz-multifinance/internal/repo"
+)
+
+// BankAccountUseCase defines the interface for customer bank account business logic
+type BankAccountUseCase interface {
+	// Register a bank account for a user; it must be verified before loans can be disbursed to it
+	RegisterBankAccount(ctx context.Context, userID, bankCode, accountNumber, holderName string) (*model.BankAccount, error)
+
+	// Get the bank accounts of a user
+	ListBankAccounts(ctx context.Context, userID string) ([]model.BankAccount, error)
+
+	// Verify or reject a pending bank account (for admin)
+	VerifyBankAccount(ctx context.Context, accountID string, verified bool, reason string) (*model.BankAccount, error)
+}
+
+// BankAccountUseCaseImpl implements BankAccountUseCase interface
+type BankAccountUseCaseImpl struct {
+	bankAccountRepo repo.BankAccountRepository
+	userRepo        repo.UserRepository
+}
+
+// NewBankAccountUseCase creates a new bank account use case instance
+func NewBankAccountUseCase(bankAccountRepo repo.BankAccountRepository, userRepo repo.UserRepository) BankAccountUseCase {
+	return &BankAccountUseCaseImpl{
+		bankAccountRepo: bankAccountRepo,
+		userRepo:        userRepo,
+	}
+}
+
+// RegisterBankAccount validates and stores a bank account as pending verification
+func (uc *BankAccountUseCaseImpl) RegisterBankAccount(ctx context.Context, userID, bankCode, accountNumber, holderName string) (*model.BankAccount, error) {
+	bankCode = strings.TrimSpace(bankCode)
+	accountNumber = strings.TrimSpace(accountNumber)
+	holderName = strings.TrimSpace(holderName)
+
+	if len(bankCode) != 3 || !isDigits(bankCode) {
+		return nil, NewValidationError("bank code must be 3 digits")
+	}
+	if len(accountNumber) < 6 || len(accountNumber) > 20 || !isDigits(accountNumber) {
+		return nil, NewValidationError("account number must be 6 to 20 digits")
+	}
+	if holderName == "" {
+		return nil, NewValidationError("account holder name is required")
+	}
+	if len(holderName) > 100 {
+		return nil, NewValidationError("account holder name must be at most 100 characters")
+	}
+
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+
+	account := &model.BankAccount{
+		UserID:        userID,
+		BankCode:      bankCode,
+		AccountNumber: accountNumber,
+		HolderName:    holderName,
+		Status:        model.BankAccountStatusPending,
+	}
+	if err := uc.bankAccountRepo.Create(ctx, account); err != nil {
+		if errors.Is(err, repo.ErrBankAccountExists) {
+			return nil, NewConflictError("bank account is already registered")
+		}
+		return nil, err
+	}
+
+	return account, nil
+}
+
+// ListBankAccounts retrieves the bank accounts of a user
+func (uc *BankAccountUseCaseImpl) ListBankAccounts(ctx context.Context, userID string) ([]model.BankAccount, error) {
+	return uc.bankAccountRepo.GetByUserID(ctx, userID)
+}
+
+// VerifyBankAccount records the outcome of checking a pending account against the bank.
+// A rejection needs a reason so the customer knows what to correct.
+func (uc *BankAccountUseCaseImpl) VerifyBankAccount(ctx context.Context, accountID string, verified bool, reason string) (*model.BankAccount, error) {
+	reason = strings.TrimSpace(reason)
+	if !verified && reason == "" {
+		return nil, NewValidationError("a reason is required to reject a bank account")
+	}
+
+	account, err := uc.bankAccountRepo.GetByID(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	if account == nil {
+		return nil, ErrBankAccountNotFound
+	}
+	if account.Status != model.BankAccountStatusPending {
+		return nil, NewConflictError("bank account has already been " + string(account.Status))
+	}
+
+	now := time.Now()
+	account.Status = model.BankAccountStatusRejected
+	account.RejectionReason = reason
+	if verified {
+		account.Status = model.BankAccountStatusVerified
+		account.RejectionReason = ""
+	}
+	account.VerifiedBy = model.ActorFromContext(ctx).ID
+	account.VerifiedAt = &now
+
+	if err := uc.bankAccountRepo.UpdateVerification(ctx, account); err != nil {
+		return nil, err
+	}
+
+	return account, nil
+}
+
+// isDigits reports whether s consists only of ASCII digits
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
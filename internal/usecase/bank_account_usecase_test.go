@@ -0,0 +1,415 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/edosulai/pt-xyz-multifinance/internal/model"
+	"github.com/edosulai/pt-xyz-multifinance/internal/repo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockBankAccountRepository struct {
+	mock.Mock
+}
+
+func (m *MockBankAccountRepository) Create(ctx context.Context, account *model.BankAccount) error {
+	args := m.Called(ctx, account)
+	return args.Error(0)
+}
+
+func (m *MockBankAccountRepository) GetByID(ctx context.Context, id string) (*model.BankAccount, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.BankAccount), args.Error(1)
+}
+
+func (m *MockBankAccountRepository) GetByUserID(ctx context.Context, userID string) ([]model.BankAccount, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).([]model.BankAccount), args.Error(1)
+}
+
+func (m *MockBankAccountRepository) UpdateVerification(ctx context.Context, account *model.BankAccount) error {
+	args := m.Called(ctx, account)
+	return args.Error(0)
+}
+
+type MockDisbursementRepository struct {
+	mock.Mock
+}
+
+func (m *MockDisbursementRepository) Create(ctx context.Context, instruction *model.DisbursementInstruction, loan *model.Loan) error {
+	args := m.Called(ctx, instruction, loan)
+	return args.Error(0)
+}
+
+func (m *MockDisbursementRepository) GetByID(ctx context.Context, id string) (*model.DisbursementInstruction, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.DisbursementInstruction), args.Error(1)
+}
+
+func (m *MockDisbursementRepository) GetByLoanID(ctx context.Context, loanID string) ([]model.DisbursementInstruction, error) {
+	args := m.Called(ctx, loanID)
+	return args.Get(0).([]model.DisbursementInstruction), args.Error(1)
+}
+
+func (m *MockDisbursementRepository) UpdateStatus(ctx context.Context, instruction *model.DisbursementInstruction, from model.DisbursementStatus) error {
+	args := m.Called(ctx, instruction, from)
+	return args.Error(0)
+}
+
+func (m *MockDisbursementRepository) ClaimPending(ctx context.Context, batchReference string, fn func(instructions []model.DisbursementInstruction) error) error {
+	args := m.Called(ctx, batchReference, fn)
+	if err := args.Error(1); err != nil {
+		return err
+	}
+	return fn(args.Get(0).([]model.DisbursementInstruction))
+}
+
+func (m *MockDisbursementRepository) Complete(ctx context.Context, instruction *model.DisbursementInstruction, from model.DisbursementStatus, tranche *model.Disbursement, loan *model.Loan, installments []model.Installment) error {
+	args := m.Called(ctx, instruction, from, tranche, loan, installments)
+	return args.Error(0)
+}
+
+func (m *MockDisbursementRepository) GetTranches(ctx context.Context, loanID string) ([]model.Disbursement, error) {
+	args := m.Called(ctx, loanID)
+	return args.Get(0).([]model.Disbursement), args.Error(1)
+}
+
+type MockInstallmentRepository struct {
+	mock.Mock
+}
+
+func (m *MockInstallmentRepository) ReplaceForLoan(ctx context.Context, loanID string, installments []model.Installment) error {
+	args := m.Called(ctx, loanID, installments)
+	return args.Error(0)
+}
+
+func (m *MockInstallmentRepository) GetByLoanID(ctx context.Context, loanID string) ([]model.Installment, error) {
+	args := m.Called(ctx, loanID)
+	return args.Get(0).([]model.Installment), args.Error(1)
+}
+
+func (m *MockInstallmentRepository) GetOverdue(ctx context.Context, asOf time.Time) ([]model.Installment, error) {
+	args := m.Called(ctx, asOf)
+	return args.Get(0).([]model.Installment), args.Error(1)
+}
+
+func (m *MockDisbursementRepository) Fail(ctx context.Context, instruction *model.DisbursementInstruction, from model.DisbursementStatus) error {
+	args := m.Called(ctx, instruction, from)
+	return args.Error(0)
+}
+
+func TestRegisterBankAccount(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("invalid details", func(t *testing.T) {
+		uc := NewBankAccountUseCase(nil, nil)
+		for _, tc := range [][3]string{
+			{"14", "1234567890", "Budi"},
+			{"0A4", "1234567890", "Budi"},
+			{"014", "12345", "Budi"},
+			{"014", "1234-567890", "Budi"},
+			{"014", "1234567890", "  "},
+		} {
+			_, err := uc.RegisterBankAccount(ctx, "user-1", tc[0], tc[1], tc[2])
+			assert.ErrorAs(t, err, &ValidationError{}, tc)
+		}
+	})
+
+	t.Run("pending until verified", func(t *testing.T) {
+		accountRepo := new(MockBankAccountRepository)
+		userRepo := new(MockUserRepository)
+		userRepo.On("GetByID", ctx, "user-1").Return(&model.User{ID: "user-1"}, nil)
+		accountRepo.On("Create", ctx, mock.AnythingOfType("*model.BankAccount")).Return(nil)
+
+		account, err := NewBankAccountUseCase(accountRepo, userRepo).RegisterBankAccount(ctx, "user-1", " 014 ", "1234567890", " Budi Santoso ")
+
+		assert.NoError(t, err)
+		assert.Equal(t, model.BankAccountStatusPending, account.Status)
+		assert.Equal(t, "014", account.BankCode)
+		assert.Equal(t, "Budi Santoso", account.HolderName)
+	})
+
+	t.Run("already registered", func(t *testing.T) {
+		accountRepo := new(MockBankAccountRepository)
+		userRepo := new(MockUserRepository)
+		userRepo.On("GetByID", ctx, "user-1").Return(&model.User{ID: "user-1"}, nil)
+		accountRepo.On("Create", ctx, mock.AnythingOfType("*model.BankAccount")).Return(repo.ErrBankAccountExists)
+
+		_, err := NewBankAccountUseCase(accountRepo, userRepo).RegisterBankAccount(ctx, "user-1", "014", "1234567890", "Budi")
+
+		assert.ErrorAs(t, err, &ConflictError{})
+	})
+}
+
+func TestVerifyBankAccount(t *testing.T) {
+	ctx := model.ContextWithActor(context.Background(), model.Actor{ID: "admin-1", Role: model.ActorRoleAdmin})
+
+	t.Run("verified by admin", func(t *testing.T) {
+		accountRepo := new(MockBankAccountRepository)
+		accountRepo.On("GetByID", ctx, "acc-1").Return(&model.BankAccount{ID: "acc-1", Status: model.BankAccountStatusPending}, nil)
+		accountRepo.On("UpdateVerification", ctx, mock.AnythingOfType("*model.BankAccount")).Return(nil)
+
+		account, err := NewBankAccountUseCase(accountRepo, nil).VerifyBankAccount(ctx, "acc-1", true, "")
+
+		assert.NoError(t, err)
+		assert.True(t, account.IsVerified())
+		assert.Equal(t, "admin-1", account.VerifiedBy)
+		assert.NotNil(t, account.VerifiedAt)
+	})
+
+	t.Run("rejection needs a reason", func(t *testing.T) {
+		_, err := NewBankAccountUseCase(nil, nil).VerifyBankAccount(ctx, "acc-1", false, " ")
+		assert.ErrorAs(t, err, &ValidationError{})
+	})
+
+	t.Run("only pending accounts", func(t *testing.T) {
+		accountRepo := new(MockBankAccountRepository)
+		accountRepo.On("GetByID", ctx, "acc-1").Return(&model.BankAccount{ID: "acc-1", Status: model.BankAccountStatusRejected}, nil)
+
+		_, err := NewBankAccountUseCase(accountRepo, nil).VerifyBankAccount(ctx, "acc-1", true, "")
+
+		assert.ErrorAs(t, err, &ConflictError{})
+		accountRepo.AssertNotCalled(t, "UpdateVerification", mock.Anything, mock.Anything)
+	})
+}
+
+func TestDisburseLoan_BankAccount(t *testing.T) {
+	ctx := model.ContextWithActor(context.Background(), model.Actor{ID: "admin-1", Role: model.ActorRoleAdmin})
+	loan := &model.Loan{ID: "loan-1", UserID: "user-1", Status: model.LoanStatusApproved, Amount: model.NewMoney(10000000), TenureMonths: 12, InterestRate: 12}
+
+	tests := []struct {
+		name    string
+		account *model.BankAccount
+		message string
+	}{
+		{"unverified", &model.BankAccount{ID: "acc-1", UserID: "user-1", Status: model.BankAccountStatusPending}, "must be verified"},
+		{"another user's account", &model.BankAccount{ID: "acc-1", UserID: "user-2", Status: model.BankAccountStatusVerified}, "does not belong to the borrower"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loanRepo := new(MockLoanRepository)
+			accountRepo := new(MockBankAccountRepository)
+			disbursementRepo := new(MockDisbursementRepository)
+			loanRepo.On("GetByID", ctx, "loan-1").Return(loan, nil)
+			loanRepo.On("GetOfferAcceptance", ctx, "loan-1").Return(acceptedOffer(t, loan), nil)
+			accountRepo.On("GetByID", ctx, "acc-1").Return(tt.account, nil)
+
+			uc := NewLoanUseCase(loanRepo, nil, nil, nil, nil, nil, nil, accountRepo, disbursementRepo)
+			_, err := uc.DisburseLoan(ctx, "loan-1", model.NewMoney(10000000), "acc-1")
+
+			assert.ErrorAs(t, err, &ValidationError{})
+			assert.Contains(t, err.Error(), tt.message)
+			disbursementRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestDisburseLoan_PendingUntilBankConfirms(t *testing.T) {
+	ctx := model.ContextWithActor(context.Background(), model.Actor{ID: "admin-1", Role: model.ActorRoleAdmin})
+	account := &model.BankAccount{ID: "acc-1", UserID: "user-1", Status: model.BankAccountStatusVerified}
+
+	setup := func(createErr error) (*MockLoanRepository, *MockDisbursementRepository, LoanUseCase) {
+		loan := &model.Loan{ID: "loan-1", UserID: "user-1", Status: model.LoanStatusApproved, Amount: model.NewMoney(10000000), TenureMonths: 12, InterestRate: 12}
+		loanRepo := new(MockLoanRepository)
+		accountRepo := new(MockBankAccountRepository)
+		disbursementRepo := new(MockDisbursementRepository)
+		loanRepo.On("GetByID", ctx, "loan-1").Return(loan, nil)
+		loanRepo.On("GetOfferAcceptance", ctx, "loan-1").Return(acceptedOffer(t, loan), nil)
+		accountRepo.On("GetByID", ctx, "acc-1").Return(account, nil)
+		disbursementRepo.On("Create", ctx, mock.AnythingOfType("*model.DisbursementInstruction"), loan).Return(createErr)
+		return loanRepo, disbursementRepo, NewLoanUseCase(loanRepo, nil, nil, nil, nil, nil, nil, accountRepo, disbursementRepo)
+	}
+
+	t.Run("instruction queued", func(t *testing.T) {
+		loanRepo, _, uc := setup(nil)
+		instruction, err := uc.DisburseLoan(ctx, "loan-1", model.NewMoney(10000000), "acc-1")
+
+		assert.NoError(t, err)
+		assert.Equal(t, model.DisbursementStatusPending, instruction.Status)
+		assert.Equal(t, model.NewMoney(10000000), instruction.Amount)
+		loanRepo.AssertNotCalled(t, "UpdateLoan", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("credit limit exceeded", func(t *testing.T) {
+		_, _, uc := setup(repo.ErrCreditLimitExceeded)
+		_, err := uc.DisburseLoan(ctx, "loan-1", model.NewMoney(10000000), "acc-1")
+		assert.ErrorAs(t, err, &ValidationError{})
+	})
+
+	t.Run("already in progress", func(t *testing.T) {
+		_, _, uc := setup(repo.ErrDisbursementInProgress)
+		_, err := uc.DisburseLoan(ctx, "loan-1", model.NewMoney(10000000), "acc-1")
+		assert.ErrorAs(t, err, &ConflictError{})
+	})
+}
+
+func TestDisburseLoan_Tranches(t *testing.T) {
+	ctx := model.ContextWithActor(context.Background(), model.Actor{ID: "admin-1", Role: model.ActorRoleAdmin})
+	account := &model.BankAccount{ID: "acc-1", UserID: "user-1", Status: model.BankAccountStatusVerified}
+
+	setup := func(status model.LoanStatus, disbursed model.Money) (*MockDisbursementRepository, LoanUseCase) {
+		loan := &model.Loan{ID: "loan-1", UserID: "user-1", Status: status, Amount: model.NewMoney(10000000), TenureMonths: 12, InterestRate: 12, DisbursedAmount: disbursed}
+		loanRepo := new(MockLoanRepository)
+		accountRepo := new(MockBankAccountRepository)
+		disbursementRepo := new(MockDisbursementRepository)
+		loanRepo.On("GetByID", ctx, "loan-1").Return(loan, nil)
+		loanRepo.On("GetOfferAcceptance", ctx, "loan-1").Return(acceptedOffer(t, loan), nil)
+		accountRepo.On("GetByID", ctx, "acc-1").Return(account, nil)
+		disbursementRepo.On("Create", ctx, mock.Anything, loan).Return(nil)
+		return disbursementRepo, NewLoanUseCase(loanRepo, nil, nil, nil, nil, nil, nil, accountRepo, disbursementRepo)
+	}
+
+	t.Run("next tranche", func(t *testing.T) {
+		_, uc := setup(model.LoanStatusPartiallyDisbursed, model.NewMoney(6000000))
+		instruction, err := uc.DisburseLoan(ctx, "loan-1", model.NewMoney(4000000), "acc-1")
+		assert.NoError(t, err)
+		assert.Equal(t, model.NewMoney(4000000), instruction.Amount)
+	})
+
+	t.Run("more than the undisbursed amount", func(t *testing.T) {
+		disbursementRepo, uc := setup(model.LoanStatusPartiallyDisbursed, model.NewMoney(6000000))
+		_, err := uc.DisburseLoan(ctx, "loan-1", model.MustParseMoney("4000000.01"), "acc-1")
+		assert.ErrorAs(t, err, &ValidationError{})
+		assert.Contains(t, err.Error(), "4000000.00")
+		disbursementRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("fully disbursed", func(t *testing.T) {
+		_, uc := setup(model.LoanStatusDisbursed, model.NewMoney(6000000))
+		_, err := uc.DisburseLoan(ctx, "loan-1", model.NewMoney(1), "acc-1")
+		var transitionErr *model.StatusTransitionError
+		assert.ErrorAs(t, err, &transitionErr)
+	})
+}
+
+func TestUpdateDisbursementStatus(t *testing.T) {
+	ctx := model.ContextWithActor(context.Background(), model.SystemActor)
+
+	setup := func(status model.DisbursementStatus) (*MockDisbursementRepository, LoanUseCase) {
+		disbursementRepo := new(MockDisbursementRepository)
+		disbursementRepo.On("GetByID", ctx, "dis-1").Return(&model.DisbursementInstruction{ID: "dis-1", LoanID: "loan-1", Amount: model.NewMoney(6000000), Status: status}, nil)
+		disbursementRepo.On("UpdateStatus", ctx, mock.AnythingOfType("*model.DisbursementInstruction"), status).Return(nil)
+		disbursementRepo.On("Fail", ctx, mock.AnythingOfType("*model.DisbursementInstruction"), status).Return(nil)
+		return disbursementRepo, NewLoanUseCase(nil, nil, nil, nil, nil, nil, nil, nil, disbursementRepo)
+	}
+
+	t.Run("sent", func(t *testing.T) {
+		_, uc := setup(model.DisbursementStatusPending)
+		sent, err := uc.UpdateDisbursementStatus(ctx, "dis-1", model.DisbursementStatusSent, "TRF-001", "")
+		assert.NoError(t, err)
+		assert.Equal(t, "TRF-001", sent.BankReference)
+		assert.NotNil(t, sent.SentAt)
+		assert.Nil(t, sent.CompletedAt)
+	})
+
+	t.Run("first tranche", func(t *testing.T) {
+		loan := &model.Loan{ID: "loan-1", UserID: "user-1", Status: model.LoanStatusApproved, Amount: model.NewMoney(10000000), TenureMonths: 12, InterestRate: 12}
+		loanRepo := new(MockLoanRepository)
+		loanRepo.On("GetByID", ctx, "loan-1").Return(loan, nil)
+		disbursementRepo := new(MockDisbursementRepository)
+		disbursementRepo.On("GetByID", ctx, "dis-1").Return(&model.DisbursementInstruction{ID: "dis-1", LoanID: "loan-1", Amount: model.NewMoney(6000000), Status: model.DisbursementStatusSent}, nil)
+		disbursementRepo.On("Complete", ctx, mock.AnythingOfType("*model.DisbursementInstruction"), model.DisbursementStatusSent, mock.AnythingOfType("*model.Disbursement"), loan, mock.AnythingOfType("[]model.Installment")).Return(nil)
+
+		uc := NewLoanUseCase(loanRepo, nil, nil, nil, nil, nil, nil, nil, disbursementRepo)
+		done, err := uc.UpdateDisbursementStatus(ctx, "dis-1", model.DisbursementStatusSucceeded, "TRF-001", "")
+
+		assert.NoError(t, err)
+		assert.Equal(t, model.DisbursementStatusSucceeded, done.Status)
+		assert.NotNil(t, done.CompletedAt)
+		assert.Equal(t, model.LoanStatusPartiallyDisbursed, loan.Status)
+
+		args := disbursementRepo.Calls[1].Arguments
+		tranche := args.Get(3).(*model.Disbursement)
+		assert.Equal(t, model.NewMoney(6000000), tranche.Amount)
+		assert.Equal(t, *done.CompletedAt, tranche.DisbursedAt)
+
+		installments := args.Get(5).([]model.Installment)
+		assert.Len(t, installments, 12)
+		assert.Equal(t, 1, installments[0].InstallmentNumber)
+	})
+
+	t.Run("last tranche reschedules the installments not yet due", func(t *testing.T) {
+		firstTranche := time.Now().AddDate(0, -2, -10)
+		loan := &model.Loan{
+			ID: "loan-1", UserID: "user-1", Status: model.LoanStatusPartiallyDisbursed, Amount: model.NewMoney(10000000),
+			TenureMonths: 12, InterestRate: 12, DisbursedAmount: model.NewMoney(6000000), DisbursedAt: &firstTranche,
+		}
+		calculated, err := calculateSchedule(model.InterestMethodAnnuity, model.NewMoney(6000000), 12, 12)
+		assert.NoError(t, err)
+		current := buildInstallmentSchedule(calculated, firstTranche)
+		current[0].Status = model.InstallmentStatusPaid
+		current[0].PrincipalPaid = current[0].PrincipalAmount
+		current[0].InterestPaid = current[0].InterestAmount
+
+		loanRepo := new(MockLoanRepository)
+		loanRepo.On("GetByID", ctx, "loan-1").Return(loan, nil)
+		installmentRepo := new(MockInstallmentRepository)
+		installmentRepo.On("GetByLoanID", ctx, "loan-1").Return(current, nil)
+		disbursementRepo := new(MockDisbursementRepository)
+		disbursementRepo.On("GetByID", ctx, "dis-2").Return(&model.DisbursementInstruction{ID: "dis-2", LoanID: "loan-1", Amount: model.NewMoney(4000000), Status: model.DisbursementStatusSent}, nil)
+		disbursementRepo.On("Complete", ctx, mock.Anything, model.DisbursementStatusSent, mock.Anything, loan, mock.Anything).Return(nil)
+
+		uc := NewLoanUseCase(loanRepo, nil, installmentRepo, nil, nil, nil, nil, nil, disbursementRepo)
+		_, err = uc.UpdateDisbursementStatus(ctx, "dis-2", model.DisbursementStatusSucceeded, "TRF-002", "")
+
+		assert.NoError(t, err)
+		assert.Equal(t, model.LoanStatusDisbursed, loan.Status)
+
+		installments := disbursementRepo.Calls[1].Arguments.Get(5).([]model.Installment)
+		assert.Len(t, installments, 10)
+		assert.Equal(t, 3, installments[0].InstallmentNumber)
+		assert.Equal(t, current[2].DueDate, installments[0].DueDate)
+
+		var principal model.Money
+		for _, inst := range installments {
+			principal += inst.PrincipalAmount
+		}
+		assert.Equal(t, current[1].RemainingBalance+model.NewMoney(4000000), principal)
+	})
+
+	t.Run("cannot skip sending", func(t *testing.T) {
+		disbursementRepo, uc := setup(model.DisbursementStatusPending)
+		_, err := uc.UpdateDisbursementStatus(ctx, "dis-1", model.DisbursementStatusSucceeded, "", "")
+		assert.ErrorAs(t, err, &ValidationError{})
+		disbursementRepo.AssertNotCalled(t, "Complete", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("final statuses are terminal", func(t *testing.T) {
+		_, uc := setup(model.DisbursementStatusFailed)
+		_, err := uc.UpdateDisbursementStatus(ctx, "dis-1", model.DisbursementStatusSent, "", "")
+		assert.ErrorAs(t, err, &ValidationError{})
+	})
+
+	t.Run("failure needs a reason and releases the limit", func(t *testing.T) {
+		disbursementRepo, uc := setup(model.DisbursementStatusSent)
+		_, err := uc.UpdateDisbursementStatus(ctx, "dis-1", model.DisbursementStatusFailed, "", "")
+		assert.ErrorAs(t, err, &ValidationError{})
+
+		failed, err := uc.UpdateDisbursementStatus(ctx, "dis-1", model.DisbursementStatusFailed, "", "account closed")
+		assert.NoError(t, err)
+		assert.Equal(t, "account closed", failed.FailureReason)
+		assert.WithinDuration(t, time.Now(), *failed.CompletedAt, time.Minute)
+		disbursementRepo.AssertCalled(t, "Fail", ctx, mock.Anything, model.DisbursementStatusSent)
+		disbursementRepo.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("concurrent update", func(t *testing.T) {
+		disbursementRepo := new(MockDisbursementRepository)
+		disbursementRepo.On("GetByID", ctx, "dis-1").Return(&model.DisbursementInstruction{ID: "dis-1", Status: model.DisbursementStatusPending}, nil)
+		disbursementRepo.On("UpdateStatus", ctx, mock.Anything, model.DisbursementStatusPending).Return(repo.ErrDisbursementStatusChanged)
+
+		uc := NewLoanUseCase(nil, nil, nil, nil, nil, nil, nil, nil, disbursementRepo)
+		_, err := uc.UpdateDisbursementStatus(ctx, "dis-1", model.DisbursementStatusSent, "", "")
+		assert.ErrorAs(t, err, &ConflictError{})
+	})
+}
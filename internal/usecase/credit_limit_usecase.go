@@ -0,0 +1,66 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/edosulai/pt-xyz-multifinance/internal/model"
+	"github.com/edosulai/pt-xyz-multifinance/internal/repo"
+)
+
+// CreditLimitUseCase defines the interface for customer credit limit business logic
+type CreditLimitUseCase interface {
+	// Get the credit limits of a user per tenor
+	GetCreditLimits(ctx context.Context, userID string) ([]model.CreditLimit, error)
+
+	// Set the credit limit of a user for one tenor (for admin)
+	SetCreditLimit(ctx context.Context, userID string, tenureMonths int, limitAmount model.Money) (*model.CreditLimit, error)
+}
+
+// CreditLimitUseCaseImpl implements CreditLimitUseCase interface
+type CreditLimitUseCaseImpl struct {
+	creditLimitRepo repo.CreditLimitRepository
+	userRepo        repo.UserRepository
+}
+
+// NewCreditLimitUseCase creates a new credit limit use case instance
+func NewCreditLimitUseCase(creditLimitRepo repo.CreditLimitRepository, userRepo repo.UserRepository) CreditLimitUseCase {
+	return &CreditLimitUseCaseImpl{
+		creditLimitRepo: creditLimitRepo,
+		userRepo:        userRepo,
+	}
+}
+
+// GetCreditLimits retrieves the credit limits of a user
+func (uc *CreditLimitUseCaseImpl) GetCreditLimits(ctx context.Context, userID string) ([]model.CreditLimit, error) {
+	return uc.creditLimitRepo.GetByUserID(ctx, userID)
+}
+
+// SetCreditLimit creates or replaces the credit limit of a user for one tenor.
+// Lowering a limit below the amount already used does not affect existing loans.
+func (uc *CreditLimitUseCaseImpl) SetCreditLimit(ctx context.Context, userID string, tenureMonths int, limitAmount model.Money) (*model.CreditLimit, error) {
+	if tenureMonths < 1 || tenureMonths > 60 {
+		return nil, NewValidationError("tenure must be between 1 and 60 months")
+	}
+	if limitAmount < 0 {
+		return nil, NewValidationError("credit limit must not be negative")
+	}
+
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+
+	limit := &model.CreditLimit{
+		UserID:       userID,
+		TenureMonths: tenureMonths,
+		LimitAmount:  limitAmount,
+	}
+	if err := uc.creditLimitRepo.Upsert(ctx, limit); err != nil {
+		return nil, err
+	}
+
+	return limit, nil
+}
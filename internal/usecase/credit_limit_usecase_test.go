@@ -0,0 +1,82 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/edosulai/pt-xyz-multifinance/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockCreditLimitRepository struct {
+	mock.Mock
+}
+
+func (m *MockCreditLimitRepository) GetByUserID(ctx context.Context, userID string) ([]model.CreditLimit, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).([]model.CreditLimit), args.Error(1)
+}
+
+func (m *MockCreditLimitRepository) GetByUserAndTenure(ctx context.Context, userID string, tenureMonths int) (*model.CreditLimit, error) {
+	args := m.Called(ctx, userID, tenureMonths)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.CreditLimit), args.Error(1)
+}
+
+func (m *MockCreditLimitRepository) Upsert(ctx context.Context, limit *model.CreditLimit) error {
+	args := m.Called(ctx, limit)
+	return args.Error(0)
+}
+
+func (m *MockCreditLimitRepository) Consume(ctx context.Context, userID string, tenureMonths int, loanID string, amount model.Money) error {
+	args := m.Called(ctx, userID, tenureMonths, loanID, amount)
+	return args.Error(0)
+}
+
+func (m *MockCreditLimitRepository) Release(ctx context.Context, loanID string) error {
+	args := m.Called(ctx, loanID)
+	return args.Error(0)
+}
+
+func TestApplyLoan_CreditLimit(t *testing.T) {
+	ctx := context.Background()
+	user := &model.User{ID: "user-1"}
+
+	t.Run("exceeds remaining limit", func(t *testing.T) {
+		userRepo := new(MockUserRepository)
+		limitRepo := new(MockCreditLimitRepository)
+		userRepo.On("GetByID", ctx, "user-1").Return(user, nil)
+		limitRepo.On("GetByUserAndTenure", ctx, "user-1", 6).
+			Return(&model.CreditLimit{TenureMonths: 6, LimitAmount: model.NewMoney(10000000), UsedAmount: model.NewMoney(7000000)}, nil)
+
+		uc := NewLoanUseCase(nil, userRepo, nil, nil, nil, limitRepo, newProductRepo(ctx, personalLoanProduct()), nil, nil)
+		_, err := uc.ApplyLoan(ctx, "user-1", "PERSONAL", model.NewMoney(5000000), 6, "renovation")
+
+		assert.ErrorAs(t, err, &ValidationError{})
+		assert.Contains(t, err.Error(), "3000000.00")
+	})
+
+	t.Run("no limit for tenor", func(t *testing.T) {
+		userRepo := new(MockUserRepository)
+		limitRepo := new(MockCreditLimitRepository)
+		userRepo.On("GetByID", ctx, "user-1").Return(user, nil)
+		limitRepo.On("GetByUserAndTenure", ctx, "user-1", 12).Return(nil, nil)
+
+		uc := NewLoanUseCase(nil, userRepo, nil, nil, nil, limitRepo, newProductRepo(ctx, personalLoanProduct()), nil, nil)
+		_, err := uc.ApplyLoan(ctx, "user-1", "PERSONAL", model.NewMoney(2000000), 12, "education")
+
+		assert.ErrorAs(t, err, &ValidationError{})
+	})
+}
+
+func TestCreditLimit_RemainingAmount(t *testing.T) {
+	limit := model.CreditLimit{LimitAmount: model.NewMoney(5000000), UsedAmount: model.NewMoney(2000000)}
+	assert.Equal(t, model.NewMoney(3000000), limit.RemainingAmount())
+
+	// A limit lowered below the used amount has nothing left
+	limit.LimitAmount = model.NewMoney(1000000)
+	assert.Equal(t, model.Money(0), limit.RemainingAmount())
+}
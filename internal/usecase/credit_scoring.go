@@ -0,0 +1,204 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/edosulai/pt-xyz-multifinance/internal/model"
+	"github.com/edosulai/pt-xyz-multifinance/internal/repo"
+)
+
+// CreditScore is the outcome of scoring a loan application
+type CreditScore struct {
+	Score       int
+	Grade       model.RiskGrade
+	ReasonCodes []string
+	// Model identifies the scorer that produced the score so results of experiments can be compared
+	Model string
+}
+
+// CreditScorer scores a loan application before it is decided. Implementations may fetch
+// whatever data they need; the approval path only relies on the returned grade.
+type CreditScorer interface {
+	Score(ctx context.Context, user *model.User, loan *model.Loan) (*CreditScore, error)
+}
+
+// RuleBasedScoringModel identifies scores produced by RuleBasedCreditScorer
+const RuleBasedScoringModel = "rule_based_v1"
+
+const (
+	baseCreditScore = 600
+	minCreditScore  = 300
+	maxCreditScore  = 850
+
+	// creditHistoryPageSize bounds how many past loans are considered
+	creditHistoryPageSize = 100
+)
+
+// RuleBasedCreditScorer scores applications from income, tenure, amount, loan history and account age
+type RuleBasedCreditScorer struct {
+	loanRepo repo.LoanRepository
+	now      func() time.Time
+}
+
+// NewRuleBasedCreditScorer creates the default credit scorer
+func NewRuleBasedCreditScorer(loanRepo repo.LoanRepository) *RuleBasedCreditScorer {
+	return &RuleBasedCreditScorer{
+		loanRepo: loanRepo,
+		now:      time.Now,
+	}
+}
+
+// Score applies each rule to a base score and grades the clamped result
+func (s *RuleBasedCreditScorer) Score(ctx context.Context, user *model.User, loan *model.Loan) (*CreditScore, error) {
+	history, _, err := s.loanRepo.GetUserLoans(ctx, user.ID, 1, creditHistoryPageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	card := scorecard{score: baseCreditScore}
+	card.scoreIncome(user.MonthlyIncome)
+	card.scoreAmount(loan.Amount, user.MonthlyIncome)
+	card.scoreTenure(loan.TenureMonths)
+	card.scoreDTI(loan.DTIRatio, loan.AffordabilityFlagged)
+	card.scoreHistory(history, loan.ID)
+	card.scoreAccountAge(daysBetween(user.CreatedAt, s.now()))
+
+	score := card.score
+	if score < minCreditScore {
+		score = minCreditScore
+	}
+	if score > maxCreditScore {
+		score = maxCreditScore
+	}
+
+	return &CreditScore{
+		Score:       score,
+		Grade:       gradeForScore(score),
+		ReasonCodes: card.reasons,
+		Model:       RuleBasedScoringModel,
+	}, nil
+}
+
+// scorecard accumulates score adjustments and the reason code of each
+type scorecard struct {
+	score   int
+	reasons []string
+}
+
+func (c *scorecard) adjust(points int, reason string) {
+	c.score += points
+	c.reasons = append(c.reasons, reason)
+}
+
+func (c *scorecard) scoreIncome(monthlyIncome model.Money) {
+	switch {
+	case monthlyIncome < model.NewMoney(3000000):
+		c.adjust(-60, model.ReasonLowIncome)
+	case monthlyIncome >= model.NewMoney(15000000):
+		c.adjust(60, model.ReasonHighIncome)
+	}
+}
+
+func (c *scorecard) scoreAmount(amount, monthlyIncome model.Money) {
+	if monthlyIncome <= 0 {
+		c.adjust(-100, model.ReasonHighAmountToIncome)
+		return
+	}
+
+	switch ratio := float64(amount) / float64(monthlyIncome); {
+	case ratio > 24:
+		c.adjust(-50, model.ReasonHighAmountToIncome)
+	case ratio <= 6:
+		c.adjust(30, model.ReasonLowAmountToIncome)
+	}
+}
+
+func (c *scorecard) scoreTenure(tenureMonths int) {
+	switch {
+	case tenureMonths > 36:
+		c.adjust(-30, model.ReasonLongTenure)
+	case tenureMonths <= 12:
+		c.adjust(20, model.ReasonShortTenure)
+	}
+}
+
+func (c *scorecard) scoreDTI(dtiPercent float64, flagged bool) {
+	if flagged || dtiPercent > 40 {
+		c.adjust(-40, model.ReasonHighDTI)
+	}
+}
+
+// scoreHistory rewards loans repaid in full and penalises defaults, restructurings and
+// loans currently past due. The application being scored is skipped.
+func (c *scorecard) scoreHistory(history []model.Loan, loanID string) {
+	var paidOff, defaulted, restructured, delinquent, seen int
+	for _, past := range history {
+		if past.ID == loanID {
+			continue
+		}
+		seen++
+		switch past.Status {
+		case model.LoanStatusPaidOff:
+			paidOff++
+		case model.LoanStatusDefaulted:
+			defaulted++
+		case model.LoanStatusRestructured:
+			restructured++
+		}
+		if past.DaysPastDue > 30 && past.Status != model.LoanStatusDefaulted {
+			delinquent++
+		}
+	}
+
+	if seen == 0 {
+		c.adjust(-20, model.ReasonNoCreditHistory)
+		return
+	}
+	if paidOff > 0 {
+		c.adjust(25*min(paidOff, 3), model.ReasonGoodRepaymentHistory)
+	}
+	if defaulted > 0 {
+		c.adjust(-150*defaulted, model.ReasonPriorDefault)
+	}
+	if restructured > 0 {
+		c.adjust(-60*restructured, model.ReasonPriorRestructuring)
+	}
+	if delinquent > 0 {
+		c.adjust(-80, model.ReasonCurrentDelinquency)
+	}
+}
+
+func (c *scorecard) scoreAccountAge(days int) {
+	switch {
+	case days < 90:
+		c.adjust(-40, model.ReasonNewAccount)
+	case days >= 730:
+		c.adjust(30, model.ReasonEstablishedAccount)
+	}
+}
+
+// gradeForScore maps a credit score to its risk grade
+func gradeForScore(score int) model.RiskGrade {
+	switch {
+	case score >= 750:
+		return model.RiskGradeA
+	case score >= 680:
+		return model.RiskGradeB
+	case score >= 620:
+		return model.RiskGradeC
+	case score >= 560:
+		return model.RiskGradeD
+	default:
+		return model.RiskGradeE
+	}
+}
+
+// applyCreditScore records a score on the loan
+func applyCreditScore(loan *model.Loan, score *CreditScore, scoredAt time.Time) {
+	loan.CreditScore = score.Score
+	loan.RiskGrade = score.Grade
+	loan.ScoreReasonCodes = score.ReasonCodes
+	loan.ScoringModel = score.Model
+	loan.ScoredAt = timePtr(scoredAt)
+}
@@ -0,0 +1,120 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/edosulai/pt-xyz-multifinance/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type stubCreditScorer struct {
+	score *CreditScore
+	err   error
+}
+
+func (s stubCreditScorer) Score(ctx context.Context, user *model.User, loan *model.Loan) (*CreditScore, error) {
+	return s.score, s.err
+}
+
+func TestRuleBasedCreditScorer(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, time.June, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("established customer with good history", func(t *testing.T) {
+		loanRepo := new(MockLoanRepository)
+		loanRepo.On("GetUserLoans", ctx, "user-1", 1, creditHistoryPageSize).Return([]model.Loan{
+			{ID: "old-1", Status: model.LoanStatusPaidOff},
+			{ID: "old-2", Status: model.LoanStatusPaidOff},
+		}, int64(2), nil)
+		scorer := NewRuleBasedCreditScorer(loanRepo)
+		scorer.now = func() time.Time { return now }
+
+		user := &model.User{ID: "user-1", MonthlyIncome: model.NewMoney(20000000), CreatedAt: now.AddDate(-3, 0, 0)}
+		score, err := scorer.Score(ctx, user, &model.Loan{Amount: model.NewMoney(10000000), TenureMonths: 12})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 790, score.Score)
+		assert.Equal(t, model.RiskGradeA, score.Grade)
+		assert.Equal(t, RuleBasedScoringModel, score.Model)
+		assert.Contains(t, score.ReasonCodes, model.ReasonGoodRepaymentHistory)
+	})
+
+	t.Run("new customer with prior default", func(t *testing.T) {
+		loanRepo := new(MockLoanRepository)
+		loanRepo.On("GetUserLoans", ctx, "user-2", 1, creditHistoryPageSize).Return([]model.Loan{
+			{ID: "old-1", Status: model.LoanStatusDefaulted, DaysPastDue: 120},
+			{ID: "loan-1", Status: model.LoanStatusPending},
+		}, int64(2), nil)
+		scorer := NewRuleBasedCreditScorer(loanRepo)
+		scorer.now = func() time.Time { return now }
+
+		user := &model.User{ID: "user-2", MonthlyIncome: model.NewMoney(2500000), CreatedAt: now.AddDate(0, -1, 0)}
+		score, err := scorer.Score(ctx, user, &model.Loan{ID: "loan-1", Amount: model.NewMoney(80000000), TenureMonths: 48})
+
+		assert.NoError(t, err)
+		assert.Equal(t, minCreditScore, score.Score)
+		assert.Equal(t, model.RiskGradeE, score.Grade)
+		assert.Equal(t, []string{
+			model.ReasonLowIncome,
+			model.ReasonHighAmountToIncome,
+			model.ReasonLongTenure,
+			model.ReasonPriorDefault,
+			model.ReasonNewAccount,
+		}, score.ReasonCodes)
+	})
+}
+
+func TestGradeForScore(t *testing.T) {
+	assert.Equal(t, model.RiskGradeA, gradeForScore(750))
+	assert.Equal(t, model.RiskGradeB, gradeForScore(749))
+	assert.Equal(t, model.RiskGradeC, gradeForScore(620))
+	assert.Equal(t, model.RiskGradeD, gradeForScore(560))
+	assert.Equal(t, model.RiskGradeE, gradeForScore(559))
+}
+
+func TestProcessLoanApplication_Scoring(t *testing.T) {
+	ctx := model.ContextWithActor(context.Background(), model.Actor{ID: "admin-1", Role: model.ActorRoleAdmin})
+
+	t.Run("declined grade cannot be approved", func(t *testing.T) {
+		loanRepo := new(MockLoanRepository)
+		loanRepo.On("GetByID", ctx, "loan-1").Return(&model.Loan{
+			ID: "loan-1", Status: model.LoanStatusInReview, RiskGrade: model.RiskGradeE, ScoredAt: timePtr(time.Now()),
+		}, nil)
+
+		uc := NewLoanUseCase(loanRepo, nil, nil, nil, nil, nil, nil, nil, nil)
+		err := uc.ProcessLoanApplication(ctx, "loan-1", true, 12, "")
+
+		assert.ErrorAs(t, err, &ValidationError{})
+		loanRepo.AssertNotCalled(t, "UpdateLoan", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("unscored application is scored by the configured scorer", func(t *testing.T) {
+		loanRepo := new(MockLoanRepository)
+		userRepo := new(MockUserRepository)
+		loanRepo.On("GetByID", ctx, "loan-1").Return(&model.Loan{ID: "loan-1", UserID: "user-1", Status: model.LoanStatusInReview}, nil)
+		userRepo.On("GetByID", ctx, "user-1").Return(&model.User{ID: "user-1"}, nil)
+
+		scorer := stubCreditScorer{score: &CreditScore{Score: 500, Grade: model.RiskGradeE, ReasonCodes: []string{"TEST"}, Model: "stub"}}
+		uc := NewLoanUseCase(loanRepo, userRepo, nil, nil, nil, nil, nil, nil, nil, WithCreditScorer(scorer))
+		err := uc.ProcessLoanApplication(ctx, "loan-1", true, 12, "")
+
+		assert.ErrorAs(t, err, &ValidationError{})
+		assert.Contains(t, err.Error(), "risk grade E")
+	})
+
+	t.Run("scorer failure", func(t *testing.T) {
+		loanRepo := new(MockLoanRepository)
+		userRepo := new(MockUserRepository)
+		loanRepo.On("GetByID", ctx, "loan-1").Return(&model.Loan{ID: "loan-1", UserID: "user-1", Status: model.LoanStatusInReview}, nil)
+		userRepo.On("GetByID", ctx, "user-1").Return(&model.User{ID: "user-1"}, nil)
+
+		uc := NewLoanUseCase(loanRepo, userRepo, nil, nil, nil, nil, nil, nil, nil, WithCreditScorer(stubCreditScorer{err: errors.New("model unavailable")}))
+		err := uc.ProcessLoanApplication(ctx, "loan-1", true, 12, "")
+
+		assert.ErrorContains(t, err, "model unavailable")
+	})
+}
@@ -0,0 +1,111 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/edosulai/pt-xyz-multifinance/internal/model"
+	"github.com/edosulai/pt-xyz-multifinance/internal/repo"
+)
+
+// DelinquencyResult summarizes a delinquency evaluation run
+type DelinquencyResult struct {
+	AsOf           time.Time
+	LoansEvaluated int
+	LoansUpdated   int
+	LoansDefaulted int
+}
+
+// DelinquencyUseCase defines the interface for days-past-due tracking
+type DelinquencyUseCase interface {
+	// Recompute days past due and collectibility of active loans as of the given date
+	EvaluateDelinquency(ctx context.Context, asOf time.Time) (*DelinquencyResult, error)
+}
+
+// DelinquencyUseCaseImpl implements DelinquencyUseCase interface
+type DelinquencyUseCaseImpl struct {
+	loanRepo         repo.LoanRepository
+	installmentRepo  repo.InstallmentRepository
+	defaultThreshold int
+}
+
+// NewDelinquencyUseCase creates a new delinquency use case instance.
+// Loans are moved to defaulted once their days past due reach defaultThreshold.
+func NewDelinquencyUseCase(loanRepo repo.LoanRepository, installmentRepo repo.InstallmentRepository, defaultThreshold int) DelinquencyUseCase {
+	return &DelinquencyUseCaseImpl{
+		loanRepo:         loanRepo,
+		installmentRepo:  installmentRepo,
+		defaultThreshold: defaultThreshold,
+	}
+}
+
+// EvaluateDelinquency updates every loan under repayment and records collectibility and status changes
+func (uc *DelinquencyUseCaseImpl) EvaluateDelinquency(ctx context.Context, asOf time.Time) (*DelinquencyResult, error) {
+	asOf = startOfDay(asOf)
+	result := &DelinquencyResult{AsOf: asOf}
+
+	loans, err := uc.loanRepo.GetLoansByStatus(ctx, model.LoanStatusPartiallyDisbursed, model.LoanStatusDisbursed, model.LoanStatusRestructured, model.LoanStatusDefaulted)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range loans {
+		loan := &loans[i]
+		result.LoansEvaluated++
+
+		installments, err := uc.installmentRepo.GetByLoanID(ctx, loan.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		dpd := computeDaysPastDue(installments, asOf)
+		collectibility := model.CollectibilityForDPD(dpd)
+		status := loan.Status
+		if status != model.LoanStatusDefaulted && uc.defaultThreshold > 0 && dpd >= uc.defaultThreshold {
+			status = model.LoanStatusDefaulted
+		}
+
+		if dpd == loan.DaysPastDue && collectibility == loan.Collectibility && status == loan.Status {
+			continue
+		}
+
+		var record *model.DelinquencyRecord
+		if collectibility != loan.Collectibility || status != loan.Status {
+			record = &model.DelinquencyRecord{
+				AsOfDate:               asOf,
+				DaysPastDue:            dpd,
+				PreviousCollectibility: loan.Collectibility,
+				Collectibility:         collectibility,
+				PreviousStatus:         loan.Status,
+				Status:                 status,
+			}
+		}
+		if status != loan.Status {
+			result.LoansDefaulted++
+		}
+
+		loan.DaysPastDue = dpd
+		loan.Collectibility = collectibility
+		loan.Status = status
+		if err := uc.loanRepo.UpdateDelinquency(ctx, loan, record); err != nil {
+			return nil, err
+		}
+		result.LoansUpdated++
+	}
+
+	return result, nil
+}
+
+// computeDaysPastDue returns the days between the oldest unpaid due date and asOf, or 0 when nothing is overdue
+func computeDaysPastDue(installments []model.Installment, asOf time.Time) int {
+	dpd := 0
+	for _, inst := range installments {
+		if !inst.IsOpen() || !inst.DueDate.Before(asOf) {
+			continue
+		}
+		if days := daysBetween(inst.DueDate, asOf); days > dpd {
+			dpd = days
+		}
+	}
+	return dpd
+}
@@ -0,0 +1,39 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/edosulai/pt-xyz-multifinance/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComputeDaysPastDue(t *testing.T) {
+	asOf := time.Date(2025, time.June, 30, 0, 0, 0, 0, time.UTC)
+	installments := []model.Installment{
+		{InstallmentNumber: 1, DueDate: time.Date(2025, time.March, 31, 0, 0, 0, 0, time.UTC), Status: model.InstallmentStatusPaid},
+		{InstallmentNumber: 2, DueDate: time.Date(2025, time.April, 30, 0, 0, 0, 0, time.UTC), Status: model.InstallmentStatusPartiallyPaid},
+		{InstallmentNumber: 3, DueDate: time.Date(2025, time.May, 31, 0, 0, 0, 0, time.UTC), Status: model.InstallmentStatusPending},
+		{InstallmentNumber: 4, DueDate: time.Date(2025, time.June, 30, 0, 0, 0, 0, time.UTC), Status: model.InstallmentStatusPending},
+	}
+
+	assert.Equal(t, 61, computeDaysPastDue(installments, asOf))
+	assert.Equal(t, 0, computeDaysPastDue(installments[3:], asOf))
+}
+
+func TestCollectibilityForDPD(t *testing.T) {
+	cases := map[int]model.Collectibility{
+		0:   model.CollectibilityCurrent,
+		1:   model.CollectibilitySpecialMention,
+		90:  model.CollectibilitySpecialMention,
+		91:  model.CollectibilitySubstandard,
+		120: model.CollectibilitySubstandard,
+		121: model.CollectibilityDoubtful,
+		180: model.CollectibilityDoubtful,
+		181: model.CollectibilityLoss,
+	}
+
+	for dpd, expected := range cases {
+		assert.Equal(t, expected, model.CollectibilityForDPD(dpd), "dpd %d", dpd)
+	}
+}
@@ -0,0 +1,155 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/edosulai/pt-xyz-multifinance/internal/model"
+	"github.com/edosulai/pt-xyz-multifinance/internal/repo"
+	"github.com/edosulai/pt-xyz-multifinance/pkg/bankfile"
+)
+
+// defaultBankFailureReason is recorded when the bank fails a transfer without giving a reason
+const defaultBankFailureReason = "rejected by bank"
+
+// DisbursementBatch summarizes an exported bank batch file
+type DisbursementBatch struct {
+	Reference    string
+	Instructions int
+	TotalAmount  model.Money
+}
+
+// DisbursementImportResult summarizes an imported bank result file
+type DisbursementImportResult struct {
+	Processed int
+	Succeeded int
+	Failed    int
+	// Skipped counts results already recorded by an earlier import of the same file
+	Skipped int
+	// Errors lists the results that could not be applied, one message per result
+	Errors []string
+}
+
+// DisbursementBatchUseCase defines the interface for exchanging disbursement files with the bank
+type DisbursementBatchUseCase interface {
+	// Write all pending disbursement instructions to a batch file and mark them as sent
+	ExportBatch(ctx context.Context, w io.Writer) (*DisbursementBatch, error)
+
+	// Apply a result file returned by the bank to the sent instructions
+	ImportResults(ctx context.Context, r io.Reader) (*DisbursementImportResult, error)
+}
+
+// DisbursementBatchUseCaseImpl implements DisbursementBatchUseCase interface
+type DisbursementBatchUseCaseImpl struct {
+	disbursementRepo repo.DisbursementRepository
+	loanUseCase      LoanUseCase
+	format           bankfile.Format
+	sourceAccount    string
+	now              func() time.Time
+}
+
+// NewDisbursementBatchUseCase creates a new disbursement batch use case instance.
+// sourceAccount is the company account the bank debits for the transfers.
+func NewDisbursementBatchUseCase(disbursementRepo repo.DisbursementRepository, loanUseCase LoanUseCase, format bankfile.Format, sourceAccount string) DisbursementBatchUseCase {
+	return &DisbursementBatchUseCaseImpl{
+		disbursementRepo: disbursementRepo,
+		loanUseCase:      loanUseCase,
+		format:           format,
+		sourceAccount:    sourceAccount,
+		now:              time.Now,
+	}
+}
+
+// ExportBatch claims the pending instructions and writes them to w. The instructions stay
+// pending when the file cannot be written. Nothing is written when no instruction is pending.
+func (uc *DisbursementBatchUseCaseImpl) ExportBatch(ctx context.Context, w io.Writer) (*DisbursementBatch, error) {
+	now := uc.now()
+	summary := &DisbursementBatch{Reference: "DISB" + now.Format("20060102150405")}
+
+	err := uc.disbursementRepo.ClaimPending(ctx, summary.Reference, func(instructions []model.DisbursementInstruction) error {
+		if len(instructions) == 0 {
+			return nil
+		}
+
+		batch := bankfile.Batch{
+			Reference:     summary.Reference,
+			SourceAccount: uc.sourceAccount,
+			Date:          now,
+		}
+		for _, instruction := range instructions {
+			batch.Transfers = append(batch.Transfers, bankfile.Transfer{
+				Reference:     instruction.ID,
+				BankCode:      instruction.BankAccount.BankCode,
+				AccountNumber: instruction.BankAccount.AccountNumber,
+				HolderName:    instruction.BankAccount.HolderName,
+				Amount:        instruction.Amount.Sen(),
+			})
+			summary.TotalAmount += instruction.Amount
+		}
+		summary.Instructions = len(instructions)
+
+		if err := bankfile.Write(w, uc.format, batch); err != nil {
+			return fmt.Errorf("failed to write disbursement batch: %v", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return summary, nil
+}
+
+// ImportResults marks each instruction in the result file as succeeded or failed. Importing
+// the same file again skips the results already recorded, and a result that cannot be
+// applied is reported without stopping the rest of the file.
+func (uc *DisbursementBatchUseCaseImpl) ImportResults(ctx context.Context, r io.Reader) (*DisbursementImportResult, error) {
+	results, err := bankfile.ReadResults(r, uc.format)
+	if err != nil {
+		return nil, NewValidationError(err.Error())
+	}
+
+	summary := &DisbursementImportResult{}
+	for _, result := range results {
+		summary.Processed++
+
+		status := model.DisbursementStatusFailed
+		if result.Succeeded {
+			status = model.DisbursementStatusSucceeded
+		}
+
+		instruction, err := uc.disbursementRepo.GetByID(ctx, result.Reference)
+		if err != nil {
+			summary.Errors = append(summary.Errors, fmt.Sprintf("%s: %v", result.Reference, err))
+			continue
+		}
+		if instruction == nil {
+			summary.Errors = append(summary.Errors, fmt.Sprintf("%s: %v", result.Reference, ErrDisbursementNotFound))
+			continue
+		}
+		if instruction.Status == status {
+			summary.Skipped++
+			continue
+		}
+
+		reason := result.Reason
+		if status == model.DisbursementStatusFailed && reason == "" {
+			reason = defaultBankFailureReason
+		}
+
+		if _, err := uc.loanUseCase.UpdateDisbursementStatus(ctx, instruction.ID, status, result.BankReference, reason); err != nil {
+			summary.Errors = append(summary.Errors, fmt.Sprintf("%s: %v", result.Reference, err))
+			continue
+		}
+
+		if result.Succeeded {
+			summary.Succeeded++
+		} else {
+			summary.Failed++
+		}
+	}
+
+	return summary, nil
+}
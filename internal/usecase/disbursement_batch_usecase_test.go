@@ -0,0 +1,124 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/edosulai/pt-xyz-multifinance/internal/model"
+	"github.com/edosulai/pt-xyz-multifinance/pkg/bankfile"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newBatchUseCase(disbursementRepo *MockDisbursementRepository, loanRepo *MockLoanRepository) *DisbursementBatchUseCaseImpl {
+	loanUseCase := NewLoanUseCase(loanRepo, nil, nil, nil, nil, nil, nil, nil, disbursementRepo)
+	uc := NewDisbursementBatchUseCase(disbursementRepo, loanUseCase, bankfile.CSV, "1234567890").(*DisbursementBatchUseCaseImpl)
+	uc.now = func() time.Time { return time.Date(2024, 1, 15, 9, 30, 0, 0, time.UTC) }
+	return uc
+}
+
+func TestExportBatch(t *testing.T) {
+	ctx := model.ContextWithActor(context.Background(), model.SystemActor)
+
+	t.Run("pending instructions", func(t *testing.T) {
+		disbursementRepo := new(MockDisbursementRepository)
+		disbursementRepo.On("ClaimPending", ctx, "DISB20240115093000", mock.Anything).Return([]model.DisbursementInstruction{
+			{
+				ID:     "dis-1",
+				Amount: model.NewMoney(5000000),
+				BankAccount: model.BankAccount{
+					BankCode: "014", AccountNumber: "0987654321", HolderName: "Budi Santoso",
+				},
+			},
+			{
+				ID:     "dis-2",
+				Amount: model.MustParseMoney("2500000.50"),
+				BankAccount: model.BankAccount{
+					BankCode: "008", AccountNumber: "1122334455", HolderName: "Siti Aminah",
+				},
+			},
+		}, nil)
+
+		var buf bytes.Buffer
+		batch, err := newBatchUseCase(disbursementRepo, nil).ExportBatch(ctx, &buf)
+
+		require.NoError(t, err)
+		assert.Equal(t, "DISB20240115093000", batch.Reference)
+		assert.Equal(t, 2, batch.Instructions)
+		assert.Equal(t, model.MustParseMoney("7500000.50"), batch.TotalAmount)
+		assert.Equal(t, "reference,bank_code,account_number,holder_name,amount\n"+
+			"dis-1,014,0987654321,Budi Santoso,5000000.00\n"+
+			"dis-2,008,1122334455,Siti Aminah,2500000.50\n", buf.String())
+	})
+
+	t.Run("nothing pending", func(t *testing.T) {
+		disbursementRepo := new(MockDisbursementRepository)
+		disbursementRepo.On("ClaimPending", ctx, mock.Anything, mock.Anything).Return([]model.DisbursementInstruction{}, nil)
+
+		var buf bytes.Buffer
+		batch, err := newBatchUseCase(disbursementRepo, nil).ExportBatch(ctx, &buf)
+
+		require.NoError(t, err)
+		assert.Zero(t, batch.Instructions)
+		assert.Zero(t, buf.Len())
+	})
+
+	t.Run("invalid account rolls back the claim", func(t *testing.T) {
+		disbursementRepo := new(MockDisbursementRepository)
+		disbursementRepo.On("ClaimPending", ctx, mock.Anything, mock.Anything).Return([]model.DisbursementInstruction{
+			{ID: "dis-1", Amount: model.NewMoney(5000000), BankAccount: model.BankAccount{BankCode: "14", AccountNumber: "0987654321"}},
+		}, nil)
+
+		_, err := newBatchUseCase(disbursementRepo, nil).ExportBatch(ctx, &bytes.Buffer{})
+		assert.Error(t, err)
+	})
+}
+
+func TestImportResults(t *testing.T) {
+	ctx := model.ContextWithActor(context.Background(), model.SystemActor)
+
+	loan := &model.Loan{ID: "loan-1", UserID: "user-1", Status: model.LoanStatusApproved, Amount: model.NewMoney(10000000), TenureMonths: 12, InterestRate: 12}
+	loanRepo := new(MockLoanRepository)
+	loanRepo.On("GetByID", ctx, "loan-1").Return(loan, nil)
+
+	disbursementRepo := new(MockDisbursementRepository)
+	disbursementRepo.On("GetByID", ctx, "dis-1").Return(&model.DisbursementInstruction{ID: "dis-1", LoanID: "loan-1", Amount: model.NewMoney(10000000), Status: model.DisbursementStatusSent}, nil)
+	disbursementRepo.On("GetByID", ctx, "dis-2").Return(&model.DisbursementInstruction{ID: "dis-2", LoanID: "loan-2", Amount: model.NewMoney(5000000), Status: model.DisbursementStatusSent}, nil)
+	disbursementRepo.On("GetByID", ctx, "dis-3").Return(&model.DisbursementInstruction{ID: "dis-3", LoanID: "loan-3", Status: model.DisbursementStatusSucceeded}, nil)
+	disbursementRepo.On("GetByID", ctx, "dis-4").Return(nil, nil)
+	disbursementRepo.On("GetByID", ctx, "dis-5").Return(nil, errors.New("connection reset"))
+	disbursementRepo.On("Complete", ctx, mock.Anything, model.DisbursementStatusSent, mock.Anything, loan, mock.Anything).Return(nil)
+	disbursementRepo.On("Fail", ctx, mock.Anything, model.DisbursementStatusSent).Return(nil)
+
+	input := "reference,status,bank_reference,reason\n" +
+		"dis-1,SUCCESS,TRX001,\n" +
+		"dis-2,FAILED,,\n" +
+		"dis-3,SUCCESS,TRX003,\n" +
+		"dis-4,SUCCESS,TRX004,\n" +
+		"dis-5,SUCCESS,TRX005,\n"
+
+	result, err := newBatchUseCase(disbursementRepo, loanRepo).ImportResults(ctx, strings.NewReader(input))
+
+	require.NoError(t, err)
+	assert.Equal(t, 5, result.Processed)
+	assert.Equal(t, 1, result.Succeeded)
+	assert.Equal(t, 1, result.Failed)
+	assert.Equal(t, 1, result.Skipped)
+	assert.Len(t, result.Errors, 2)
+	assert.Equal(t, model.LoanStatusDisbursed, loan.Status)
+
+	for _, call := range disbursementRepo.Calls {
+		if call.Method == "Fail" {
+			assert.Equal(t, defaultBankFailureReason, call.Arguments.Get(1).(*model.DisbursementInstruction).FailureReason)
+		}
+	}
+	disbursementRepo.AssertNumberOfCalls(t, "Fail", 1)
+
+	_, err = newBatchUseCase(disbursementRepo, loanRepo).ImportResults(ctx, strings.NewReader("reference,status\ndis-1,UNKNOWN\n"))
+	assert.ErrorAs(t, err, &ValidationError{})
+}
@@ -0,0 +1,96 @@
+package usecase
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/edosulai/pt-xyz-multifinance/internal/model"
+)
+
+// DocumentRule adds a document to the checklist of new applications that meet all of its
+// conditions. Conditions left empty always match.
+type DocumentRule struct {
+	Type model.DocumentType
+	// ProductCodes limits the rule to applications for these products
+	ProductCodes []string
+	// MinAmount applies the rule to loans of at least this amount
+	MinAmount model.Money
+	// MinMonthlyIncome applies the rule to applicants declaring at least this income
+	MinMonthlyIncome model.Money
+}
+
+// DefaultDocumentRules is used when no document rules are configured. They come on top of the
+// documents each product requires.
+var DefaultDocumentRules = []DocumentRule{
+	{Type: model.DocumentTypeKTP},
+	{Type: model.DocumentTypeBankStatement, MinAmount: model.NewMoney(50000000)},
+}
+
+// ParseDocumentRule builds a rule from configured values; empty amounts leave the condition out
+func ParseDocumentRule(docType string, productCodes []string, minAmount, minMonthlyIncome string) (DocumentRule, error) {
+	rule := DocumentRule{Type: model.DocumentType(docType), ProductCodes: productCodes}
+	if !knownDocumentTypes[rule.Type] {
+		return DocumentRule{}, fmt.Errorf("unknown document type %q", docType)
+	}
+
+	var err error
+	if strings.TrimSpace(minAmount) != "" {
+		if rule.MinAmount, err = model.ParseMoney(minAmount); err != nil {
+			return DocumentRule{}, fmt.Errorf("invalid minimum amount of %s rule: %v", docType, err)
+		}
+	}
+	if strings.TrimSpace(minMonthlyIncome) != "" {
+		if rule.MinMonthlyIncome, err = model.ParseMoney(minMonthlyIncome); err != nil {
+			return DocumentRule{}, fmt.Errorf("invalid minimum monthly income of %s rule: %v", docType, err)
+		}
+	}
+
+	return rule, nil
+}
+
+// matches reports whether the rule applies to an application
+func (r DocumentRule) matches(productCode string, amount, monthlyIncome model.Money) bool {
+	if len(r.ProductCodes) > 0 && !containsString(r.ProductCodes, productCode) {
+		return false
+	}
+	return amount >= r.MinAmount && monthlyIncome >= r.MinMonthlyIncome
+}
+
+// documentChecklist returns the required placeholder documents of a new application: those the
+// product requires followed by those added by matching rules, each type once
+func documentChecklist(product *model.LoanProduct, rules []DocumentRule, amount, monthlyIncome model.Money) []model.Document {
+	seen := make(map[model.DocumentType]bool)
+	var checklist []model.Document
+	add := func(docType model.DocumentType) {
+		if seen[docType] {
+			return
+		}
+		seen[docType] = true
+		checklist = append(checklist, model.Document{
+			Type:     docType,
+			Name:     string(docType),
+			Status:   model.DocumentStatusRequired,
+			Required: true,
+		})
+	}
+
+	for _, docType := range product.RequiredDocuments {
+		add(docType)
+	}
+	for _, rule := range rules {
+		if rule.matches(product.Code, amount, monthlyIncome) {
+			add(rule.Type)
+		}
+	}
+
+	return checklist
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
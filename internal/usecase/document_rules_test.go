@@ -0,0 +1,63 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/edosulai/pt-xyz-multifinance/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDocumentChecklist(t *testing.T) {
+	product := personalLoanProduct()
+	product.RequiredDocuments = []model.DocumentType{model.DocumentTypeKTP, model.DocumentTypePayslip}
+	rules := []DocumentRule{
+		{Type: model.DocumentTypeKTP},
+		{Type: model.DocumentTypeBankStatement, MinAmount: model.NewMoney(50000000)},
+		{Type: model.DocumentTypeBankStatement, MinMonthlyIncome: model.NewMoney(25000000)},
+		{Type: model.DocumentTypeEmployeeLetter, ProductCodes: []string{"PAYROLL"}},
+	}
+
+	tests := []struct {
+		name          string
+		amount        model.Money
+		monthlyIncome model.Money
+		want          []model.DocumentType
+	}{
+		{"product documents only", model.NewMoney(10000000), model.NewMoney(8000000), []model.DocumentType{model.DocumentTypeKTP, model.DocumentTypePayslip}},
+		{"large amount", model.NewMoney(50000000), model.NewMoney(8000000), []model.DocumentType{model.DocumentTypeKTP, model.DocumentTypePayslip, model.DocumentTypeBankStatement}},
+		{"high income and large amount", model.NewMoney(80000000), model.NewMoney(30000000), []model.DocumentType{model.DocumentTypeKTP, model.DocumentTypePayslip, model.DocumentTypeBankStatement}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checklist := documentChecklist(product, rules, tt.amount, tt.monthlyIncome)
+
+			var types []model.DocumentType
+			for _, doc := range checklist {
+				assert.Equal(t, model.DocumentStatusRequired, doc.Status)
+				assert.True(t, doc.Required)
+				types = append(types, doc.Type)
+			}
+			assert.Equal(t, tt.want, types)
+		})
+	}
+
+	payroll := personalLoanProduct()
+	payroll.Code = "PAYROLL"
+	checklist := documentChecklist(payroll, rules, model.NewMoney(10000000), model.NewMoney(8000000))
+	require.Len(t, checklist, 2)
+	assert.Equal(t, model.DocumentTypeEmployeeLetter, checklist[1].Type)
+}
+
+func TestParseDocumentRule(t *testing.T) {
+	rule, err := ParseDocumentRule("bank_statement", []string{"PERSONAL"}, "50000000", "")
+	require.NoError(t, err)
+	assert.Equal(t, DocumentRule{Type: model.DocumentTypeBankStatement, ProductCodes: []string{"PERSONAL"}, MinAmount: model.NewMoney(50000000)}, rule)
+
+	_, err = ParseDocumentRule("selfie", nil, "", "")
+	assert.Error(t, err)
+
+	_, err = ParseDocumentRule("payslip", nil, "", "lots")
+	assert.Error(t, err)
+}
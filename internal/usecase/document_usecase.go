@@ -0,0 +1,261 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/edosulai/pt-xyz-multifinance/internal/model"
+	"github.com/edosulai/pt-xyz-multifinance/internal/repo"
+	"github.com/edosulai/pt-xyz-multifinance/pkg/storage"
+)
+
+// sniffLength is how many leading bytes are inspected to detect the content type of an upload
+const sniffLength = 512
+
+// DocumentUploadPolicy bounds the files accepted as loan documents
+type DocumentUploadPolicy struct {
+	MaxSizeBytes int64
+	// AllowedContentTypes are matched against the type detected from the file content, not
+	// the type the client declares
+	AllowedContentTypes []string
+}
+
+// DefaultDocumentUploadPolicy accepts scans and photos of up to 10 MiB
+var DefaultDocumentUploadPolicy = DocumentUploadPolicy{
+	MaxSizeBytes:        10 << 20,
+	AllowedContentTypes: []string{"application/pdf", "image/jpeg", "image/png"},
+}
+
+// knownDocumentTypes lists the document types a loan can be supported with
+var knownDocumentTypes = map[model.DocumentType]bool{
+	model.DocumentTypeKTP:            true,
+	model.DocumentTypePayslip:        true,
+	model.DocumentTypeBankStatement:  true,
+	model.DocumentTypeEmployeeLetter: true,
+}
+
+// errDocumentTooLarge aborts storing an upload once it exceeds the size limit
+var errDocumentTooLarge = errors.New("document is too large")
+
+// DocumentUseCase defines the interface for uploading and reading loan documents
+type DocumentUseCase interface {
+	// Store an uploaded file and attach it to a loan as a document
+	UploadDocument(ctx context.Context, loanID string, docType model.DocumentType, name string, r io.Reader) (*model.Document, error)
+
+	// Read back an uploaded document after checking it was not altered in storage
+	GetDocumentContent(ctx context.Context, documentID string) (*model.Document, []byte, error)
+}
+
+// DocumentUseCaseImpl implements DocumentUseCase interface
+type DocumentUseCaseImpl struct {
+	loanRepo repo.LoanRepository
+	store    storage.BlobStore
+	policy   DocumentUploadPolicy
+}
+
+// NewDocumentUseCase creates a new document use case instance
+func NewDocumentUseCase(loanRepo repo.LoanRepository, store storage.BlobStore, policy DocumentUploadPolicy) DocumentUseCase {
+	return &DocumentUseCaseImpl{
+		loanRepo: loanRepo,
+		store:    store,
+		policy:   policy,
+	}
+}
+
+// UploadDocument checks the content type and size of the file, stores it under a fresh key and
+// records its digest on a new document. The loan moves to review like a document submission.
+func (uc *DocumentUseCaseImpl) UploadDocument(ctx context.Context, loanID string, docType model.DocumentType, name string, r io.Reader) (*model.Document, error) {
+	if !knownDocumentTypes[docType] {
+		return nil, NewValidationError(fmt.Sprintf("unknown document type %q", docType))
+	}
+
+	loan, err := uc.loanRepo.GetByID(ctx, loanID)
+	if err != nil {
+		return nil, err
+	}
+	if loan == nil {
+		return nil, ErrLoanNotFound
+	}
+	if err := authorizeLoanAccess(ctx, loan); err != nil {
+		return nil, err
+	}
+	if err := checkTransition(ctx, loan.Status, model.LoanStatusInReview); err != nil {
+		return nil, err
+	}
+
+	head := make([]byte, sniffLength)
+	n, err := io.ReadFull(r, head)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return nil, fmt.Errorf("failed to read document: %v", err)
+	}
+	head = head[:n]
+	if n == 0 {
+		return nil, NewValidationError("document is empty")
+	}
+
+	contentType := detectContentType(head)
+	if !uc.allowsContentType(contentType) {
+		return nil, NewValidationError(fmt.Sprintf("documents of type %s are not accepted", contentType))
+	}
+
+	key, err := newStorageKey(loan.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	hash := sha256.New()
+	content := &limitedReader{r: io.MultiReader(bytes.NewReader(head), r), remaining: uc.policy.MaxSizeBytes}
+	if err := uc.store.Put(ctx, key, io.TeeReader(content, hash)); err != nil {
+		if errors.Is(err, errDocumentTooLarge) {
+			return nil, NewValidationError(fmt.Sprintf("document must not be larger than %d bytes", uc.policy.MaxSizeBytes))
+		}
+		return nil, err
+	}
+
+	doc := &model.Document{
+		LoanID:      loan.ID,
+		Type:        docType,
+		Name:        documentName(name, docType),
+		Status:      model.DocumentStatusUploaded,
+		StorageKey:  key,
+		ContentType: contentType,
+		SizeBytes:   uc.policy.MaxSizeBytes - content.remaining,
+		SHA256:      hex.EncodeToString(hash.Sum(nil)),
+		UploadedAt:  timePtr(time.Now()),
+	}
+	if err := uc.loanRepo.AddDocument(ctx, doc); err != nil {
+		uc.store.Delete(ctx, key)
+		return nil, err
+	}
+
+	if err := uc.loanRepo.UpdateLoanStatus(ctx, loan.ID, model.LoanStatusInReview, "document uploaded"); err != nil {
+		return nil, err
+	}
+
+	return doc, nil
+}
+
+// GetDocumentContent reads an uploaded document and verifies it against the recorded digest
+func (uc *DocumentUseCaseImpl) GetDocumentContent(ctx context.Context, documentID string) (*model.Document, []byte, error) {
+	doc, err := uc.loanRepo.GetDocumentByID(ctx, documentID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if doc == nil {
+		return nil, nil, ErrDocumentNotFound
+	}
+
+	loan, err := uc.loanRepo.GetByID(ctx, doc.LoanID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if loan == nil {
+		return nil, nil, ErrLoanNotFound
+	}
+	if err := authorizeLoanAccess(ctx, loan); err != nil {
+		return nil, nil, err
+	}
+	if doc.StorageKey == "" {
+		return nil, nil, NewValidationError("document was submitted as a link and has no stored content")
+	}
+
+	rc, err := uc.store.Open(ctx, doc.StorageKey)
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, nil, fmt.Errorf("stored content of document %s is missing", doc.ID)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rc.Close()
+
+	data, err := io.ReadAll(io.LimitReader(rc, doc.SizeBytes+1))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read document: %v", err)
+	}
+
+	sum := sha256.Sum256(data)
+	if int64(len(data)) != doc.SizeBytes || hex.EncodeToString(sum[:]) != doc.SHA256 {
+		return nil, nil, fmt.Errorf("stored content of document %s failed the integrity check", doc.ID)
+	}
+
+	return doc, data, nil
+}
+
+func (uc *DocumentUseCaseImpl) allowsContentType(contentType string) bool {
+	for _, allowed := range uc.policy.AllowedContentTypes {
+		if allowed == contentType {
+			return true
+		}
+	}
+	return false
+}
+
+// authorizeLoanAccess lets customers reach only their own loans; staff and batch jobs reach all
+func authorizeLoanAccess(ctx context.Context, loan *model.Loan) error {
+	actor := model.ActorFromContext(ctx)
+	switch actor.Role {
+	case model.ActorRoleAdmin, model.ActorRoleSystem:
+		return nil
+	case model.ActorRoleCustomer:
+		if actor.ID != "" && actor.ID == loan.UserID {
+			return nil
+		}
+	}
+	return ErrLoanAccessDenied
+}
+
+// detectContentType returns the media type sniffed from the start of a file, without parameters
+func detectContentType(head []byte) string {
+	contentType := http.DetectContentType(head)
+	if i := strings.IndexByte(contentType, ';'); i >= 0 {
+		contentType = contentType[:i]
+	}
+	return strings.TrimSpace(contentType)
+}
+
+// newStorageKey returns a fresh blob store key for a document of the loan
+func newStorageKey(loanID string) (string, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", fmt.Errorf("failed to generate storage key: %v", err)
+	}
+	return "documents/" + loanID + "/" + hex.EncodeToString(id), nil
+}
+
+// documentName keeps the base name of the uploaded file, falling back to the document type
+func documentName(name string, docType model.DocumentType) string {
+	name = strings.TrimSpace(filepath.Base(strings.ReplaceAll(name, "\\", "/")))
+	if name == "" || name == "." || name == "/" {
+		return string(docType)
+	}
+	if len(name) > 255 {
+		name = name[:255]
+	}
+	return name
+}
+
+// limitedReader fails with errDocumentTooLarge instead of truncating once more than
+// remaining bytes are read
+type limitedReader struct {
+	r         io.Reader
+	remaining int64
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	n, err := l.r.Read(p)
+	l.remaining -= int64(n)
+	if l.remaining < 0 {
+		return n, errDocumentTooLarge
+	}
+	return n, err
+}
@@ -0,0 +1,134 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/edosulai/pt-xyz-multifinance/internal/model"
+	"github.com/edosulai/pt-xyz-multifinance/pkg/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// pngHeader is enough of a PNG file for content type detection
+var pngHeader = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+func newDocumentUseCase(t *testing.T, loanRepo *MockLoanRepository, maxSize int64) (*DocumentUseCaseImpl, string) {
+	dir := t.TempDir()
+	store, err := storage.NewLocalStore(dir)
+	require.NoError(t, err)
+
+	policy := DefaultDocumentUploadPolicy
+	policy.MaxSizeBytes = maxSize
+	return NewDocumentUseCase(loanRepo, store, policy).(*DocumentUseCaseImpl), dir
+}
+
+func TestUploadDocument(t *testing.T) {
+	customer := model.ContextWithActor(context.Background(), model.Actor{ID: "user-1", Role: model.ActorRoleCustomer})
+	stranger := model.ContextWithActor(context.Background(), model.Actor{ID: "user-2", Role: model.ActorRoleCustomer})
+	content := append(append([]byte{}, pngHeader...), bytes.Repeat([]byte{0x42}, 600)...)
+
+	newLoanRepo := func(ctx context.Context) *MockLoanRepository {
+		loanRepo := new(MockLoanRepository)
+		loanRepo.On("GetByID", ctx, "loan-1").Return(&model.Loan{ID: "loan-1", UserID: "user-1", Status: model.LoanStatusPending}, nil)
+		return loanRepo
+	}
+
+	t.Run("stored with digest", func(t *testing.T) {
+		loanRepo := newLoanRepo(customer)
+		loanRepo.On("AddDocument", customer, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+			args.Get(1).(*model.Document).ID = "doc-1"
+		})
+		loanRepo.On("UpdateLoanStatus", customer, "loan-1", model.LoanStatusInReview, "document uploaded").Return(nil)
+		uc, dir := newDocumentUseCase(t, loanRepo, 1024)
+
+		doc, err := uc.UploadDocument(customer, "loan-1", model.DocumentTypeKTP, `C:\scans\ktp.png`, bytes.NewReader(content))
+
+		require.NoError(t, err)
+		sum := sha256.Sum256(content)
+		assert.Equal(t, hex.EncodeToString(sum[:]), doc.SHA256)
+		assert.Equal(t, int64(len(content)), doc.SizeBytes)
+		assert.Equal(t, "image/png", doc.ContentType)
+		assert.Equal(t, "ktp.png", doc.Name)
+		assert.Equal(t, model.DocumentStatusUploaded, doc.Status)
+		assert.Empty(t, doc.URL)
+		assert.True(t, strings.HasPrefix(doc.StorageKey, "documents/loan-1/"))
+
+		stored, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(doc.StorageKey)))
+		require.NoError(t, err)
+		assert.Equal(t, content, stored)
+	})
+
+	t.Run("content type not accepted", func(t *testing.T) {
+		loanRepo := newLoanRepo(customer)
+		uc, _ := newDocumentUseCase(t, loanRepo, 1024)
+
+		_, err := uc.UploadDocument(customer, "loan-1", model.DocumentTypeKTP, "ktp.png", strings.NewReader("<html><script>alert(1)</script></html>"))
+
+		assert.ErrorAs(t, err, &ValidationError{})
+		loanRepo.AssertNotCalled(t, "AddDocument", mock.Anything, mock.Anything)
+	})
+
+	t.Run("too large", func(t *testing.T) {
+		loanRepo := newLoanRepo(customer)
+		uc, dir := newDocumentUseCase(t, loanRepo, 100)
+
+		_, err := uc.UploadDocument(customer, "loan-1", model.DocumentTypeKTP, "ktp.png", bytes.NewReader(content))
+
+		assert.ErrorAs(t, err, &ValidationError{})
+		loanRepo.AssertNotCalled(t, "AddDocument", mock.Anything, mock.Anything)
+		entries, _ := os.ReadDir(filepath.Join(dir, "documents", "loan-1"))
+		assert.Empty(t, entries)
+	})
+
+	t.Run("unknown document type", func(t *testing.T) {
+		uc, _ := newDocumentUseCase(t, new(MockLoanRepository), 1024)
+
+		_, err := uc.UploadDocument(customer, "loan-1", "selfie", "me.png", bytes.NewReader(content))
+
+		assert.ErrorAs(t, err, &ValidationError{})
+	})
+
+	t.Run("another customer's loan", func(t *testing.T) {
+		uc, _ := newDocumentUseCase(t, newLoanRepo(stranger), 1024)
+
+		_, err := uc.UploadDocument(stranger, "loan-1", model.DocumentTypeKTP, "ktp.png", bytes.NewReader(content))
+
+		assert.ErrorIs(t, err, ErrLoanAccessDenied)
+	})
+}
+
+func TestGetDocumentContent(t *testing.T) {
+	ctx := model.ContextWithActor(context.Background(), model.Actor{ID: "admin-1", Role: model.ActorRoleAdmin})
+	content := append(append([]byte{}, pngHeader...), []byte("payload")...)
+	sum := sha256.Sum256(content)
+
+	loanRepo := new(MockLoanRepository)
+	loanRepo.On("GetByID", ctx, "loan-1").Return(&model.Loan{ID: "loan-1", UserID: "user-1"}, nil)
+	loanRepo.On("GetDocumentByID", ctx, "doc-1").Return(&model.Document{
+		ID: "doc-1", LoanID: "loan-1", StorageKey: "documents/loan-1/abc", ContentType: "image/png",
+		SizeBytes: int64(len(content)), SHA256: hex.EncodeToString(sum[:]),
+	}, nil)
+	loanRepo.On("GetDocumentByID", ctx, "doc-2").Return(&model.Document{ID: "doc-2", LoanID: "loan-1", URL: "http://storage.example.com/ktp.jpg"}, nil)
+	uc, dir := newDocumentUseCase(t, loanRepo, 1024)
+	require.NoError(t, uc.store.Put(ctx, "documents/loan-1/abc", bytes.NewReader(content)))
+
+	_, data, err := uc.GetDocumentContent(ctx, "doc-1")
+	require.NoError(t, err)
+	assert.Equal(t, content, data)
+
+	_, _, err = uc.GetDocumentContent(ctx, "doc-2")
+	assert.ErrorAs(t, err, &ValidationError{})
+
+	tampered := append(append([]byte{}, pngHeader...), []byte("PAYLOAD")...)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "documents", "loan-1", "abc"), tampered, 0o600))
+	_, _, err = uc.GetDocumentContent(ctx, "doc-1")
+	assert.ErrorContains(t, err, "integrity check")
+}
@@ -39,4 +39,11 @@ var (
 	ErrTooManyLoginAttempts = errors.New("too many login attempts, please try again later")
 	ErrAccountLocked        = errors.New("account is locked due to too many failed attempts")
 	ErrLoanNotFound         = errors.New("loan not found")
+	ErrPayoffQuoteNotFound  = errors.New("payoff quote not found")
+	ErrBankAccountNotFound  = errors.New("bank account not found")
+	ErrDisbursementNotFound = errors.New("disbursement instruction not found")
+	ErrDocumentNotFound     = errors.New("document not found")
+	ErrLoanAccessDenied     = errors.New("not allowed to access another user's loan")
+	ErrOfferTermsChanged    = errors.New("loan offer terms have changed, please review the current offer")
+	ErrOfferNotAccepted     = errors.New("loan offer has not been accepted on the current terms")
 )
@@ -0,0 +1,115 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/edosulai/pt-xyz-multifinance/internal/model"
+	"github.com/edosulai/pt-xyz-multifinance/internal/repo"
+)
+
+// AcceptLoanOffer approves an offered loan on behalf of its applicant. termsHash must be the
+// digest of the offer terms shown to them, so that consent is only recorded for the terms the
+// loan is actually approved on; the terms are stored with the client the offer was accepted from.
+func (uc *LoanUseCaseImpl) AcceptLoanOffer(ctx context.Context, loanID, termsHash, ipAddress, userAgent string) (*model.LoanOfferAcceptance, error) {
+	termsHash = strings.ToLower(strings.TrimSpace(termsHash))
+	if termsHash == "" {
+		return nil, NewValidationError("terms hash is required")
+	}
+
+	loan, err := uc.loanRepo.GetByID(ctx, loanID)
+	if err != nil {
+		return nil, err
+	}
+	if loan == nil {
+		return nil, ErrLoanNotFound
+	}
+	if err := authorizeLoanAccess(ctx, loan); err != nil {
+		return nil, err
+	}
+
+	// Accepted offers stay accepted; consent is recorded once
+	if loan.Status != model.LoanStatusOffered {
+		return nil, &model.StatusTransitionError{From: loan.Status, To: model.LoanStatusApproved}
+	}
+	if err := checkTransition(ctx, loan.Status, model.LoanStatusApproved); err != nil {
+		return nil, err
+	}
+
+	terms, hash, err := loan.OfferTerms().Encode()
+	if err != nil {
+		return nil, err
+	}
+	if hash != termsHash {
+		return nil, ErrOfferTermsChanged
+	}
+
+	acceptance := &model.LoanOfferAcceptance{
+		LoanID:     loan.ID,
+		UserID:     loan.UserID,
+		Terms:      terms,
+		TermsHash:  hash,
+		AcceptedAt: time.Now(),
+		IPAddress:  strings.TrimSpace(ipAddress),
+		UserAgent:  strings.TrimSpace(userAgent),
+	}
+	if err := uc.loanRepo.AcceptOffer(ctx, acceptance, loan.UpdatedAt, "offer accepted"); err != nil {
+		if errors.Is(err, repo.ErrLoanOfferChanged) {
+			return nil, ErrOfferTermsChanged
+		}
+		return nil, err
+	}
+
+	return acceptance, nil
+}
+
+// DeclineLoanOffer closes an offered loan on behalf of its applicant
+func (uc *LoanUseCaseImpl) DeclineLoanOffer(ctx context.Context, loanID, reason string) (*model.Loan, error) {
+	loan, err := uc.loanRepo.GetByID(ctx, loanID)
+	if err != nil {
+		return nil, err
+	}
+	if loan == nil {
+		return nil, ErrLoanNotFound
+	}
+	if err := authorizeLoanAccess(ctx, loan); err != nil {
+		return nil, err
+	}
+	if err := checkTransition(ctx, loan.Status, model.LoanStatusDeclined); err != nil {
+		return nil, err
+	}
+
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		reason = "offer declined"
+	}
+	if err := uc.loanRepo.UpdateLoanStatus(ctx, loan.ID, model.LoanStatusDeclined, reason); err != nil {
+		return nil, err
+	}
+
+	loan.Status = model.LoanStatusDeclined
+	return loan, nil
+}
+
+// checkOfferAccepted makes sure the borrower accepted the offer on the terms the loan still has,
+// and that the recorded terms are the ones the hash was taken of
+func (uc *LoanUseCaseImpl) checkOfferAccepted(ctx context.Context, loan *model.Loan) error {
+	acceptance, err := uc.loanRepo.GetOfferAcceptance(ctx, loan.ID)
+	if err != nil {
+		return err
+	}
+	if acceptance == nil {
+		return ErrOfferNotAccepted
+	}
+
+	_, hash, err := loan.OfferTerms().Encode()
+	if err != nil {
+		return err
+	}
+	if hash != acceptance.TermsHash || !acceptance.TermsIntact() {
+		return ErrOfferNotAccepted
+	}
+	return nil
+}
@@ -0,0 +1,222 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/edosulai/pt-xyz-multifinance/internal/model"
+	"github.com/edosulai/pt-xyz-multifinance/internal/repo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// acceptedOffer returns the acceptance of the loan's current terms
+func acceptedOffer(t *testing.T, loan *model.Loan) *model.LoanOfferAcceptance {
+	terms, hash, err := loan.OfferTerms().Encode()
+	require.NoError(t, err)
+	return &model.LoanOfferAcceptance{LoanID: loan.ID, UserID: loan.UserID, Terms: terms, TermsHash: hash}
+}
+
+func offeredLoan() *model.Loan {
+	return &model.Loan{
+		ID: "loan-1", UserID: "user-1", ProductCode: "PERSONAL", Status: model.LoanStatusOffered, Amount: model.NewMoney(12000000),
+		TenureMonths: 12, InterestRate: 18, InterestMethod: model.InterestMethodAnnuity, EffectiveAnnualRate: 19.5618,
+		MonthlyPayment: model.MustParseMoney("1100159.91"),
+	}
+}
+
+func TestAcceptLoanOffer(t *testing.T) {
+	ctx := model.ContextWithActor(context.Background(), model.Actor{ID: "user-1", Role: model.ActorRoleCustomer})
+	_, hash, err := offeredLoan().OfferTerms().Encode()
+	require.NoError(t, err)
+
+	t.Run("consent recorded", func(t *testing.T) {
+		loan := offeredLoan()
+		loanRepo := new(MockLoanRepository)
+		loanRepo.On("GetByID", ctx, "loan-1").Return(loan, nil)
+		loanRepo.On("AcceptOffer", ctx, mock.AnythingOfType("*model.LoanOfferAcceptance"), loan.UpdatedAt, "offer accepted").Return(nil)
+
+		acceptance, err := NewLoanUseCase(loanRepo, nil, nil, nil, nil, nil, nil, nil, nil).AcceptLoanOffer(ctx, "loan-1", " "+hash+" ", "203.0.113.7", "XYZ Mobile/2.1")
+
+		require.NoError(t, err)
+		assert.Equal(t, hash, acceptance.TermsHash)
+		assert.Equal(t, "user-1", acceptance.UserID)
+		assert.Equal(t, "203.0.113.7", acceptance.IPAddress)
+		assert.Equal(t, "XYZ Mobile/2.1", acceptance.UserAgent)
+		assert.False(t, acceptance.AcceptedAt.IsZero())
+		assert.JSONEq(t, `{"loan_id":"loan-1","product_code":"PERSONAL","amount":"12000000.00","tenure_months":12,"interest_rate":18,"interest_method":"annuity","effective_annual_rate":19.5618,"monthly_payment":"1100159.91"}`, string(acceptance.Terms))
+	})
+
+	t.Run("terms changed since shown", func(t *testing.T) {
+		loan := offeredLoan()
+		loan.InterestRate = 20
+		loanRepo := new(MockLoanRepository)
+		loanRepo.On("GetByID", ctx, "loan-1").Return(loan, nil)
+
+		_, err := NewLoanUseCase(loanRepo, nil, nil, nil, nil, nil, nil, nil, nil).AcceptLoanOffer(ctx, "loan-1", hash, "", "")
+
+		assert.ErrorIs(t, err, ErrOfferTermsChanged)
+		loanRepo.AssertNotCalled(t, "AcceptOffer", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("repriced while accepting", func(t *testing.T) {
+		loanRepo := new(MockLoanRepository)
+		loanRepo.On("GetByID", ctx, "loan-1").Return(offeredLoan(), nil)
+		loanRepo.On("AcceptOffer", ctx, mock.Anything, mock.Anything, mock.Anything).Return(repo.ErrLoanOfferChanged)
+
+		_, err := NewLoanUseCase(loanRepo, nil, nil, nil, nil, nil, nil, nil, nil).AcceptLoanOffer(ctx, "loan-1", hash, "", "")
+
+		assert.ErrorIs(t, err, ErrOfferTermsChanged)
+	})
+
+	t.Run("rejected", func(t *testing.T) {
+		admin := model.ContextWithActor(context.Background(), model.Actor{ID: "admin-1", Role: model.ActorRoleAdmin})
+		other := model.ContextWithActor(context.Background(), model.Actor{ID: "user-2", Role: model.ActorRoleCustomer})
+		accepted := offeredLoan()
+		accepted.Status = model.LoanStatusApproved
+
+		loanRepo := new(MockLoanRepository)
+		loanRepo.On("GetByID", mock.Anything, "loan-1").Return(offeredLoan(), nil)
+		loanRepo.On("GetByID", mock.Anything, "loan-2").Return(accepted, nil)
+		uc := NewLoanUseCase(loanRepo, nil, nil, nil, nil, nil, nil, nil, nil)
+
+		_, err := uc.AcceptLoanOffer(ctx, "loan-1", "", "", "")
+		assert.ErrorAs(t, err, &ValidationError{})
+
+		_, err = uc.AcceptLoanOffer(other, "loan-1", hash, "", "")
+		assert.ErrorIs(t, err, ErrLoanAccessDenied)
+
+		var transitionErr *model.StatusTransitionError
+		_, err = uc.AcceptLoanOffer(admin, "loan-1", hash, "", "")
+		assert.ErrorAs(t, err, &transitionErr)
+
+		_, err = uc.AcceptLoanOffer(ctx, "loan-2", hash, "", "")
+		assert.ErrorAs(t, err, &transitionErr)
+
+		loanRepo.AssertNotCalled(t, "AcceptOffer", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestDeclineLoanOffer(t *testing.T) {
+	ctx := model.ContextWithActor(context.Background(), model.Actor{ID: "user-1", Role: model.ActorRoleCustomer})
+
+	t.Run("declined", func(t *testing.T) {
+		loanRepo := new(MockLoanRepository)
+		loanRepo.On("GetByID", ctx, "loan-1").Return(offeredLoan(), nil)
+		loanRepo.On("UpdateLoanStatus", ctx, "loan-1", model.LoanStatusDeclined, "offer declined").Return(nil)
+
+		loan, err := NewLoanUseCase(loanRepo, nil, nil, nil, nil, nil, nil, nil, nil).DeclineLoanOffer(ctx, "loan-1", " ")
+
+		require.NoError(t, err)
+		assert.Equal(t, model.LoanStatusDeclined, loan.Status)
+		loanRepo.AssertExpectations(t)
+	})
+
+	t.Run("loan not offered", func(t *testing.T) {
+		loan := offeredLoan()
+		loan.Status = model.LoanStatusInReview
+		loanRepo := new(MockLoanRepository)
+		loanRepo.On("GetByID", ctx, "loan-1").Return(loan, nil)
+
+		_, err := NewLoanUseCase(loanRepo, nil, nil, nil, nil, nil, nil, nil, nil).DeclineLoanOffer(ctx, "loan-1", "found a better rate")
+
+		var transitionErr *model.StatusTransitionError
+		assert.ErrorAs(t, err, &transitionErr)
+		loanRepo.AssertNotCalled(t, "UpdateLoanStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestDisburseLoan_RequiresAcceptedOffer(t *testing.T) {
+	ctx := model.ContextWithActor(context.Background(), model.Actor{ID: "admin-1", Role: model.ActorRoleAdmin})
+	loan := offeredLoan()
+	loan.Status = model.LoanStatusApproved
+	stale := acceptedOffer(t, loan)
+	stale.TermsHash = "0000000000000000000000000000000000000000000000000000000000000000"
+
+	tests := []struct {
+		name       string
+		acceptance *model.LoanOfferAcceptance
+	}{
+		{"offer never accepted", nil},
+		{"accepted on other terms", stale},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loanRepo := new(MockLoanRepository)
+			loanRepo.On("GetByID", ctx, "loan-1").Return(loan, nil)
+			loanRepo.On("GetOfferAcceptance", ctx, "loan-1").Return(tt.acceptance, nil)
+			disbursementRepo := new(MockDisbursementRepository)
+
+			_, err := NewLoanUseCase(loanRepo, nil, nil, nil, nil, nil, nil, nil, disbursementRepo).DisburseLoan(ctx, "loan-1", model.NewMoney(12000000), "acc-1")
+
+			assert.ErrorIs(t, err, ErrOfferNotAccepted)
+			disbursementRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+
+	t.Run("offer still open", func(t *testing.T) {
+		loanRepo := new(MockLoanRepository)
+		loanRepo.On("GetByID", ctx, "loan-1").Return(offeredLoan(), nil)
+
+		_, err := NewLoanUseCase(loanRepo, nil, nil, nil, nil, nil, nil, nil, nil).DisburseLoan(ctx, "loan-1", model.NewMoney(12000000), "acc-1")
+
+		var transitionErr *model.StatusTransitionError
+		assert.ErrorAs(t, err, &transitionErr)
+	})
+}
+
+func TestDisburseLoan_TranchesKeepAcceptedOffer(t *testing.T) {
+	admin := model.ContextWithActor(context.Background(), model.Actor{ID: "admin-1", Role: model.ActorRoleAdmin})
+	system := model.ContextWithActor(context.Background(), model.SystemActor)
+	loan := offeredLoan()
+	loan.Status = model.LoanStatusApproved
+	acceptance := acceptedOffer(t, loan)
+	accepted := loan.OfferTerms()
+
+	loanRepo := new(MockLoanRepository)
+	loanRepo.On("GetByID", mock.Anything, "loan-1").Return(loan, nil)
+	loanRepo.On("GetOfferAcceptance", mock.Anything, "loan-1").Return(acceptance, nil)
+	accountRepo := new(MockBankAccountRepository)
+	accountRepo.On("GetByID", mock.Anything, "acc-1").Return(&model.BankAccount{ID: "acc-1", UserID: "user-1", Status: model.BankAccountStatusVerified}, nil)
+	installmentRepo := new(MockInstallmentRepository)
+	disbursementRepo := new(MockDisbursementRepository)
+	disbursementRepo.On("Create", mock.Anything, mock.Anything, loan).Return(nil)
+	disbursementRepo.On("GetByID", mock.Anything, "dis-1").Return(&model.DisbursementInstruction{ID: "dis-1", LoanID: "loan-1", Amount: model.NewMoney(5000000), Status: model.DisbursementStatusSent}, nil)
+	disbursementRepo.On("GetByID", mock.Anything, "dis-2").Return(&model.DisbursementInstruction{ID: "dis-2", LoanID: "loan-1", Amount: model.NewMoney(7000000), Status: model.DisbursementStatusSent}, nil)
+
+	// Stand in for the database: the loan takes the tranche and the schedule is replaced
+	var schedule []model.Installment
+	disbursementRepo.On("Complete", mock.Anything, mock.Anything, model.DisbursementStatusSent, mock.Anything, loan, mock.Anything).Run(func(args mock.Arguments) {
+		tranche := args.Get(3).(*model.Disbursement)
+		if loan.DisbursedAt == nil {
+			loan.DisbursedAt = &tranche.DisbursedAt
+		}
+		loan.DisbursedAmount += tranche.Amount
+		schedule = args.Get(5).([]model.Installment)
+		installmentRepo.On("GetByLoanID", mock.Anything, "loan-1").Return(schedule, nil).Once()
+	}).Return(nil)
+
+	uc := NewLoanUseCase(loanRepo, nil, installmentRepo, nil, nil, nil, nil, accountRepo, disbursementRepo)
+
+	_, err := uc.DisburseLoan(admin, "loan-1", model.NewMoney(5000000), "acc-1")
+	require.NoError(t, err)
+	_, err = uc.UpdateDisbursementStatus(system, "dis-1", model.DisbursementStatusSucceeded, "TRF-001", "")
+	require.NoError(t, err)
+
+	assert.Equal(t, model.LoanStatusPartiallyDisbursed, loan.Status)
+	assert.Equal(t, accepted, loan.OfferTerms())
+	assert.Less(t, int64(schedule[0].TotalAmount), int64(loan.MonthlyPayment))
+	require.NoError(t, uc.(*LoanUseCaseImpl).checkOfferAccepted(admin, loan))
+
+	_, err = uc.DisburseLoan(admin, "loan-1", model.NewMoney(7000000), "acc-1")
+	require.NoError(t, err)
+	_, err = uc.UpdateDisbursementStatus(system, "dis-2", model.DisbursementStatusSucceeded, "TRF-002", "")
+	require.NoError(t, err)
+
+	assert.Equal(t, model.LoanStatusDisbursed, loan.Status)
+	assert.Equal(t, accepted, loan.OfferTerms())
+	require.NoError(t, uc.(*LoanUseCaseImpl).checkOfferAccepted(admin, loan))
+	loanRepo.AssertNumberOfCalls(t, "GetOfferAcceptance", 4)
+}
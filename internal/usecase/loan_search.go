@@ -0,0 +1,96 @@
+package usecase
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/edosulai/pt-xyz-multifinance/internal/model"
+)
+
+const (
+	// defaultSearchPageSize is used when a loan search does not ask for a page size
+	defaultSearchPageSize = 20
+	// maxSearchPageSize bounds how many loans a single search page returns
+	maxSearchPageSize = 100
+)
+
+// loanCursor is the decoded form of the opaque page token returned by a loan search. It pins the
+// filter and sort it was issued for so it cannot be replayed against a different query.
+type loanCursor struct {
+	Query     string    `json:"q"`
+	CreatedAt time.Time `json:"c"`
+	Amount    int64     `json:"a"`
+	ID        string    `json:"i"`
+}
+
+// searchFingerprint identifies the filter and sort of a loan search
+func searchFingerprint(filter model.LoanSearchFilter, sort model.LoanSort) string {
+	data, _ := json.Marshal(struct {
+		Filter model.LoanSearchFilter
+		Sort   model.LoanSort
+	}{filter, sort})
+	sum := sha256.Sum256(data)
+	return base64.RawURLEncoding.EncodeToString(sum[:8])
+}
+
+// encodeLoanCursor returns the token for the page that follows key
+func encodeLoanCursor(fingerprint string, key model.LoanSearchKey) string {
+	data, _ := json.Marshal(loanCursor{
+		Query:     fingerprint,
+		CreatedAt: key.CreatedAt,
+		Amount:    key.Amount.Sen(),
+		ID:        key.ID,
+	})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeLoanCursor parses a page token issued for the search identified by fingerprint
+func decodeLoanCursor(fingerprint, token string) (*model.LoanSearchKey, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, NewValidationError("invalid page token")
+	}
+
+	var cursor loanCursor
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.ID == "" {
+		return nil, NewValidationError("invalid page token")
+	}
+	if cursor.Query != fingerprint {
+		return nil, NewValidationError("page token does not match the search filter and sort")
+	}
+
+	return &model.LoanSearchKey{
+		CreatedAt: cursor.CreatedAt,
+		Amount:    model.Money(cursor.Amount),
+		ID:        cursor.ID,
+	}, nil
+}
+
+// validateLoanSearch rejects contradictory criteria and fills in the default sort
+func validateLoanSearch(filter model.LoanSearchFilter, sort *model.LoanSort) error {
+	switch sort.Field {
+	case "":
+		sort.Field = model.LoanSortCreatedAt
+	case model.LoanSortCreatedAt, model.LoanSortAmount:
+	default:
+		return NewValidationError(fmt.Sprintf("loans cannot be sorted by %q", sort.Field))
+	}
+
+	if filter.MinAmount < 0 || filter.MaxAmount < 0 {
+		return NewValidationError("amount range must not be negative")
+	}
+	if filter.MaxAmount > 0 && filter.MinAmount > filter.MaxAmount {
+		return NewValidationError("minimum amount must not exceed maximum amount")
+	}
+	if filter.CreatedFrom != nil && filter.CreatedTo != nil && !filter.CreatedFrom.Before(*filter.CreatedTo) {
+		return NewValidationError("created date range must start before it ends")
+	}
+	if filter.DisbursedFrom != nil && filter.DisbursedTo != nil && !filter.DisbursedFrom.Before(*filter.DisbursedTo) {
+		return NewValidationError("disbursed date range must start before it ends")
+	}
+
+	return nil
+}
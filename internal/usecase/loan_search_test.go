@@ -0,0 +1,100 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/edosulai/pt-xyz-multifinance/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchLoans(t *testing.T) {
+	ctx := model.ContextWithActor(context.Background(), model.Actor{ID: "admin-1", Role: model.ActorRoleAdmin})
+	created := time.Date(2024, 1, 15, 9, 30, 0, 0, time.UTC)
+
+	filter := model.LoanSearchFilter{Statuses: []model.LoanStatus{model.LoanStatusInReview}, MinAmount: model.NewMoney(1000000)}
+	sort := model.LoanSort{Field: model.LoanSortAmount, Descending: true}
+	firstPage := []model.Loan{
+		{ID: "loan-1", Amount: model.NewMoney(9000000), CreatedAt: created},
+		{ID: "loan-2", Amount: model.NewMoney(7000000), CreatedAt: created},
+		{ID: "loan-3", Amount: model.NewMoney(5000000), CreatedAt: created},
+	}
+	secondPage := []model.Loan{
+		{ID: "loan-3", Amount: model.NewMoney(5000000), CreatedAt: created},
+	}
+
+	loanRepo := new(MockLoanRepository)
+	loanRepo.On("SearchLoans", ctx, filter, sort, (*model.LoanSearchKey)(nil), 3).Return(firstPage, nil)
+	loanRepo.On("SearchLoans", ctx, filter, sort, &model.LoanSearchKey{CreatedAt: created, Amount: model.NewMoney(7000000), ID: "loan-2"}, 3).Return(secondPage, nil)
+	uc := NewLoanUseCase(loanRepo, nil, nil, nil, nil, nil, nil, nil, nil)
+
+	loans, token, err := uc.SearchLoans(ctx, filter, sort, "", 2)
+	require.NoError(t, err)
+	assert.Len(t, loans, 2)
+	assert.NotEmpty(t, token)
+
+	loans, next, err := uc.SearchLoans(ctx, filter, sort, token, 2)
+	require.NoError(t, err)
+	assert.Equal(t, "loan-3", loans[0].ID)
+	assert.Empty(t, next)
+
+	t.Run("token reused with another sort", func(t *testing.T) {
+		_, _, err := uc.SearchLoans(ctx, filter, model.LoanSort{Field: model.LoanSortAmount}, token, 2)
+		assert.ErrorAs(t, err, &ValidationError{})
+	})
+
+	t.Run("malformed token", func(t *testing.T) {
+		_, _, err := uc.SearchLoans(ctx, filter, sort, "not a token", 2)
+		assert.ErrorAs(t, err, &ValidationError{})
+	})
+
+	loanRepo.AssertNumberOfCalls(t, "SearchLoans", 2)
+}
+
+func TestSearchLoans_Defaults(t *testing.T) {
+	ctx := context.Background()
+
+	loanRepo := new(MockLoanRepository)
+	loanRepo.On("SearchLoans", ctx, model.LoanSearchFilter{}, model.LoanSort{Field: model.LoanSortCreatedAt}, (*model.LoanSearchKey)(nil), defaultSearchPageSize+1).Return([]model.Loan{}, nil)
+	loanRepo.On("SearchLoans", ctx, model.LoanSearchFilter{}, model.LoanSort{Field: model.LoanSortCreatedAt}, (*model.LoanSearchKey)(nil), maxSearchPageSize+1).Return([]model.Loan{}, nil)
+	uc := NewLoanUseCase(loanRepo, nil, nil, nil, nil, nil, nil, nil, nil)
+
+	_, _, err := uc.SearchLoans(ctx, model.LoanSearchFilter{}, model.LoanSort{}, "", 0)
+	assert.NoError(t, err)
+
+	_, _, err = uc.SearchLoans(ctx, model.LoanSearchFilter{}, model.LoanSort{}, "", 1000)
+	assert.NoError(t, err)
+
+	loanRepo.AssertExpectations(t)
+}
+
+func TestSearchLoans_InvalidCriteria(t *testing.T) {
+	from := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		filter model.LoanSearchFilter
+		sort   model.LoanSort
+	}{
+		{"unknown sort field", model.LoanSearchFilter{}, model.LoanSort{Field: "purpose"}},
+		{"inverted amount range", model.LoanSearchFilter{MinAmount: model.NewMoney(5000000), MaxAmount: model.NewMoney(1000000)}, model.LoanSort{}},
+		{"inverted created range", model.LoanSearchFilter{CreatedFrom: &from, CreatedTo: &to}, model.LoanSort{}},
+		{"inverted disbursed range", model.LoanSearchFilter{DisbursedFrom: &from, DisbursedTo: &to}, model.LoanSort{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loanRepo := new(MockLoanRepository)
+			uc := NewLoanUseCase(loanRepo, nil, nil, nil, nil, nil, nil, nil, nil)
+
+			_, _, err := uc.SearchLoans(context.Background(), tt.filter, tt.sort, "", 10)
+
+			assert.ErrorAs(t, err, &ValidationError{})
+			loanRepo.AssertNotCalled(t, "SearchLoans", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
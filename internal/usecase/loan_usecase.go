@@ -2,17 +2,23 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/edosulai/pt-xyz-multifinance/internal/model"
 	"github.com/edosulai/pt-xyz-multifinance/internal/repo"
+	"github.com/edosulai/pt-xyz-multifinance/pkg/interest"
 )
 
 // LoanUseCase defines the interface for loan business logic
 type LoanUseCase interface {
-	// Apply for a new loan
-	ApplyLoan(ctx context.Context, userID string, amount float64, tenureMonths int, purpose string) (*model.Loan, error)
+	// Apply for a new loan under a product of the catalog
+	ApplyLoan(ctx context.Context, userID, productCode string, amount model.Money, tenureMonths int, purpose string) (*model.Loan, error)
+
+	// List the loan products open for new applications
+	ListLoanProducts(ctx context.Context) ([]model.LoanProduct, error)
 
 	// Get loan application status
 	GetLoanStatus(ctx context.Context, loanID string) (*model.Loan, error) // Get user's loan history
@@ -21,29 +27,155 @@ type LoanUseCase interface {
 	// Submit loan documents
 	SubmitLoanDocuments(ctx context.Context, loanID string, docs []model.Document) error
 
-	// Process loan application (for admin/system)
-	ProcessLoanApplication(ctx context.Context, loanID string, approve bool, interestRate float64) error
+	// List the applications waiting for a decision (for admin)
+	ListPendingApplications(ctx context.Context) ([]model.Loan, error)
+
+	// Search all loans one page at a time; pass the returned token to get the next page (for admin)
+	SearchLoans(ctx context.Context, filter model.LoanSearchFilter, sort model.LoanSort, pageToken string, pageSize int) ([]model.Loan, string, error)
+
+	// Process loan application, recording the reason in the status history (for admin/system)
+	ProcessLoanApplication(ctx context.Context, loanID string, approve bool, interestRate float64, reason string) error
+
+	// Reprice a loan whose offer has not been accepted yet (for admin)
+	SetInterestRate(ctx context.Context, loanID string, interestRate float64) (*model.Loan, error)
+
+	// Verify or reject a document submitted for a loan, recording the reviewer (for admin)
+	ReviewDocument(ctx context.Context, loanID, documentID string, review model.DocumentReview) (*model.Document, error)
+
+	// Accept the offer of an approved loan on the terms identified by termsHash, recording the
+	// client the borrower consented from (for the borrower)
+	AcceptLoanOffer(ctx context.Context, loanID, termsHash, ipAddress, userAgent string) (*model.LoanOfferAcceptance, error)
+
+	// Decline the offer of an approved loan (for the borrower)
+	DeclineLoanOffer(ctx context.Context, loanID, reason string) (*model.Loan, error)
+
+	// Disburse approved loan to a verified bank account of the borrower (for admin/system)
+	DisburseLoan(ctx context.Context, loanID string, disbursedAmount model.Money, bankAccountID string) (*model.DisbursementInstruction, error)
+
+	// Get the disbursement instructions of a loan
+	GetDisbursements(ctx context.Context, loanID string) ([]model.DisbursementInstruction, error)
+
+	// Get the tranches released for a loan
+	GetDisbursementTranches(ctx context.Context, loanID string) ([]model.Disbursement, error)
+
+	// Move a disbursement instruction along its lifecycle as the bank reports progress (for admin/system)
+	UpdateDisbursementStatus(ctx context.Context, instructionID string, status model.DisbursementStatus, bankReference, failureReason string) (*model.DisbursementInstruction, error)
+
+	// Get loan repayment schedule
+	GetRepaymentSchedule(ctx context.Context, loanID string) ([]model.Installment, error)
+
+	// Get the status change history of a loan
+	GetLoanStatusHistory(ctx context.Context, loanID string) ([]model.LoanStatusHistory, error)
 
-	// Disburse approved loan (for admin/system)
-	DisburseLoan(ctx context.Context, loanID string, disbursedAmount float64) error
+	// Record a repayment and allocate it across outstanding installments
+	RecordPayment(ctx context.Context, loanID string, amount model.Money, paymentMethod, reference string, paidAt time.Time) (*model.Payment, error)
+
+	// Quote the amount needed to close a loan early
+	GetPayoffQuote(ctx context.Context, loanID string) (*model.PayoffQuote, error)
+
+	// Settle a loan early with a payment against a valid payoff quote
+	SettleLoan(ctx context.Context, loanID, quoteID string, amount model.Money, paymentMethod, reference string, paidAt time.Time) (*model.Payment, error)
 }
 
 // LoanUseCaseImpl implements LoanUseCase interface
 type LoanUseCaseImpl struct {
-	loanRepo repo.LoanRepository
-	userRepo repo.UserRepository
+	loanRepo         repo.LoanRepository
+	userRepo         repo.UserRepository
+	installmentRepo  repo.InstallmentRepository
+	paymentRepo      repo.PaymentRepository
+	payoffQuoteRepo  repo.PayoffQuoteRepository
+	creditLimitRepo  repo.CreditLimitRepository
+	productRepo      repo.LoanProductRepository
+	bankAccountRepo  repo.BankAccountRepository
+	disbursementRepo repo.DisbursementRepository
+	allocationOrder  []model.PaymentComponent
+	payoffPolicy     PayoffPolicy
+	affordability    AffordabilityRule
+	scorer           CreditScorer
+	documentRules    []DocumentRule
+	applications     model.ApplicationPolicy
+}
+
+// LoanOption configures optional behaviour of the loan use case
+type LoanOption func(*LoanUseCaseImpl)
+
+// WithAllocationOrder sets the waterfall used to allocate payments within an installment
+func WithAllocationOrder(order []model.PaymentComponent) LoanOption {
+	return func(uc *LoanUseCaseImpl) {
+		uc.allocationOrder = order
+	}
+}
+
+// WithPayoffPolicy sets the pricing and validity of early payoff quotes
+func WithPayoffPolicy(policy PayoffPolicy) LoanOption {
+	return func(uc *LoanUseCaseImpl) {
+		uc.payoffPolicy = policy
+	}
+}
+
+// WithAffordabilityRule sets the debt-to-income check applied to new applications
+func WithAffordabilityRule(rule AffordabilityRule) LoanOption {
+	return func(uc *LoanUseCaseImpl) {
+		uc.affordability = rule
+	}
+}
+
+// WithCreditScorer sets the scorer used to grade new applications
+func WithCreditScorer(scorer CreditScorer) LoanOption {
+	return func(uc *LoanUseCaseImpl) {
+		uc.scorer = scorer
+	}
+}
+
+// WithDocumentRules sets the rules adding documents to the checklist of new applications
+func WithDocumentRules(rules []DocumentRule) LoanOption {
+	return func(uc *LoanUseCaseImpl) {
+		uc.documentRules = rules
+	}
+}
+
+// WithApplicationPolicy sets how many open and duplicate applications a user may submit
+func WithApplicationPolicy(policy model.ApplicationPolicy) LoanOption {
+	return func(uc *LoanUseCaseImpl) {
+		uc.applications = policy
+	}
 }
 
 // NewLoanUseCase creates a new loan use case instance
-func NewLoanUseCase(loanRepo repo.LoanRepository, userRepo repo.UserRepository) LoanUseCase {
-	return &LoanUseCaseImpl{
-		loanRepo: loanRepo,
-		userRepo: userRepo,
+func NewLoanUseCase(loanRepo repo.LoanRepository, userRepo repo.UserRepository, installmentRepo repo.InstallmentRepository, paymentRepo repo.PaymentRepository, payoffQuoteRepo repo.PayoffQuoteRepository, creditLimitRepo repo.CreditLimitRepository, productRepo repo.LoanProductRepository, bankAccountRepo repo.BankAccountRepository, disbursementRepo repo.DisbursementRepository, opts ...LoanOption) LoanUseCase {
+	uc := &LoanUseCaseImpl{
+		loanRepo:         loanRepo,
+		userRepo:         userRepo,
+		installmentRepo:  installmentRepo,
+		paymentRepo:      paymentRepo,
+		payoffQuoteRepo:  payoffQuoteRepo,
+		creditLimitRepo:  creditLimitRepo,
+		productRepo:      productRepo,
+		bankAccountRepo:  bankAccountRepo,
+		disbursementRepo: disbursementRepo,
+		allocationOrder:  DefaultAllocationOrder,
+		payoffPolicy:     DefaultPayoffPolicy,
+		affordability:    DefaultAffordabilityRule,
+		scorer:           NewRuleBasedCreditScorer(loanRepo),
+		documentRules:    DefaultDocumentRules,
+		applications:     DefaultApplicationPolicy,
+	}
+
+	for _, opt := range opts {
+		opt(uc)
 	}
+
+	return uc
+}
+
+// DefaultApplicationPolicy is used when no application policy is configured
+var DefaultApplicationPolicy = model.ApplicationPolicy{
+	MaxOpenApplications: 1,
+	DuplicateWindow:     5 * time.Minute,
 }
 
 // ApplyLoan handles the loan application process
-func (uc *LoanUseCaseImpl) ApplyLoan(ctx context.Context, userID string, amount float64, tenureMonths int, purpose string) (*model.Loan, error) {
+func (uc *LoanUseCaseImpl) ApplyLoan(ctx context.Context, userID, productCode string, amount model.Money, tenureMonths int, purpose string) (*model.Loan, error) {
 	// Validate user exists and is eligible
 	user, err := uc.userRepo.GetByID(ctx, userID)
 	if err != nil {
@@ -53,33 +185,117 @@ func (uc *LoanUseCaseImpl) ApplyLoan(ctx context.Context, userID string, amount
 		return nil, ErrUserNotFound
 	}
 
-	// Validate loan amount and tenure
-	if amount < 1000000 {
-		return nil, fmt.Errorf("loan amount must be at least 1,000,000")
+	// Validate loan amount and tenure against the product
+	product, err := uc.activeProduct(ctx, productCode)
+	if err != nil {
+		return nil, err
+	}
+	if amount < product.MinAmount || amount > product.MaxAmount {
+		return nil, NewValidationError(fmt.Sprintf("loan amount must be between %s and %s for product %s", product.MinAmount, product.MaxAmount, product.Code))
+	}
+	if !product.AllowsTenure(tenureMonths) {
+		return nil, NewValidationError(fmt.Sprintf("loan tenure must be one of %v months for product %s", product.TenureOptions, product.Code))
+	}
+
+	// Validate remaining credit limit for the requested tenor
+	limit, err := uc.creditLimitRepo.GetByUserAndTenure(ctx, userID, tenureMonths)
+	if err != nil {
+		return nil, err
+	}
+	if limit == nil {
+		return nil, NewValidationError(fmt.Sprintf("no credit limit available for %d month tenure", tenureMonths))
 	}
-	if tenureMonths < 6 || tenureMonths > 60 {
-		return nil, fmt.Errorf("loan tenure must be between 6 and 60 months")
+	if amount > limit.RemainingAmount() {
+		return nil, NewValidationError(fmt.Sprintf("loan amount exceeds remaining credit limit of %s for %d month tenure", limit.RemainingAmount(), tenureMonths))
+	}
+
+	// Validate the new payment against the user's debt-to-income ratio
+	if user.MonthlyIncome <= 0 {
+		return nil, NewValidationError("monthly income is required to assess affordability")
+	}
+	activeLoans, err := uc.loanRepo.GetUserLoansByStatus(ctx, userID, activeLoanStatuses...)
+	if err != nil {
+		return nil, err
+	}
+	existingPayments := existingMonthlyPayments(activeLoans)
+	estimate, err := calculateSchedule(product.InterestMethod, amount, uc.affordability.EstimateInterestRate, tenureMonths)
+	if err != nil {
+		return nil, err
+	}
+	estimatedPayment := model.Money(estimate.FirstPayment())
+	assessment := assessAffordability(uc.affordability, user.MonthlyIncome, existingPayments, estimatedPayment)
+	if assessment.exceeded && uc.affordability.RejectAboveMax {
+		return nil, &AffordabilityError{
+			MonthlyIncome:    user.MonthlyIncome,
+			ExistingPayments: existingPayments,
+			EstimatedPayment: estimatedPayment,
+			DTIPercent:       assessment.dtiPercent,
+			MaxDTIPercent:    uc.affordability.MaxDTIPercent,
+		}
 	}
 
 	// Create loan application
 	loan := &model.Loan{
-		UserID:       userID,
-		Amount:       amount,
-		TenureMonths: tenureMonths,
-		Purpose:      purpose,
-		Status:       model.LoanStatusPending,
+		UserID:               userID,
+		ProductCode:          product.Code,
+		InterestMethod:       product.InterestMethod,
+		Amount:               amount,
+		TenureMonths:         tenureMonths,
+		Purpose:              purpose,
+		Status:               model.LoanStatusPending,
+		Collectibility:       model.CollectibilityCurrent,
+		DTIRatio:             assessment.dtiPercent,
+		AffordabilityFlagged: assessment.exceeded,
 	}
 
-	if err := uc.loanRepo.Create(ctx, loan); err != nil {
+	// Score the application so analysts see its risk before it is decided
+	if err := uc.scoreLoan(ctx, user, loan); err != nil {
+		return nil, err
+	}
+
+	// Tell the applicant up front which documents to upload
+	loan.Documents = documentChecklist(product, uc.documentRules, amount, user.MonthlyIncome)
+
+	if err := uc.loanRepo.Create(ctx, loan, uc.applications); err != nil {
 		return nil, err
 	}
 
 	return loan, nil
 }
 
+// ListLoanProducts retrieves the active loan products
+func (uc *LoanUseCaseImpl) ListLoanProducts(ctx context.Context) ([]model.LoanProduct, error) {
+	return uc.productRepo.ListActive(ctx)
+}
+
+// activeProduct looks up a product that is open for new applications
+func (uc *LoanUseCaseImpl) activeProduct(ctx context.Context, code string) (*model.LoanProduct, error) {
+	if code == "" {
+		return nil, NewValidationError("product code is required")
+	}
+
+	product, err := uc.productRepo.GetByCode(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	if product == nil || !product.IsActive {
+		return nil, NewValidationError(fmt.Sprintf("loan product %s is not available", code))
+	}
+
+	return product, nil
+}
+
 // GetLoanStatus retrieves the current status of a loan application
 func (uc *LoanUseCaseImpl) GetLoanStatus(ctx context.Context, loanID string) (*model.Loan, error) {
-	return uc.loanRepo.GetByID(ctx, loanID)
+	loan, err := uc.loanRepo.GetByID(ctx, loanID)
+	if err != nil {
+		return nil, err
+	}
+	if loan == nil {
+		return nil, ErrLoanNotFound
+	}
+
+	return loan, nil
 }
 
 // GetLoanHistory retrieves the loan history for a user
@@ -98,11 +314,22 @@ func (uc *LoanUseCaseImpl) SubmitLoanDocuments(ctx context.Context, loanID strin
 	}
 
 	// Validate loan status
-	if loan.Status != model.LoanStatusPending && loan.Status != model.LoanStatusInReview {
-		return fmt.Errorf("cannot submit documents for loan in %s status", loan.Status)
+	if err := checkTransition(ctx, loan.Status, model.LoanStatusInReview); err != nil {
+		return err
 	}
 
-	// Add documents
+	submitted := make(map[model.DocumentType]bool, len(docs))
+	for _, doc := range docs {
+		if !knownDocumentTypes[doc.Type] {
+			return NewValidationError(fmt.Sprintf("unknown document type %q", doc.Type))
+		}
+		if submitted[doc.Type] {
+			return NewValidationError(fmt.Sprintf("document type %s submitted more than once", doc.Type))
+		}
+		submitted[doc.Type] = true
+	}
+
+	// Add documents, filling the checklist slots of their types
 	for _, doc := range docs {
 		doc.LoanID = loanID
 		doc.Status = model.DocumentStatusUploaded
@@ -113,11 +340,54 @@ func (uc *LoanUseCaseImpl) SubmitLoanDocuments(ctx context.Context, loanID strin
 	}
 
 	// Update loan status to in_review
-	return uc.loanRepo.UpdateLoanStatus(ctx, loanID, model.LoanStatusInReview)
+	return uc.loanRepo.UpdateLoanStatus(ctx, loanID, model.LoanStatusInReview, "documents submitted")
+}
+
+// ListPendingApplications retrieves the applications waiting for a decision, oldest first
+func (uc *LoanUseCaseImpl) ListPendingApplications(ctx context.Context) ([]model.Loan, error) {
+	return uc.loanRepo.GetLoansByStatus(ctx, model.LoanStatusPending, model.LoanStatusInReview)
 }
 
-// ProcessLoanApplication handles the loan approval/rejection process
-func (uc *LoanUseCaseImpl) ProcessLoanApplication(ctx context.Context, loanID string, approve bool, interestRate float64) error {
+// SearchLoans retrieves a page of loans matching filter. The returned token is empty on the last
+// page and is only valid for the same filter and sort.
+func (uc *LoanUseCaseImpl) SearchLoans(ctx context.Context, filter model.LoanSearchFilter, sort model.LoanSort, pageToken string, pageSize int) ([]model.Loan, string, error) {
+	if err := validateLoanSearch(filter, &sort); err != nil {
+		return nil, "", err
+	}
+	if pageSize < 1 {
+		pageSize = defaultSearchPageSize
+	}
+	if pageSize > maxSearchPageSize {
+		pageSize = maxSearchPageSize
+	}
+
+	fingerprint := searchFingerprint(filter, sort)
+	var after *model.LoanSearchKey
+	if pageToken != "" {
+		key, err := decodeLoanCursor(fingerprint, pageToken)
+		if err != nil {
+			return nil, "", err
+		}
+		after = key
+	}
+
+	// Fetch one extra loan to learn whether another page follows
+	loans, err := uc.loanRepo.SearchLoans(ctx, filter, sort, after, pageSize+1)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(loans) <= pageSize {
+		return loans, "", nil
+	}
+
+	loans = loans[:pageSize]
+	return loans, encodeLoanCursor(fingerprint, loans[pageSize-1].SearchKey()), nil
+}
+
+// ProcessLoanApplication handles the loan approval/rejection process. Rejections must give a
+// reason; approvals fall back to a generic one. An approved application is offered to the
+// applicant on the priced terms and only becomes an approved loan once they accept them.
+func (uc *LoanUseCaseImpl) ProcessLoanApplication(ctx context.Context, loanID string, approve bool, interestRate float64, reason string) error {
 	loan, err := uc.loanRepo.GetByID(ctx, loanID)
 	if err != nil {
 		return err
@@ -126,23 +396,381 @@ func (uc *LoanUseCaseImpl) ProcessLoanApplication(ctx context.Context, loanID st
 		return ErrLoanNotFound
 	}
 
+	reason = strings.TrimSpace(reason)
+	target := model.LoanStatusRejected
+	if approve {
+		target = model.LoanStatusOffered
+		if reason == "" {
+			reason = "application approved"
+		}
+	} else if reason == "" {
+		return NewValidationError("a reason is required to reject an application")
+	}
+	if err := checkTransition(ctx, loan.Status, target); err != nil {
+		return err
+	}
+
+	var schedule *interest.Schedule
 	if approve {
-		if interestRate <= 0 {
-			return fmt.Errorf("interest rate must be greater than 0")
+		// Applications submitted before scoring was introduced are scored on approval
+		if loan.ScoredAt == nil {
+			user, err := uc.userRepo.GetByID(ctx, loan.UserID)
+			if err != nil {
+				return err
+			}
+			if user == nil {
+				return ErrUserNotFound
+			}
+			if err := uc.scoreLoan(ctx, user, loan); err != nil {
+				return err
+			}
+		}
+		if !loan.RiskGrade.Approvable() {
+			return NewValidationError(fmt.Sprintf("applications with risk grade %s cannot be approved", loan.RiskGrade))
+		}
+
+		product, err := uc.loanProduct(ctx, loan)
+		if err != nil {
+			return err
+		}
+		if missing := unverifiedDocuments(loan, product); len(missing) > 0 {
+			return NewValidationError(fmt.Sprintf("documents must be verified before approval: %s", strings.Join(missing, ", ")))
+		}
+
+		schedule, err = uc.priceLoan(loan, product, interestRate)
+		if err != nil {
+			return err
+		}
+	}
+	loan.Status = target
+
+	if err := uc.loanRepo.UpdateLoan(ctx, loan, reason); err != nil {
+		return err
+	}
+
+	if !approve {
+		return nil
+	}
+
+	// Generate the indicative schedule; it is regenerated from the disbursement date later
+	return uc.installmentRepo.ReplaceForLoan(ctx, loan.ID, buildInstallmentSchedule(schedule, time.Now()))
+}
+
+// SetInterestRate changes the rate of an offered loan and regenerates its indicative schedule. The
+// applicant has to accept the new terms; once accepted they are fixed and the loan has to be
+// restructured instead.
+func (uc *LoanUseCaseImpl) SetInterestRate(ctx context.Context, loanID string, interestRate float64) (*model.Loan, error) {
+	loan, err := uc.loanRepo.GetByID(ctx, loanID)
+	if err != nil {
+		return nil, err
+	}
+	if loan == nil {
+		return nil, ErrLoanNotFound
+	}
+	if loan.Status != model.LoanStatusOffered {
+		return nil, NewValidationError(fmt.Sprintf("interest rate cannot be changed on a %s loan", loan.Status))
+	}
+
+	product, err := uc.loanProduct(ctx, loan)
+	if err != nil {
+		return nil, err
+	}
+	schedule, err := uc.priceLoan(loan, product, interestRate)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.loanRepo.UpdateLoan(ctx, loan, "interest rate changed"); err != nil {
+		return nil, err
+	}
+	if err := uc.installmentRepo.ReplaceForLoan(ctx, loan.ID, buildInstallmentSchedule(schedule, time.Now())); err != nil {
+		return nil, err
+	}
+
+	return loan, nil
+}
+
+// priceLoan checks interestRate against the loan's product and applies it to the loan together
+// with the resulting monthly payment. The schedule is returned for the caller to store.
+// The rate is rounded to the two decimals it is stored with first, so the loan is priced, and its
+// offer terms hashed, on the rate read back from the database.
+func (uc *LoanUseCaseImpl) priceLoan(loan *model.Loan, product *model.LoanProduct, interestRate float64) (*interest.Schedule, error) {
+	interestRate = roundPercent(interestRate)
+	if err := checkInterestRate(interestRate); err != nil {
+		return nil, err
+	}
+	if !product.AllowsInterestRate(interestRate) {
+		return nil, NewValidationError(fmt.Sprintf("interest rate must be between %.2f%% and %.2f%% for product %s", product.MinInterestRate, product.MaxInterestRate, product.Code))
+	}
+
+	schedule, err := calculateSchedule(loan.InterestMethod, loan.Amount, interestRate, loan.TenureMonths)
+	if err != nil {
+		return nil, err
+	}
+	loan.InterestRate = interestRate
+	loan.MonthlyPayment = model.Money(schedule.FirstPayment())
+	loan.EffectiveAnnualRate = schedule.EffectiveAnnualRate
+
+	return schedule, nil
+}
+
+// loanProduct returns the catalog product of a loan
+func (uc *LoanUseCaseImpl) loanProduct(ctx context.Context, loan *model.Loan) (*model.LoanProduct, error) {
+	return getLoanProduct(ctx, uc.productRepo, loan)
+}
+
+// getLoanProduct looks up the product a loan was booked under. Every loan has one, loans from
+// before the catalog included, so a missing product is an error.
+func getLoanProduct(ctx context.Context, productRepo repo.LoanProductRepository, loan *model.Loan) (*model.LoanProduct, error) {
+	product, err := productRepo.GetByCode(ctx, loan.ProductCode)
+	if err != nil {
+		return nil, err
+	}
+	if product == nil {
+		return nil, fmt.Errorf("loan product %q of loan %s not found", loan.ProductCode, loan.ID)
+	}
+	return product, nil
+}
+
+// unverifiedDocuments lists the documents required by the product or on the loan's checklist
+// that have not been verified for the loan yet
+func unverifiedDocuments(loan *model.Loan, product *model.LoanProduct) []string {
+	required := append(append([]model.DocumentType{}, product.RequiredDocuments...), loan.RequiredDocumentTypes()...)
+
+	seen := make(map[model.DocumentType]bool)
+	var missing []string
+	for _, docType := range required {
+		if !seen[docType] && !loan.HasVerifiedDocument(docType) {
+			missing = append(missing, string(docType))
+		}
+		seen[docType] = true
+	}
+	return missing
+}
+
+// ReviewDocument records an analyst's decision on a document submitted for a loan. Rejecting a
+// document sends a loan under review back to the applicant, who is told which documents to upload again.
+func (uc *LoanUseCaseImpl) ReviewDocument(ctx context.Context, loanID, documentID string, review model.DocumentReview) (*model.Document, error) {
+	review.Note = strings.TrimSpace(review.Note)
+	switch review.Status {
+	case model.DocumentStatusVerified:
+		if review.ReasonCode != "" && !review.ReasonCode.IsKnown() {
+			return nil, NewValidationError(fmt.Sprintf("unknown reason code %q", review.ReasonCode))
+		}
+	case model.DocumentStatusRejected:
+		if review.ReasonCode == "" {
+			return nil, NewValidationError("a reason code is required to reject a document")
+		}
+		if !review.ReasonCode.IsKnown() {
+			return nil, NewValidationError(fmt.Sprintf("unknown reason code %q", review.ReasonCode))
+		}
+		if review.ReasonCode == model.DocumentReasonOther && review.Note == "" {
+			return nil, NewValidationError("a note is required when the reason code is other")
+		}
+	default:
+		return nil, NewValidationError(fmt.Sprintf("document status must be %s or %s", model.DocumentStatusVerified, model.DocumentStatusRejected))
+	}
+
+	doc, err := uc.loanRepo.GetDocumentByID(ctx, documentID)
+	if err != nil {
+		return nil, err
+	}
+	if doc == nil || doc.LoanID != loanID {
+		return nil, ErrDocumentNotFound
+	}
+	if doc.Status == model.DocumentStatusRequired {
+		return nil, NewValidationError("document has not been uploaded yet")
+	}
+
+	loan, err := uc.loanRepo.GetByID(ctx, loanID)
+	if err != nil {
+		return nil, err
+	}
+	if loan == nil {
+		return nil, ErrLoanNotFound
+	}
+
+	target, reason := loan.Status, ""
+	switch loan.Status {
+	case model.LoanStatusInReview:
+		if review.Status == model.DocumentStatusRejected {
+			target = model.LoanStatusDocumentsNeeded
+			reason = fmt.Sprintf("document %s rejected: %s", doc.Type, review.ReasonCode)
+		}
+	case model.LoanStatusPending, model.LoanStatusDocumentsNeeded:
+	default:
+		return nil, NewValidationError(fmt.Sprintf("documents of a %s loan can no longer be reviewed", loan.Status))
+	}
+	if err := checkTransition(ctx, loan.Status, target); err != nil {
+		return nil, err
+	}
+
+	doc.Status = review.Status
+	doc.ReasonCode = review.ReasonCode
+	doc.ReviewNote = review.Note
+	doc.ReviewedBy = model.ActorFromContext(ctx).ID
+	doc.ReviewedAt = timePtr(time.Now())
+
+	if err := uc.loanRepo.ReviewDocument(ctx, doc, target, reason); err != nil {
+		return nil, err
+	}
+
+	return doc, nil
+}
+
+// scoreLoan grades the application with the configured scorer and records the result on the loan
+func (uc *LoanUseCaseImpl) scoreLoan(ctx context.Context, user *model.User, loan *model.Loan) error {
+	score, err := uc.scorer.Score(ctx, user, loan)
+	if err != nil {
+		return fmt.Errorf("failed to score loan application: %v", err)
+	}
+	applyCreditScore(loan, score, time.Now())
+	return nil
+}
+
+// DisburseLoan instructs the bank to transfer a tranche of the funds to the borrower's verified
+// account. The amount is reserved on the credit limit straight away, but the loan only moves on
+// once the bank reports the transfer as succeeded. Tranches may be released one at a time until
+// they add up to the approved amount; each one needs the borrower to have accepted the offer on
+// the loan's current terms.
+func (uc *LoanUseCaseImpl) DisburseLoan(ctx context.Context, loanID string, disbursedAmount model.Money, bankAccountID string) (*model.DisbursementInstruction, error) {
+	loan, err := uc.loanRepo.GetByID(ctx, loanID)
+	if err != nil {
+		return nil, err
+	}
+	if loan == nil {
+		return nil, ErrLoanNotFound
+	}
+
+	// Loans that were already fully released stay closed to new tranches
+	if loan.Status == model.LoanStatusDisbursed {
+		return nil, &model.StatusTransitionError{From: loan.Status, To: model.LoanStatusDisbursed}
+	}
+	if err := checkTransition(ctx, loan.Status, model.LoanStatusDisbursed); err != nil {
+		return nil, err
+	}
+	if err := uc.checkOfferAccepted(ctx, loan); err != nil {
+		return nil, err
+	}
+
+	if disbursedAmount <= 0 {
+		return nil, NewValidationError("invalid disbursement amount")
+	}
+	if disbursedAmount > loan.UndisbursedAmount() {
+		return nil, NewValidationError(fmt.Sprintf("disbursement amount exceeds the undisbursed amount of %s", loan.UndisbursedAmount()))
+	}
+
+	account, err := uc.bankAccountRepo.GetByID(ctx, bankAccountID)
+	if err != nil {
+		return nil, err
+	}
+	if account == nil {
+		return nil, ErrBankAccountNotFound
+	}
+	if account.UserID != loan.UserID {
+		return nil, NewValidationError("bank account does not belong to the borrower")
+	}
+	if !account.IsVerified() {
+		return nil, NewValidationError("bank account must be verified before disbursement")
+	}
+
+	// Validate the terms now rather than when the bank reports back
+	if _, err := calculateSchedule(loan.InterestMethod, loan.DisbursedAmount+disbursedAmount, loan.InterestRate, loan.TenureMonths); err != nil {
+		return nil, err
+	}
+
+	instruction := &model.DisbursementInstruction{
+		LoanID:        loan.ID,
+		BankAccountID: account.ID,
+		Amount:        disbursedAmount,
+		Status:        model.DisbursementStatusPending,
+	}
+	if err := uc.disbursementRepo.Create(ctx, instruction, loan); err != nil {
+		switch {
+		case errors.Is(err, repo.ErrCreditLimitExceeded):
+			return nil, NewValidationError("disbursement amount exceeds remaining credit limit")
+		case errors.Is(err, repo.ErrDisbursementInProgress):
+			return nil, NewConflictError("loan already has a disbursement in progress")
 		}
-		loan.Status = model.LoanStatusApproved
-		loan.InterestRate = interestRate
-		loan.MonthlyPayment = calculateMonthlyPayment(loan.Amount, interestRate, loan.TenureMonths)
-	} else {
-		loan.Status = model.LoanStatusRejected
+		return nil, err
 	}
 
-	return uc.loanRepo.UpdateLoan(ctx, loan)
+	return instruction, nil
 }
 
-// DisburseLoan handles the loan disbursement process
-func (uc *LoanUseCaseImpl) DisburseLoan(ctx context.Context, loanID string, disbursedAmount float64) error {
+// GetDisbursements retrieves the disbursement instructions of a loan
+func (uc *LoanUseCaseImpl) GetDisbursements(ctx context.Context, loanID string) ([]model.DisbursementInstruction, error) {
 	loan, err := uc.loanRepo.GetByID(ctx, loanID)
+	if err != nil {
+		return nil, err
+	}
+	if loan == nil {
+		return nil, ErrLoanNotFound
+	}
+
+	return uc.disbursementRepo.GetByLoanID(ctx, loanID)
+}
+
+// UpdateDisbursementStatus records progress reported by the bank. A sent instruction keeps the
+// bank's transfer reference and a failed one needs the reason the bank gave. A succeeded
+// instruction disburses the loan; a failed one releases the credit limit it reserved.
+func (uc *LoanUseCaseImpl) UpdateDisbursementStatus(ctx context.Context, instructionID string, status model.DisbursementStatus, bankReference, failureReason string) (*model.DisbursementInstruction, error) {
+	bankReference = strings.TrimSpace(bankReference)
+	failureReason = strings.TrimSpace(failureReason)
+
+	instruction, err := uc.disbursementRepo.GetByID(ctx, instructionID)
+	if err != nil {
+		return nil, err
+	}
+	if instruction == nil {
+		return nil, ErrDisbursementNotFound
+	}
+
+	from := instruction.Status
+	if err := model.CheckDisbursementTransition(from, status); err != nil {
+		return nil, NewValidationError(err.Error())
+	}
+	if status == model.DisbursementStatusFailed && failureReason == "" {
+		return nil, NewValidationError("a failure reason is required")
+	}
+
+	now := time.Now()
+	instruction.Status = status
+	if bankReference != "" {
+		instruction.BankReference = bankReference
+	}
+	if status == model.DisbursementStatusSent {
+		instruction.SentAt = &now
+	}
+	if status.IsFinal() {
+		instruction.CompletedAt = &now
+		instruction.FailureReason = failureReason
+	}
+
+	switch status {
+	case model.DisbursementStatusSucceeded:
+		err = uc.completeDisbursement(ctx, instruction, from, now)
+	case model.DisbursementStatusFailed:
+		err = uc.disbursementRepo.Fail(ctx, instruction, from)
+	default:
+		err = uc.disbursementRepo.UpdateStatus(ctx, instruction, from)
+	}
+	if err != nil {
+		if errors.Is(err, repo.ErrDisbursementStatusChanged) {
+			return nil, NewConflictError("disbursement instruction has changed, please retry")
+		}
+		return nil, err
+	}
+
+	return instruction, nil
+}
+
+// completeDisbursement releases the tranche of a succeeded instruction. The loan stays partially
+// disbursed until the tranches add up to its amount. The first tranche starts the schedule; later
+// ones reschedule the installments not yet due over the rest of the tenure. The loan keeps the
+// terms the borrower accepted; what each tranche actually costs is on its installments.
+func (uc *LoanUseCaseImpl) completeDisbursement(ctx context.Context, instruction *model.DisbursementInstruction, from model.DisbursementStatus, completedAt time.Time) error {
+	loan, err := uc.loanRepo.GetByID(ctx, instruction.LoanID)
 	if err != nil {
 		return err
 	}
@@ -150,37 +778,285 @@ func (uc *LoanUseCaseImpl) DisburseLoan(ctx context.Context, loanID string, disb
 		return ErrLoanNotFound
 	}
 
-	if loan.Status != model.LoanStatusApproved {
-		return fmt.Errorf("cannot disburse loan in %s status", loan.Status)
+	var installments []model.Installment
+	if loan.DisbursedAmount > 0 {
+		if installments, err = uc.installmentRepo.GetByLoanID(ctx, loan.ID); err != nil {
+			return err
+		}
+	}
+
+	plan := planTranche(loan, installments, instruction.Amount, completedAt)
+	schedule, err := calculateSchedule(loan.InterestMethod, plan.principal, loan.InterestRate, plan.tenureMonths)
+	if err != nil {
+		return err
+	}
+
+	loan.Status = model.LoanStatusPartiallyDisbursed
+	if loan.DisbursedAmount+instruction.Amount >= loan.Amount {
+		loan.Status = model.LoanStatusDisbursed
+	}
+
+	tranche := &model.Disbursement{Amount: instruction.Amount, DisbursedAt: completedAt}
+	return uc.disbursementRepo.Complete(ctx, instruction, from, tranche, loan, continueInstallmentSchedule(schedule, plan.start, plan.firstNumber))
+}
+
+// GetDisbursementTranches retrieves the tranches released for a loan
+func (uc *LoanUseCaseImpl) GetDisbursementTranches(ctx context.Context, loanID string) ([]model.Disbursement, error) {
+	loan, err := uc.loanRepo.GetByID(ctx, loanID)
+	if err != nil {
+		return nil, err
+	}
+	if loan == nil {
+		return nil, ErrLoanNotFound
+	}
+
+	return uc.disbursementRepo.GetTranches(ctx, loanID)
+}
+
+// GetRepaymentSchedule retrieves the repayment schedule of a loan
+func (uc *LoanUseCaseImpl) GetRepaymentSchedule(ctx context.Context, loanID string) ([]model.Installment, error) {
+	loan, err := uc.loanRepo.GetByID(ctx, loanID)
+	if err != nil {
+		return nil, err
+	}
+	if loan == nil {
+		return nil, ErrLoanNotFound
 	}
+	if err := authorizeLoanAccess(ctx, loan); err != nil {
+		return nil, err
+	}
+
+	return uc.installmentRepo.GetByLoanID(ctx, loanID)
+}
 
-	if disbursedAmount <= 0 || disbursedAmount > loan.Amount {
-		return fmt.Errorf("invalid disbursement amount")
+// GetLoanStatusHistory retrieves the status changes of a loan
+func (uc *LoanUseCaseImpl) GetLoanStatusHistory(ctx context.Context, loanID string) ([]model.LoanStatusHistory, error) {
+	loan, err := uc.loanRepo.GetByID(ctx, loanID)
+	if err != nil {
+		return nil, err
+	}
+	if loan == nil {
+		return nil, ErrLoanNotFound
+	}
+	if err := authorizeLoanAccess(ctx, loan); err != nil {
+		return nil, err
+	}
+
+	return uc.loanRepo.GetStatusHistory(ctx, loanID)
+}
+
+// RecordPayment posts a repayment against a loan and settles installments oldest first
+func (uc *LoanUseCaseImpl) RecordPayment(ctx context.Context, loanID string, amount model.Money, paymentMethod, reference string, paidAt time.Time) (*model.Payment, error) {
+	if amount <= 0 {
+		return nil, NewValidationError("payment amount must be greater than 0")
+	}
+	if paymentMethod == "" {
+		return nil, NewValidationError("payment method is required")
+	}
+	if paidAt.IsZero() {
+		paidAt = time.Now()
+	}
+
+	loan, err := uc.loanRepo.GetByID(ctx, loanID)
+	if err != nil {
+		return nil, err
+	}
+	if loan == nil {
+		return nil, ErrLoanNotFound
+	}
+
+	if !isRepayable(loan.Status) {
+		return nil, &model.StatusTransitionError{From: loan.Status, To: model.LoanStatusPaidOff}
+	}
+
+	installments, err := uc.installmentRepo.GetByLoanID(ctx, loanID)
+	if err != nil {
+		return nil, err
+	}
+
+	var outstanding model.Money
+	for _, inst := range installments {
+		if inst.IsOpen() {
+			outstanding += inst.OutstandingAmount()
+		}
+	}
+	if amount > outstanding {
+		return nil, NewValidationError(fmt.Sprintf("payment amount exceeds outstanding amount of %s", outstanding))
+	}
+
+	allocations, touched, _ := allocatePayment(amount, installments, uc.allocationOrder, paidAt)
+
+	previousBalance := loan.OutstandingBalance
+	for _, alloc := range allocations {
+		if alloc.Component == model.PaymentComponentPrincipal {
+			loan.OutstandingBalance -= alloc.Amount
+		}
+	}
+	if loan.OutstandingBalance <= 0 {
+		loan.OutstandingBalance = 0
+		loan.Status = model.LoanStatusPaidOff
+	}
+
+	payment := &model.Payment{
+		Amount:        amount,
+		PaymentMethod: paymentMethod,
+		Reference:     reference,
+		PaidAt:        paidAt,
+		Allocations:   allocations,
+	}
+
+	if err := uc.paymentRepo.Create(ctx, payment, touched, loan, previousBalance); err != nil {
+		if errors.Is(err, repo.ErrDuplicatePaymentReference) {
+			return nil, NewConflictError(err.Error())
+		}
+		return nil, err
+	}
+
+	return payment, nil
+}
+
+// GetPayoffQuote prices the early settlement of a loan as of now, charging the early termination
+// fee of the loan's product, and stores the quote
+func (uc *LoanUseCaseImpl) GetPayoffQuote(ctx context.Context, loanID string) (*model.PayoffQuote, error) {
+	loan, err := uc.loanRepo.GetByID(ctx, loanID)
+	if err != nil {
+		return nil, err
+	}
+	if loan == nil {
+		return nil, ErrLoanNotFound
+	}
+	if err := authorizeLoanAccess(ctx, loan); err != nil {
+		return nil, err
+	}
+
+	if !isRepayable(loan.Status) {
+		return nil, &model.StatusTransitionError{From: loan.Status, To: model.LoanStatusPaidOff}
+	}
+
+	installments, err := uc.installmentRepo.GetByLoanID(ctx, loanID)
+	if err != nil {
+		return nil, err
+	}
+
+	product, err := uc.loanProduct(ctx, loan)
+	if err != nil {
+		return nil, err
 	}
 
 	now := time.Now()
-	loan.Status = model.LoanStatusDisbursed
-	loan.DisbursedAmount = disbursedAmount
-	loan.DisbursedAt = &now
+	breakdown := computePayoff(installments, now, now)
+	fee := breakdown.principal.Percent(product.EarlyTerminationFeePercent)
 
-	return uc.loanRepo.UpdateLoan(ctx, loan)
+	quote := &model.PayoffQuote{
+		LoanID:               loanID,
+		OutstandingPrincipal: breakdown.principal,
+		AccruedInterest:      breakdown.interest,
+		OutstandingFees:      breakdown.fees,
+		EarlyTerminationFee:  fee,
+		TotalAmount:          breakdown.principal + breakdown.interest + breakdown.fees + fee,
+		Status:               model.PayoffQuoteStatusActive,
+		QuotedAt:             now,
+		ExpiresAt:            now.Add(uc.payoffPolicy.QuoteValidity),
+	}
+
+	if err := uc.payoffQuoteRepo.Create(ctx, quote); err != nil {
+		return nil, err
+	}
+
+	return quote, nil
 }
 
-// Helper function to create Time pointer
-func timePtr(t time.Time) *time.Time {
-	return &t
+// SettleLoan closes a loan with a payment for the full amount of an unexpired payoff quote.
+// The quote is rejected if the loan was paid or charged after it was issued.
+func (uc *LoanUseCaseImpl) SettleLoan(ctx context.Context, loanID, quoteID string, amount model.Money, paymentMethod, reference string, paidAt time.Time) (*model.Payment, error) {
+	if paymentMethod == "" {
+		return nil, NewValidationError("payment method is required")
+	}
+	if paidAt.IsZero() {
+		paidAt = time.Now()
+	}
+
+	quote, err := uc.payoffQuoteRepo.GetByID(ctx, quoteID)
+	if err != nil {
+		return nil, err
+	}
+	if quote == nil || quote.LoanID != loanID {
+		return nil, ErrPayoffQuoteNotFound
+	}
+	if quote.Status != model.PayoffQuoteStatusActive {
+		return nil, NewConflictError("payoff quote has already been settled")
+	}
+	if quote.IsExpired(time.Now()) {
+		return nil, NewValidationError("payoff quote has expired")
+	}
+	if amount != quote.TotalAmount {
+		return nil, NewValidationError(fmt.Sprintf("settlement amount must equal the quoted amount of %s", quote.TotalAmount))
+	}
+
+	loan, err := uc.loanRepo.GetByID(ctx, loanID)
+	if err != nil {
+		return nil, err
+	}
+	if loan == nil {
+		return nil, ErrLoanNotFound
+	}
+
+	if !isRepayable(loan.Status) {
+		return nil, &model.StatusTransitionError{From: loan.Status, To: model.LoanStatusPaidOff}
+	}
+
+	installments, err := uc.installmentRepo.GetByLoanID(ctx, loanID)
+	if err != nil {
+		return nil, err
+	}
+
+	breakdown := computePayoff(installments, quote.QuotedAt, paidAt)
+	if breakdown.principal != quote.OutstandingPrincipal ||
+		breakdown.interest != quote.AccruedInterest ||
+		breakdown.fees != quote.OutstandingFees {
+		return nil, NewConflictError("loan has changed since the payoff quote was issued, please request a new quote")
+	}
+
+	previousBalance := loan.OutstandingBalance
+	loan.OutstandingBalance = 0
+	loan.Status = model.LoanStatusPaidOff
+
+	payment := &model.Payment{
+		Amount:        quote.TotalAmount,
+		PaymentMethod: paymentMethod,
+		Reference:     reference,
+		PaidAt:        paidAt,
+		Allocations:   breakdown.allocations,
+	}
+
+	if err := uc.payoffQuoteRepo.Settle(ctx, quote, payment, breakdown.installments, loan, previousBalance); err != nil {
+		if errors.Is(err, repo.ErrDuplicatePaymentReference) || errors.Is(err, repo.ErrPayoffQuoteAlreadySettled) {
+			return nil, NewConflictError(err.Error())
+		}
+		return nil, err
+	}
+
+	return payment, nil
 }
 
-// Helper function to calculate monthly payment
-func calculateMonthlyPayment(principal, interestRate float64, tenureMonths int) float64 {
-	monthlyRate := interestRate / 12 / 100
+// checkTransition validates a status change for the actor in ctx before any side effects are applied.
+// The repository enforces the same rule again when the status is written.
+func checkTransition(ctx context.Context, from, to model.LoanStatus) error {
+	return model.CheckLoanTransition(from, to, model.ActorFromContext(ctx).Role)
+}
 
-	// Using the PMT formula: PMT = P * (r * (1 + r)^n) / ((1 + r)^n - 1)
-	temp := (1 + monthlyRate)
-	factor := temp
-	for i := 1; i < tenureMonths; i++ {
-		factor *= temp
+// isRepayable reports whether a loan in the given status accepts repayments, which are the
+// statuses a loan can be paid off from
+func isRepayable(status model.LoanStatus) bool {
+	switch status {
+	case model.LoanStatusPartiallyDisbursed, model.LoanStatusDisbursed, model.LoanStatusRestructured, model.LoanStatusDefaulted:
+		return true
+	default:
+		return false
 	}
+}
 
-	return principal * (monthlyRate * factor) / (factor - 1)
+// Helper function to create Time pointer
+func timePtr(t time.Time) *time.Time {
+	return &t
 }
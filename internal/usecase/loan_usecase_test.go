@@ -0,0 +1,631 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/edosulai/pt-xyz-multifinance/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockLoanRepository struct {
+	mock.Mock
+}
+
+func (m *MockLoanRepository) Create(ctx context.Context, loan *model.Loan, policy model.ApplicationPolicy) error {
+	args := m.Called(ctx, loan, policy)
+	return args.Error(0)
+}
+
+func (m *MockLoanRepository) GetByID(ctx context.Context, id string) (*model.Loan, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Loan), args.Error(1)
+}
+
+func (m *MockLoanRepository) GetUserLoans(ctx context.Context, userID string, page, pageSize int) ([]model.Loan, int64, error) {
+	args := m.Called(ctx, userID, page, pageSize)
+	return args.Get(0).([]model.Loan), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockLoanRepository) UpdateLoanStatus(ctx context.Context, id string, status model.LoanStatus, reason string) error {
+	args := m.Called(ctx, id, status, reason)
+	return args.Error(0)
+}
+
+func (m *MockLoanRepository) UpdateLoan(ctx context.Context, loan *model.Loan, reason string) error {
+	args := m.Called(ctx, loan, reason)
+	return args.Error(0)
+}
+
+func (m *MockLoanRepository) AcceptOffer(ctx context.Context, acceptance *model.LoanOfferAcceptance, loanUpdatedAt time.Time, reason string) error {
+	args := m.Called(ctx, acceptance, loanUpdatedAt, reason)
+	return args.Error(0)
+}
+
+func (m *MockLoanRepository) GetOfferAcceptance(ctx context.Context, loanID string) (*model.LoanOfferAcceptance, error) {
+	args := m.Called(ctx, loanID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.LoanOfferAcceptance), args.Error(1)
+}
+
+func (m *MockLoanRepository) GetStatusHistory(ctx context.Context, loanID string) ([]model.LoanStatusHistory, error) {
+	args := m.Called(ctx, loanID)
+	return args.Get(0).([]model.LoanStatusHistory), args.Error(1)
+}
+
+func (m *MockLoanRepository) AddDocument(ctx context.Context, doc *model.Document) error {
+	args := m.Called(ctx, doc)
+	return args.Error(0)
+}
+
+func (m *MockLoanRepository) ReviewDocument(ctx context.Context, doc *model.Document, loanStatus model.LoanStatus, reason string) error {
+	args := m.Called(ctx, doc, loanStatus, reason)
+	return args.Error(0)
+}
+
+func (m *MockLoanRepository) GetDocumentByID(ctx context.Context, id string) (*model.Document, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Document), args.Error(1)
+}
+
+func (m *MockLoanRepository) GetDocumentsByLoanID(ctx context.Context, loanID string) ([]model.Document, error) {
+	args := m.Called(ctx, loanID)
+	return args.Get(0).([]model.Document), args.Error(1)
+}
+
+func (m *MockLoanRepository) GetLoansByStatus(ctx context.Context, statuses ...model.LoanStatus) ([]model.Loan, error) {
+	args := m.Called(ctx, statuses)
+	return args.Get(0).([]model.Loan), args.Error(1)
+}
+
+func (m *MockLoanRepository) SearchLoans(ctx context.Context, filter model.LoanSearchFilter, sort model.LoanSort, after *model.LoanSearchKey, limit int) ([]model.Loan, error) {
+	args := m.Called(ctx, filter, sort, after, limit)
+	return args.Get(0).([]model.Loan), args.Error(1)
+}
+
+func (m *MockLoanRepository) GetUserLoansByStatus(ctx context.Context, userID string, statuses ...model.LoanStatus) ([]model.Loan, error) {
+	args := m.Called(ctx, userID, statuses)
+	return args.Get(0).([]model.Loan), args.Error(1)
+}
+
+func (m *MockLoanRepository) UpdateDelinquency(ctx context.Context, loan *model.Loan, record *model.DelinquencyRecord) error {
+	args := m.Called(ctx, loan, record)
+	return args.Error(0)
+}
+
+type MockLoanProductRepository struct {
+	mock.Mock
+}
+
+func (m *MockLoanProductRepository) GetByCode(ctx context.Context, code string) (*model.LoanProduct, error) {
+	args := m.Called(ctx, code)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.LoanProduct), args.Error(1)
+}
+
+func (m *MockLoanProductRepository) ListActive(ctx context.Context) ([]model.LoanProduct, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]model.LoanProduct), args.Error(1)
+}
+
+// personalLoanProduct mirrors the product seeded by the loan products migration
+func personalLoanProduct() *model.LoanProduct {
+	return &model.LoanProduct{
+		Code:            "PERSONAL",
+		MinAmount:       model.NewMoney(1000000),
+		MaxAmount:       model.NewMoney(500000000),
+		TenureOptions:   []int{6, 12, 18, 24, 36, 48, 60},
+		InterestMethod:  model.InterestMethodAnnuity,
+		MinInterestRate: 8,
+		MaxInterestRate: 30,
+		IsActive:        true,
+	}
+}
+
+func newProductRepo(ctx context.Context, products ...*model.LoanProduct) *MockLoanProductRepository {
+	productRepo := new(MockLoanProductRepository)
+	for _, product := range products {
+		productRepo.On("GetByCode", ctx, product.Code).Return(product, nil)
+	}
+	return productRepo
+}
+
+func TestApplyLoan_Product(t *testing.T) {
+	ctx := context.Background()
+	user := &model.User{ID: "user-1", MonthlyIncome: model.NewMoney(10000000)}
+	inactive := personalLoanProduct()
+	inactive.Code, inactive.IsActive = "RETIRED", false
+
+	tests := []struct {
+		name    string
+		code    string
+		amount  model.Money
+		tenure  int
+		message string
+	}{
+		{"missing product code", "", model.NewMoney(5000000), 12, "product code is required"},
+		{"unknown product", "UNKNOWN", model.NewMoney(5000000), 12, "loan product UNKNOWN is not available"},
+		{"inactive product", "RETIRED", model.NewMoney(5000000), 12, "loan product RETIRED is not available"},
+		{"amount below minimum", "PERSONAL", model.NewMoney(500000), 12, "loan amount must be between"},
+		{"amount above maximum", "PERSONAL", model.NewMoney(600000000), 12, "loan amount must be between"},
+		{"tenure not offered", "PERSONAL", model.NewMoney(5000000), 10, "loan tenure must be one of [6 12 18 24 36 48 60] months"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userRepo := new(MockUserRepository)
+			userRepo.On("GetByID", ctx, "user-1").Return(user, nil)
+			productRepo := newProductRepo(ctx, personalLoanProduct(), inactive)
+			productRepo.On("GetByCode", ctx, "UNKNOWN").Return(nil, nil)
+
+			uc := NewLoanUseCase(nil, userRepo, nil, nil, nil, nil, productRepo, nil, nil)
+			_, err := uc.ApplyLoan(ctx, "user-1", tt.code, tt.amount, tt.tenure, "renovation")
+
+			assert.ErrorAs(t, err, &ValidationError{})
+			assert.Contains(t, err.Error(), tt.message)
+		})
+	}
+}
+
+func TestApplyLoan_ApplicationPolicy(t *testing.T) {
+	ctx := context.Background()
+	user := &model.User{ID: "user-1", MonthlyIncome: model.NewMoney(10000000)}
+	limit := &model.CreditLimit{TenureMonths: 12, LimitAmount: model.NewMoney(50000000)}
+	policy := model.ApplicationPolicy{MaxOpenApplications: 2, DuplicateWindow: time.Minute}
+
+	tests := []struct {
+		name      string
+		createErr error
+	}{
+		{"duplicate application", &model.DuplicateApplicationError{LoanID: "loan-1"}},
+		{"too many open applications", &model.OpenApplicationLimitError{Max: 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loanRepo := new(MockLoanRepository)
+			userRepo := new(MockUserRepository)
+			limitRepo := new(MockCreditLimitRepository)
+			userRepo.On("GetByID", ctx, "user-1").Return(user, nil)
+			limitRepo.On("GetByUserAndTenure", ctx, "user-1", 12).Return(limit, nil)
+			loanRepo.On("GetUserLoansByStatus", ctx, "user-1", activeLoanStatuses).Return([]model.Loan{}, nil)
+			loanRepo.On("GetUserLoans", ctx, "user-1", 1, creditHistoryPageSize).Return([]model.Loan{}, int64(0), nil)
+			loanRepo.On("Create", ctx, mock.AnythingOfType("*model.Loan"), policy).Return(tt.createErr)
+
+			uc := NewLoanUseCase(loanRepo, userRepo, nil, nil, nil, limitRepo, newProductRepo(ctx, personalLoanProduct()), nil, nil, WithApplicationPolicy(policy))
+			_, err := uc.ApplyLoan(ctx, "user-1", "PERSONAL", model.NewMoney(5000000), 12, "renovation")
+
+			assert.ErrorIs(t, err, tt.createErr)
+			loanRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestProcessLoanApplication_ProductRate(t *testing.T) {
+	ctx := model.ContextWithActor(context.Background(), model.Actor{ID: "admin-1", Role: model.ActorRoleAdmin})
+	loanRepo := new(MockLoanRepository)
+	loanRepo.On("GetByID", ctx, "loan-1").Return(&model.Loan{
+		ID: "loan-1", ProductCode: "PERSONAL", Status: model.LoanStatusInReview, RiskGrade: model.RiskGradeB, ScoredAt: timePtr(time.Now()),
+	}, nil)
+
+	uc := NewLoanUseCase(loanRepo, nil, nil, nil, nil, nil, newProductRepo(ctx, personalLoanProduct()), nil, nil)
+	err := uc.ProcessLoanApplication(ctx, "loan-1", true, 35, "")
+
+	assert.ErrorAs(t, err, &ValidationError{})
+	assert.Contains(t, err.Error(), "between 8.00% and 30.00%")
+	loanRepo.AssertNotCalled(t, "UpdateLoan", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestProcessLoanApplication_Rejection(t *testing.T) {
+	ctx := model.ContextWithActor(context.Background(), model.Actor{ID: "admin-1", Role: model.ActorRoleAdmin})
+
+	t.Run("reason required", func(t *testing.T) {
+		loanRepo := new(MockLoanRepository)
+		loanRepo.On("GetByID", ctx, "loan-1").Return(&model.Loan{ID: "loan-1", Status: model.LoanStatusInReview}, nil)
+
+		err := NewLoanUseCase(loanRepo, nil, nil, nil, nil, nil, nil, nil, nil).ProcessLoanApplication(ctx, "loan-1", false, 0, "  ")
+
+		assert.ErrorAs(t, err, &ValidationError{})
+		loanRepo.AssertNotCalled(t, "UpdateLoan", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("reason recorded", func(t *testing.T) {
+		loan := &model.Loan{ID: "loan-1", Status: model.LoanStatusInReview}
+		loanRepo := new(MockLoanRepository)
+		loanRepo.On("GetByID", ctx, "loan-1").Return(loan, nil)
+		loanRepo.On("UpdateLoan", ctx, loan, "income could not be verified").Return(nil)
+
+		err := NewLoanUseCase(loanRepo, nil, nil, nil, nil, nil, nil, nil, nil).ProcessLoanApplication(ctx, "loan-1", false, 0, "income could not be verified")
+
+		assert.NoError(t, err)
+		assert.Equal(t, model.LoanStatusRejected, loan.Status)
+		loanRepo.AssertExpectations(t)
+	})
+}
+
+func TestSetInterestRate(t *testing.T) {
+	ctx := model.ContextWithActor(context.Background(), model.Actor{ID: "admin-1", Role: model.ActorRoleAdmin})
+
+	t.Run("offered loan is repriced", func(t *testing.T) {
+		loan := &model.Loan{
+			ID: "loan-1", ProductCode: "PERSONAL", Status: model.LoanStatusOffered, Amount: model.NewMoney(12000000),
+			TenureMonths: 12, InterestRate: 12, InterestMethod: model.InterestMethodAnnuity,
+		}
+		loanRepo := new(MockLoanRepository)
+		loanRepo.On("GetByID", ctx, "loan-1").Return(loan, nil)
+		loanRepo.On("UpdateLoan", ctx, loan, "interest rate changed").Return(nil)
+		installmentRepo := new(MockInstallmentRepository)
+		installmentRepo.On("ReplaceForLoan", ctx, "loan-1", mock.Anything).Return(nil)
+
+		uc := NewLoanUseCase(loanRepo, nil, installmentRepo, nil, nil, nil, newProductRepo(ctx, personalLoanProduct()), nil, nil)
+		updated, err := uc.SetInterestRate(ctx, "loan-1", 18)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 18.0, updated.InterestRate)
+		assert.Equal(t, model.MustParseMoney("1100159.91"), updated.MonthlyPayment)
+		installmentRepo.AssertExpectations(t)
+	})
+
+	t.Run("offer terms survive the round trip through the database", func(t *testing.T) {
+		loan := &model.Loan{
+			ID: "loan-1", ProductCode: "PERSONAL", Status: model.LoanStatusOffered, Amount: model.NewMoney(12000000),
+			TenureMonths: 12, InterestRate: 12, InterestMethod: model.InterestMethodAnnuity,
+		}
+		loanRepo := new(MockLoanRepository)
+		loanRepo.On("GetByID", ctx, "loan-1").Return(loan, nil)
+		loanRepo.On("UpdateLoan", ctx, loan, "interest rate changed").Return(nil)
+		installmentRepo := new(MockInstallmentRepository)
+		installmentRepo.On("ReplaceForLoan", ctx, "loan-1", mock.Anything).Return(nil)
+
+		uc := NewLoanUseCase(loanRepo, nil, installmentRepo, nil, nil, nil, newProductRepo(ctx, personalLoanProduct()), nil, nil)
+		updated, err := uc.SetInterestRate(ctx, "loan-1", 18.125)
+		require.NoError(t, err)
+		assert.Equal(t, 18.13, updated.InterestRate)
+
+		// interest_rate is DECIMAL(5,2) and effective_annual_rate DECIMAL(8,4)
+		stored := *updated
+		stored.InterestRate, err = strconv.ParseFloat(fmt.Sprintf("%.2f", updated.InterestRate), 64)
+		require.NoError(t, err)
+		stored.EffectiveAnnualRate, err = strconv.ParseFloat(fmt.Sprintf("%.4f", updated.EffectiveAnnualRate), 64)
+		require.NoError(t, err)
+
+		_, shown, err := updated.OfferTerms().Encode()
+		require.NoError(t, err)
+		_, reread, err := stored.OfferTerms().Encode()
+		require.NoError(t, err)
+		assert.Equal(t, reread, shown)
+	})
+
+	t.Run("rate outside product range", func(t *testing.T) {
+		loanRepo := new(MockLoanRepository)
+		loanRepo.On("GetByID", ctx, "loan-1").Return(&model.Loan{ID: "loan-1", ProductCode: "PERSONAL", Status: model.LoanStatusOffered}, nil)
+
+		uc := NewLoanUseCase(loanRepo, nil, nil, nil, nil, nil, newProductRepo(ctx, personalLoanProduct()), nil, nil)
+		_, err := uc.SetInterestRate(ctx, "loan-1", 35)
+
+		assert.ErrorAs(t, err, &ValidationError{})
+		loanRepo.AssertNotCalled(t, "UpdateLoan", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("rate beyond the interest rate column", func(t *testing.T) {
+		product := personalLoanProduct()
+		product.MaxInterestRate = 999.99
+		loanRepo := new(MockLoanRepository)
+		loanRepo.On("GetByID", ctx, "loan-1").Return(&model.Loan{ID: "loan-1", ProductCode: "PERSONAL", Status: model.LoanStatusOffered}, nil)
+
+		uc := NewLoanUseCase(loanRepo, nil, nil, nil, nil, nil, newProductRepo(ctx, product), nil, nil)
+		for _, rate := range []float64{1000, 999.995, -0.01} {
+			_, err := uc.SetInterestRate(ctx, "loan-1", rate)
+			assert.ErrorAs(t, err, &ValidationError{}, rate)
+		}
+		loanRepo.AssertNotCalled(t, "UpdateLoan", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("accepted offer", func(t *testing.T) {
+		loanRepo := new(MockLoanRepository)
+		loanRepo.On("GetByID", ctx, "loan-1").Return(&model.Loan{ID: "loan-1", ProductCode: "PERSONAL", Status: model.LoanStatusApproved}, nil)
+
+		_, err := NewLoanUseCase(loanRepo, nil, nil, nil, nil, nil, nil, nil, nil).SetInterestRate(ctx, "loan-1", 12)
+
+		assert.ErrorAs(t, err, &ValidationError{})
+		loanRepo.AssertNotCalled(t, "UpdateLoan", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("partially disbursed loan", func(t *testing.T) {
+		loanRepo := new(MockLoanRepository)
+		loanRepo.On("GetByID", ctx, "loan-1").Return(&model.Loan{ID: "loan-1", Status: model.LoanStatusPartiallyDisbursed, DisbursedAmount: model.NewMoney(5000000)}, nil)
+
+		_, err := NewLoanUseCase(loanRepo, nil, nil, nil, nil, nil, nil, nil, nil).SetInterestRate(ctx, "loan-1", 12)
+
+		assert.ErrorAs(t, err, &ValidationError{})
+	})
+}
+
+func TestGetRepaymentSchedule(t *testing.T) {
+	owner := model.ContextWithActor(context.Background(), model.Actor{ID: "user-1", Role: model.ActorRoleCustomer})
+	other := model.ContextWithActor(context.Background(), model.Actor{ID: "user-2", Role: model.ActorRoleCustomer})
+	admin := model.ContextWithActor(context.Background(), model.Actor{ID: "admin-1", Role: model.ActorRoleAdmin})
+	schedule := []model.Installment{{ID: "i1", LoanID: "loan-1", InstallmentNumber: 1}}
+
+	loanRepo := new(MockLoanRepository)
+	loanRepo.On("GetByID", mock.Anything, "loan-1").Return(&model.Loan{ID: "loan-1", UserID: "user-1", Status: model.LoanStatusDisbursed}, nil)
+	loanRepo.On("GetByID", mock.Anything, "loan-2").Return(nil, nil)
+	installmentRepo := new(MockInstallmentRepository)
+	installmentRepo.On("GetByLoanID", mock.Anything, "loan-1").Return(schedule, nil)
+	uc := NewLoanUseCase(loanRepo, nil, installmentRepo, nil, nil, nil, nil, nil, nil)
+
+	installments, err := uc.GetRepaymentSchedule(owner, "loan-1")
+	assert.NoError(t, err)
+	assert.Equal(t, schedule, installments)
+
+	_, err = uc.GetRepaymentSchedule(admin, "loan-1")
+	assert.NoError(t, err)
+
+	_, err = uc.GetRepaymentSchedule(other, "loan-1")
+	assert.ErrorIs(t, err, ErrLoanAccessDenied)
+
+	_, err = uc.GetRepaymentSchedule(admin, "loan-2")
+	assert.ErrorIs(t, err, ErrLoanNotFound)
+	installmentRepo.AssertNumberOfCalls(t, "GetByLoanID", 2)
+}
+
+func TestSubmitLoanDocuments(t *testing.T) {
+	ctx := model.ContextWithActor(context.Background(), model.Actor{ID: "user-1", Role: model.ActorRoleCustomer})
+
+	newLoanRepo := func() *MockLoanRepository {
+		loanRepo := new(MockLoanRepository)
+		loanRepo.On("GetByID", ctx, "loan-1").Return(&model.Loan{ID: "loan-1", UserID: "user-1", Status: model.LoanStatusPending}, nil)
+		return loanRepo
+	}
+
+	t.Run("submitted", func(t *testing.T) {
+		loanRepo := newLoanRepo()
+		loanRepo.On("AddDocument", ctx, mock.AnythingOfType("*model.Document")).Return(nil)
+		loanRepo.On("UpdateLoanStatus", ctx, "loan-1", model.LoanStatusInReview, "documents submitted").Return(nil)
+
+		err := NewLoanUseCase(loanRepo, nil, nil, nil, nil, nil, nil, nil, nil).SubmitLoanDocuments(ctx, "loan-1", []model.Document{
+			{Type: model.DocumentTypeKTP, Name: "ktp.jpg", URL: "http://storage.example.com/ktp.jpg"},
+			{Type: model.DocumentTypePayslip, Name: "payslip.pdf", URL: "http://storage.example.com/payslip.pdf"},
+		})
+
+		assert.NoError(t, err)
+		loanRepo.AssertNumberOfCalls(t, "AddDocument", 2)
+	})
+
+	t.Run("invalid documents", func(t *testing.T) {
+		loanRepo := newLoanRepo()
+		uc := NewLoanUseCase(loanRepo, nil, nil, nil, nil, nil, nil, nil, nil)
+
+		err := uc.SubmitLoanDocuments(ctx, "loan-1", []model.Document{{Type: "selfie", Name: "me.jpg"}})
+		assert.ErrorAs(t, err, &ValidationError{})
+
+		err = uc.SubmitLoanDocuments(ctx, "loan-1", []model.Document{{Type: model.DocumentTypeKTP, Name: "front.jpg"}, {Type: model.DocumentTypeKTP, Name: "back.jpg"}})
+		assert.ErrorAs(t, err, &ValidationError{})
+
+		loanRepo.AssertNotCalled(t, "AddDocument", mock.Anything, mock.Anything)
+	})
+}
+
+func TestReviewDocument(t *testing.T) {
+	ctx := model.ContextWithActor(context.Background(), model.Actor{ID: "admin-1", Role: model.ActorRoleAdmin})
+
+	newLoanRepo := func(status model.LoanStatus) *MockLoanRepository {
+		loanRepo := new(MockLoanRepository)
+		loanRepo.On("GetDocumentByID", ctx, "doc-1").Return(&model.Document{ID: "doc-1", LoanID: "loan-1", Type: model.DocumentTypeKTP, Status: model.DocumentStatusUploaded}, nil)
+		loanRepo.On("GetDocumentByID", ctx, "doc-2").Return(&model.Document{ID: "doc-2", LoanID: "loan-1", Status: model.DocumentStatusRequired}, nil)
+		loanRepo.On("GetDocumentByID", ctx, "doc-3").Return(nil, nil)
+		loanRepo.On("GetByID", ctx, "loan-1").Return(&model.Loan{ID: "loan-1", Status: status}, nil)
+		return loanRepo
+	}
+
+	t.Run("verified", func(t *testing.T) {
+		loanRepo := newLoanRepo(model.LoanStatusInReview)
+		loanRepo.On("ReviewDocument", ctx, mock.Anything, model.LoanStatusInReview, "").Return(nil)
+
+		doc, err := NewLoanUseCase(loanRepo, nil, nil, nil, nil, nil, nil, nil, nil).ReviewDocument(ctx, "loan-1", "doc-1", model.DocumentReview{Status: model.DocumentStatusVerified})
+
+		require.NoError(t, err)
+		assert.Equal(t, model.DocumentStatusVerified, doc.Status)
+		assert.Equal(t, "admin-1", doc.ReviewedBy)
+		assert.NotNil(t, doc.ReviewedAt)
+		loanRepo.AssertCalled(t, "ReviewDocument", ctx, doc, model.LoanStatusInReview, "")
+	})
+
+	t.Run("rejected asks for documents", func(t *testing.T) {
+		loanRepo := newLoanRepo(model.LoanStatusInReview)
+		loanRepo.On("ReviewDocument", ctx, mock.Anything, model.LoanStatusDocumentsNeeded, "document ktp rejected: illegible").Return(nil)
+
+		doc, err := NewLoanUseCase(loanRepo, nil, nil, nil, nil, nil, nil, nil, nil).ReviewDocument(ctx, "loan-1", "doc-1", model.DocumentReview{
+			Status: model.DocumentStatusRejected, ReasonCode: model.DocumentReasonIllegible, Note: " photo is blurred ",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, model.DocumentStatusRejected, doc.Status)
+		assert.Equal(t, model.DocumentReasonIllegible, doc.ReasonCode)
+		assert.Equal(t, "photo is blurred", doc.ReviewNote)
+		loanRepo.AssertCalled(t, "ReviewDocument", ctx, doc, model.LoanStatusDocumentsNeeded, "document ktp rejected: illegible")
+	})
+
+	t.Run("invalid reviews", func(t *testing.T) {
+		loanRepo := newLoanRepo(model.LoanStatusInReview)
+		uc := NewLoanUseCase(loanRepo, nil, nil, nil, nil, nil, nil, nil, nil)
+
+		tests := []struct {
+			name       string
+			documentID string
+			review     model.DocumentReview
+		}{
+			{"rejection without reason code", "doc-1", model.DocumentReview{Status: model.DocumentStatusRejected}},
+			{"unknown reason code", "doc-1", model.DocumentReview{Status: model.DocumentStatusRejected, ReasonCode: "blurry"}},
+			{"other without note", "doc-1", model.DocumentReview{Status: model.DocumentStatusRejected, ReasonCode: model.DocumentReasonOther}},
+			{"not a review status", "doc-1", model.DocumentReview{Status: model.DocumentStatusUploaded}},
+			{"not uploaded yet", "doc-2", model.DocumentReview{Status: model.DocumentStatusVerified}},
+		}
+		for _, tt := range tests {
+			_, err := uc.ReviewDocument(ctx, "loan-1", tt.documentID, tt.review)
+			assert.ErrorAs(t, err, &ValidationError{}, tt.name)
+		}
+
+		_, err := uc.ReviewDocument(ctx, "loan-2", "doc-1", model.DocumentReview{Status: model.DocumentStatusVerified})
+		assert.ErrorIs(t, err, ErrDocumentNotFound)
+		_, err = uc.ReviewDocument(ctx, "loan-1", "doc-3", model.DocumentReview{Status: model.DocumentStatusVerified})
+		assert.ErrorIs(t, err, ErrDocumentNotFound)
+
+		loanRepo.AssertNotCalled(t, "ReviewDocument", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("decided loan", func(t *testing.T) {
+		loanRepo := newLoanRepo(model.LoanStatusApproved)
+
+		_, err := NewLoanUseCase(loanRepo, nil, nil, nil, nil, nil, nil, nil, nil).ReviewDocument(ctx, "loan-1", "doc-1", model.DocumentReview{Status: model.DocumentStatusVerified})
+
+		assert.ErrorAs(t, err, &ValidationError{})
+		loanRepo.AssertNotCalled(t, "ReviewDocument", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestProcessLoanApplication_RequiresVerifiedDocuments(t *testing.T) {
+	ctx := model.ContextWithActor(context.Background(), model.Actor{ID: "admin-1", Role: model.ActorRoleAdmin})
+	product := personalLoanProduct()
+	product.RequiredDocuments = []model.DocumentType{model.DocumentTypeKTP, model.DocumentTypePayslip}
+	now := time.Now()
+
+	newLoan := func(docs ...model.Document) *model.Loan {
+		return &model.Loan{
+			ID: "loan-1", ProductCode: "PERSONAL", Status: model.LoanStatusInReview, Amount: model.NewMoney(12000000),
+			TenureMonths: 12, InterestMethod: model.InterestMethodAnnuity, RiskGrade: model.RiskGradeA, ScoredAt: &now,
+			Documents: docs,
+		}
+	}
+
+	t.Run("blocked", func(t *testing.T) {
+		loanRepo := new(MockLoanRepository)
+		loanRepo.On("GetByID", ctx, "loan-1").Return(newLoan(
+			model.Document{Type: model.DocumentTypeKTP, Status: model.DocumentStatusVerified},
+			model.Document{Type: model.DocumentTypePayslip, Status: model.DocumentStatusUploaded},
+		), nil)
+
+		err := NewLoanUseCase(loanRepo, nil, nil, nil, nil, nil, newProductRepo(ctx, product), nil, nil).ProcessLoanApplication(ctx, "loan-1", true, 12, "")
+
+		assert.ErrorAs(t, err, &ValidationError{})
+		assert.Contains(t, err.Error(), "payslip")
+		assert.NotContains(t, err.Error(), "ktp")
+		loanRepo.AssertNotCalled(t, "UpdateLoan", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("checklist document not verified", func(t *testing.T) {
+		loanRepo := new(MockLoanRepository)
+		loanRepo.On("GetByID", ctx, "loan-1").Return(newLoan(
+			model.Document{Type: model.DocumentTypeKTP, Status: model.DocumentStatusVerified},
+			model.Document{Type: model.DocumentTypePayslip, Status: model.DocumentStatusVerified},
+			model.Document{Type: model.DocumentTypeBankStatement, Status: model.DocumentStatusRequired, Required: true},
+		), nil)
+
+		err := NewLoanUseCase(loanRepo, nil, nil, nil, nil, nil, newProductRepo(ctx, product), nil, nil).ProcessLoanApplication(ctx, "loan-1", true, 12, "")
+
+		assert.ErrorAs(t, err, &ValidationError{})
+		assert.Contains(t, err.Error(), "bank_statement")
+	})
+
+	t.Run("all verified", func(t *testing.T) {
+		loan := newLoan(
+			model.Document{Type: model.DocumentTypeKTP, Status: model.DocumentStatusVerified},
+			model.Document{Type: model.DocumentTypePayslip, Status: model.DocumentStatusRejected},
+			model.Document{Type: model.DocumentTypePayslip, Status: model.DocumentStatusVerified},
+		)
+		loanRepo := new(MockLoanRepository)
+		loanRepo.On("GetByID", ctx, "loan-1").Return(loan, nil)
+		loanRepo.On("UpdateLoan", ctx, loan, "application approved").Return(nil)
+		installmentRepo := new(MockInstallmentRepository)
+		installmentRepo.On("ReplaceForLoan", ctx, "loan-1", mock.Anything).Return(nil)
+
+		err := NewLoanUseCase(loanRepo, nil, installmentRepo, nil, nil, nil, newProductRepo(ctx, product), nil, nil).ProcessLoanApplication(ctx, "loan-1", true, 12, "")
+
+		assert.NoError(t, err)
+		assert.Equal(t, model.LoanStatusOffered, loan.Status)
+	})
+}
+
+func TestCheckTransition(t *testing.T) {
+	admin := model.ContextWithActor(context.Background(), model.Actor{ID: "admin-1", Role: model.ActorRoleAdmin})
+	customer := model.ContextWithActor(context.Background(), model.Actor{ID: "user-1", Role: model.ActorRoleCustomer})
+	system := model.ContextWithActor(context.Background(), model.SystemActor)
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		from    model.LoanStatus
+		to      model.LoanStatus
+		allowed bool
+	}{
+		{"customer submits documents", customer, model.LoanStatusPending, model.LoanStatusInReview, true},
+		{"admin approves reviewed loan", admin, model.LoanStatusInReview, model.LoanStatusOffered, true},
+		{"customer cannot approve", customer, model.LoanStatusInReview, model.LoanStatusOffered, false},
+		{"approval skips the offer", admin, model.LoanStatusInReview, model.LoanStatusApproved, false},
+		{"customer accepts offer", customer, model.LoanStatusOffered, model.LoanStatusApproved, true},
+		{"admin cannot accept offer", admin, model.LoanStatusOffered, model.LoanStatusApproved, false},
+		{"customer declines offer", customer, model.LoanStatusOffered, model.LoanStatusDeclined, true},
+		{"approval of disbursed loan", admin, model.LoanStatusDisbursed, model.LoanStatusApproved, false},
+		{"approval of rejected loan", admin, model.LoanStatusRejected, model.LoanStatusApproved, false},
+		{"disbursement skips approval", admin, model.LoanStatusPending, model.LoanStatusDisbursed, false},
+		{"system pays off disbursed loan", system, model.LoanStatusDisbursed, model.LoanStatusPaidOff, true},
+		{"customer cannot pay off", customer, model.LoanStatusDisbursed, model.LoanStatusPaidOff, false},
+		{"customer cannot pay off restructured loan", customer, model.LoanStatusRestructured, model.LoanStatusPaidOff, false},
+		{"system defaults delinquent loan", system, model.LoanStatusRestructured, model.LoanStatusDefaulted, true},
+		{"system cannot restructure", system, model.LoanStatusDefaulted, model.LoanStatusRestructured, false},
+		{"missing actor", context.Background(), model.LoanStatusApproved, model.LoanStatusDisbursed, false},
+		{"unchanged status", customer, model.LoanStatusInReview, model.LoanStatusInReview, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkTransition(tt.ctx, tt.from, tt.to)
+			if tt.allowed {
+				assert.NoError(t, err)
+				return
+			}
+
+			var transitionErr *model.StatusTransitionError
+			assert.ErrorAs(t, err, &transitionErr)
+			assert.Equal(t, tt.from, transitionErr.From)
+			assert.Equal(t, tt.to, transitionErr.To)
+		})
+	}
+}
+
+func TestGetLoanStatusHistory(t *testing.T) {
+	owner := model.ContextWithActor(context.Background(), model.Actor{ID: "user-1", Role: model.ActorRoleCustomer})
+	other := model.ContextWithActor(context.Background(), model.Actor{ID: "user-2", Role: model.ActorRoleCustomer})
+	history := []model.LoanStatusHistory{{LoanID: "loan-1", FromStatus: model.LoanStatusPending, ToStatus: model.LoanStatusInReview}}
+
+	loanRepo := new(MockLoanRepository)
+	loanRepo.On("GetByID", mock.Anything, "loan-1").Return(&model.Loan{ID: "loan-1", UserID: "user-1", Status: model.LoanStatusInReview}, nil)
+	loanRepo.On("GetStatusHistory", mock.Anything, "loan-1").Return(history, nil)
+	uc := NewLoanUseCase(loanRepo, nil, nil, nil, nil, nil, nil, nil, nil)
+
+	changes, err := uc.GetLoanStatusHistory(owner, "loan-1")
+	assert.NoError(t, err)
+	assert.Equal(t, history, changes)
+
+	_, err = uc.GetLoanStatusHistory(other, "loan-1")
+	assert.ErrorIs(t, err, ErrLoanAccessDenied)
+
+	_, err = uc.GetLoanStatusHistory(context.Background(), "loan-1")
+	assert.ErrorIs(t, err, ErrLoanAccessDenied)
+	loanRepo.AssertNumberOfCalls(t, "GetStatusHistory", 1)
+}
@@ -0,0 +1,108 @@
+package usecase
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/edosulai/pt-xyz-multifinance/internal/model"
+)
+
+// DefaultAllocationOrder is the waterfall applied to each installment when none is configured
+var DefaultAllocationOrder = []model.PaymentComponent{
+	model.PaymentComponentFee,
+	model.PaymentComponentInterest,
+	model.PaymentComponentPrincipal,
+}
+
+// ParseAllocationOrder converts configured component names into a payment waterfall.
+// Every component must appear exactly once; an empty list yields DefaultAllocationOrder.
+func ParseAllocationOrder(names []string) ([]model.PaymentComponent, error) {
+	if len(names) == 0 {
+		return DefaultAllocationOrder, nil
+	}
+
+	seen := make(map[model.PaymentComponent]bool, len(names))
+	order := make([]model.PaymentComponent, 0, len(names))
+	for _, name := range names {
+		component := model.PaymentComponent(name)
+		switch component {
+		case model.PaymentComponentFee, model.PaymentComponentInterest, model.PaymentComponentPrincipal:
+		default:
+			return nil, fmt.Errorf("unknown payment component %q", name)
+		}
+		if seen[component] {
+			return nil, fmt.Errorf("payment component %q listed more than once", name)
+		}
+		seen[component] = true
+		order = append(order, component)
+	}
+
+	if len(order) != len(DefaultAllocationOrder) {
+		return nil, fmt.Errorf("payment allocation order must list fee, interest and principal")
+	}
+
+	return order, nil
+}
+
+// allocatePayment applies amount to the installments oldest first, following order within each installment.
+// It returns the allocations, the installments that were changed and any unallocated remainder.
+func allocatePayment(amount model.Money, installments []model.Installment, order []model.PaymentComponent, paidAt time.Time) ([]model.PaymentAllocation, []model.Installment, model.Money) {
+	var allocations []model.PaymentAllocation
+	var touched []model.Installment
+
+	remaining := amount
+	for _, inst := range installments {
+		if remaining <= 0 {
+			break
+		}
+		if !inst.IsOpen() {
+			continue
+		}
+
+		changed := false
+		for _, component := range order {
+			due, paid := installmentComponent(&inst, component)
+			outstanding := due - *paid
+			if outstanding <= 0 || remaining <= 0 {
+				continue
+			}
+
+			applied := model.MinMoney(outstanding, remaining)
+			*paid += applied
+			remaining -= applied
+			changed = true
+
+			allocations = append(allocations, model.PaymentAllocation{
+				InstallmentID: inst.ID,
+				Component:     component,
+				Amount:        applied,
+			})
+		}
+
+		if !changed {
+			continue
+		}
+
+		if inst.OutstandingAmount() <= 0 {
+			inst.Status = model.InstallmentStatusPaid
+			inst.PaidAt = timePtr(paidAt)
+		} else {
+			inst.Status = model.InstallmentStatusPartiallyPaid
+		}
+		touched = append(touched, inst)
+	}
+
+	return allocations, touched, remaining
+}
+
+// installmentComponent returns the amount due and a pointer to the paid amount of one component
+func installmentComponent(inst *model.Installment, component model.PaymentComponent) (model.Money, *model.Money) {
+	switch component {
+	case model.PaymentComponentFee:
+		return inst.FeeAmount, &inst.FeePaid
+	case model.PaymentComponentInterest:
+		return inst.InterestAmount, &inst.InterestPaid
+	default:
+		return inst.PrincipalAmount, &inst.PrincipalPaid
+	}
+}
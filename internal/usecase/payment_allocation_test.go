@@ -0,0 +1,87 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/edosulai/pt-xyz-multifinance/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAllocatePayment(t *testing.T) {
+	paidAt := time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC)
+	newSchedule := func() []model.Installment {
+		return []model.Installment{
+			{ID: "i1", InstallmentNumber: 1, PrincipalAmount: model.NewMoney(900000), InterestAmount: model.NewMoney(100000), FeeAmount: model.NewMoney(50000), Status: model.InstallmentStatusPending},
+			{ID: "i2", InstallmentNumber: 2, PrincipalAmount: model.NewMoney(910000), InterestAmount: model.NewMoney(90000), Status: model.InstallmentStatusPending},
+		}
+	}
+
+	t.Run("fee then interest then principal", func(t *testing.T) {
+		allocations, touched, remaining := allocatePayment(model.NewMoney(1200000), newSchedule(), DefaultAllocationOrder, paidAt)
+
+		assert.Equal(t, model.Money(0), remaining)
+		assert.Len(t, touched, 2)
+		assert.Equal(t, model.InstallmentStatusPaid, touched[0].Status)
+		assert.Equal(t, paidAt, *touched[0].PaidAt)
+		assert.Equal(t, model.InstallmentStatusPartiallyPaid, touched[1].Status)
+		assert.Equal(t, model.NewMoney(90000), touched[1].InterestPaid)
+		assert.Equal(t, model.NewMoney(60000), touched[1].PrincipalPaid)
+
+		assert.Equal(t, []model.PaymentComponent{
+			model.PaymentComponentFee,
+			model.PaymentComponentInterest,
+			model.PaymentComponentPrincipal,
+			model.PaymentComponentInterest,
+			model.PaymentComponentPrincipal,
+		}, components(allocations))
+	})
+
+	t.Run("custom order", func(t *testing.T) {
+		order := []model.PaymentComponent{model.PaymentComponentPrincipal, model.PaymentComponentInterest, model.PaymentComponentFee}
+		allocations, touched, _ := allocatePayment(model.NewMoney(950000), newSchedule(), order, paidAt)
+
+		assert.Len(t, touched, 1)
+		assert.Equal(t, model.NewMoney(900000), touched[0].PrincipalPaid)
+		assert.Equal(t, model.NewMoney(50000), touched[0].InterestPaid)
+		assert.Equal(t, model.Money(0), touched[0].FeePaid)
+		assert.Len(t, allocations, 2)
+	})
+
+	t.Run("paid installments are skipped", func(t *testing.T) {
+		schedule := newSchedule()
+		schedule[0].Status = model.InstallmentStatusPaid
+
+		allocations, touched, _ := allocatePayment(model.NewMoney(1000), schedule, DefaultAllocationOrder, paidAt)
+
+		assert.Len(t, touched, 1)
+		assert.Equal(t, "i2", allocations[0].InstallmentID)
+	})
+}
+
+func TestParseAllocationOrder(t *testing.T) {
+	order, err := ParseAllocationOrder(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultAllocationOrder, order)
+
+	order, err = ParseAllocationOrder([]string{"interest", "fee", "principal"})
+	assert.NoError(t, err)
+	assert.Equal(t, model.PaymentComponentInterest, order[0])
+
+	_, err = ParseAllocationOrder([]string{"fee", "fee", "principal"})
+	assert.Error(t, err)
+
+	_, err = ParseAllocationOrder([]string{"fee", "interest"})
+	assert.Error(t, err)
+
+	_, err = ParseAllocationOrder([]string{"penalty", "interest", "principal"})
+	assert.Error(t, err)
+}
+
+func components(allocations []model.PaymentAllocation) []model.PaymentComponent {
+	result := make([]model.PaymentComponent, 0, len(allocations))
+	for _, alloc := range allocations {
+		result = append(result, alloc.Component)
+	}
+	return result
+}
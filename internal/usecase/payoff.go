@@ -0,0 +1,104 @@
+package usecase
+
+import (
+	"time"
+
+	"github.com/edosulai/pt-xyz-multifinance/internal/model"
+)
+
+// PayoffPolicy configures how early payoff quotes are priced
+type PayoffPolicy struct {
+	// QuoteValidity is how long a quote can be settled after it was issued
+	QuoteValidity time.Duration
+}
+
+// DefaultPayoffPolicy is used when no payoff policy is configured
+var DefaultPayoffPolicy = PayoffPolicy{
+	QuoteValidity: 24 * time.Hour,
+}
+
+// payoffBreakdown is the amount needed to close a loan and how it settles each open installment
+type payoffBreakdown struct {
+	principal    model.Money
+	interest     model.Money
+	fees         model.Money
+	allocations  []model.PaymentAllocation
+	installments []model.Installment
+}
+
+// computePayoff settles every open installment as of asOf. Installments already due owe their full
+// interest, the installment of the current period owes interest accrued pro rata to asOf, and later
+// installments owe principal only. Installments left with waived interest are closed rather than paid.
+func computePayoff(installments []model.Installment, asOf, paidAt time.Time) payoffBreakdown {
+	var result payoffBreakdown
+
+	asOfDay := startOfDay(asOf)
+	currentPeriodSeen := false
+	for _, inst := range installments {
+		if !inst.IsOpen() {
+			continue
+		}
+
+		fee := inst.FeeAmount - inst.FeePaid
+		principal := inst.PrincipalAmount - inst.PrincipalPaid
+
+		var interest model.Money
+		if !inst.DueDate.After(asOfDay) {
+			interest = inst.InterestAmount - inst.InterestPaid
+		} else if !currentPeriodSeen {
+			currentPeriodSeen = true
+			interest = accruedInterest(inst, asOfDay) - inst.InterestPaid
+		}
+		if interest < 0 {
+			interest = 0
+		}
+
+		for _, part := range []struct {
+			component model.PaymentComponent
+			amount    model.Money
+			paid      *model.Money
+		}{
+			{model.PaymentComponentFee, fee, &inst.FeePaid},
+			{model.PaymentComponentInterest, interest, &inst.InterestPaid},
+			{model.PaymentComponentPrincipal, principal, &inst.PrincipalPaid},
+		} {
+			if part.amount <= 0 {
+				continue
+			}
+			*part.paid += part.amount
+			result.allocations = append(result.allocations, model.PaymentAllocation{
+				InstallmentID: inst.ID,
+				Component:     part.component,
+				Amount:        part.amount,
+			})
+		}
+
+		result.fees += fee
+		result.interest += interest
+		result.principal += principal
+
+		if inst.OutstandingAmount() <= 0 {
+			inst.Status = model.InstallmentStatusPaid
+		} else {
+			inst.Status = model.InstallmentStatusClosed
+		}
+		inst.PaidAt = timePtr(paidAt)
+		result.installments = append(result.installments, inst)
+	}
+
+	return result
+}
+
+// accruedInterest returns the interest of inst earned from the start of its period up to asOf
+func accruedInterest(inst model.Installment, asOf time.Time) model.Money {
+	periodStart := addMonths(inst.DueDate, -1)
+	periodDays := daysBetween(periodStart, inst.DueDate)
+	elapsed := daysBetween(periodStart, asOf)
+	if elapsed <= 0 || periodDays <= 0 {
+		return 0
+	}
+	if elapsed >= periodDays {
+		return inst.InterestAmount
+	}
+	return inst.InterestAmount.MulRatio(int64(elapsed), int64(periodDays))
+}
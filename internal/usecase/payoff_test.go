@@ -0,0 +1,159 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/edosulai/pt-xyz-multifinance/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockPayoffQuoteRepository struct {
+	mock.Mock
+}
+
+func (m *MockPayoffQuoteRepository) Create(ctx context.Context, quote *model.PayoffQuote) error {
+	args := m.Called(ctx, quote)
+	return args.Error(0)
+}
+
+func (m *MockPayoffQuoteRepository) GetByID(ctx context.Context, id string) (*model.PayoffQuote, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.PayoffQuote), args.Error(1)
+}
+
+func (m *MockPayoffQuoteRepository) Settle(ctx context.Context, quote *model.PayoffQuote, payment *model.Payment, installments []model.Installment, loan *model.Loan, previousBalance model.Money) error {
+	args := m.Called(ctx, quote, payment, installments, loan, previousBalance)
+	return args.Error(0)
+}
+
+func TestComputePayoff(t *testing.T) {
+	paidAt := time.Date(2025, time.March, 16, 9, 0, 0, 0, time.UTC)
+	newSchedule := func() []model.Installment {
+		return []model.Installment{
+			{ID: "i1", InstallmentNumber: 1, DueDate: time.Date(2025, time.February, 1, 0, 0, 0, 0, time.UTC), PrincipalAmount: model.NewMoney(900000), InterestAmount: model.NewMoney(100000), PrincipalPaid: model.NewMoney(900000), InterestPaid: model.NewMoney(100000), Status: model.InstallmentStatusPaid},
+			{ID: "i2", InstallmentNumber: 2, DueDate: time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC), PrincipalAmount: model.NewMoney(910000), InterestAmount: model.NewMoney(90000), FeeAmount: model.NewMoney(15000), InterestPaid: model.NewMoney(40000), Status: model.InstallmentStatusPartiallyPaid},
+			{ID: "i3", InstallmentNumber: 3, DueDate: time.Date(2025, time.April, 1, 0, 0, 0, 0, time.UTC), PrincipalAmount: model.NewMoney(920000), InterestAmount: model.NewMoney(80000), Status: model.InstallmentStatusPending},
+			{ID: "i4", InstallmentNumber: 4, DueDate: time.Date(2025, time.May, 1, 0, 0, 0, 0, time.UTC), PrincipalAmount: model.NewMoney(930000), InterestAmount: model.NewMoney(70000), Status: model.InstallmentStatusPending},
+		}
+	}
+
+	breakdown := computePayoff(newSchedule(), paidAt, paidAt)
+
+	// 15 of 31 days of the March period have elapsed
+	assert.Equal(t, model.MustParseMoney("88709.68"), breakdown.interest)
+	assert.Equal(t, model.NewMoney(15000), breakdown.fees)
+	assert.Equal(t, model.NewMoney(910000+920000+930000), breakdown.principal)
+
+	assert.Len(t, breakdown.installments, 3)
+	assert.Equal(t, model.InstallmentStatusPaid, breakdown.installments[0].Status)
+	assert.Equal(t, model.InstallmentStatusClosed, breakdown.installments[1].Status)
+	assert.Equal(t, model.InstallmentStatusClosed, breakdown.installments[2].Status)
+	assert.Equal(t, model.Money(0), breakdown.installments[2].InterestPaid)
+	assert.Equal(t, paidAt, *breakdown.installments[2].PaidAt)
+
+	var allocated model.Money
+	for _, alloc := range breakdown.allocations {
+		allocated += alloc.Amount
+	}
+	assert.Equal(t, breakdown.principal+breakdown.interest+breakdown.fees, allocated)
+
+	t.Run("settlement on the same day reproduces the quote", func(t *testing.T) {
+		later := paidAt.Add(5 * time.Hour)
+		again := computePayoff(newSchedule(), paidAt, later)
+		assert.Equal(t, breakdown.interest, again.interest)
+		assert.Equal(t, later, *again.installments[0].PaidAt)
+	})
+}
+
+func TestRepayment_LoanNotRepayable(t *testing.T) {
+	ctx := model.ContextWithActor(context.Background(), model.SystemActor)
+	quote := &model.PayoffQuote{
+		ID: "quote-1", LoanID: "loan-1", TotalAmount: model.NewMoney(1000000),
+		Status: model.PayoffQuoteStatusActive, QuotedAt: time.Now(), ExpiresAt: time.Now().Add(time.Hour),
+	}
+
+	for _, loanStatus := range []model.LoanStatus{model.LoanStatusApproved, model.LoanStatusPaidOff} {
+		t.Run(string(loanStatus), func(t *testing.T) {
+			loanRepo := new(MockLoanRepository)
+			loanRepo.On("GetByID", ctx, "loan-1").Return(&model.Loan{ID: "loan-1", Status: loanStatus}, nil)
+			payoffQuoteRepo := new(MockPayoffQuoteRepository)
+			payoffQuoteRepo.On("GetByID", ctx, "quote-1").Return(quote, nil)
+			uc := NewLoanUseCase(loanRepo, nil, nil, nil, payoffQuoteRepo, nil, nil, nil, nil)
+
+			var transitionErr *model.StatusTransitionError
+			_, err := uc.RecordPayment(ctx, "loan-1", model.NewMoney(500000), "transfer", "ref-1", time.Time{})
+			assert.ErrorAs(t, err, &transitionErr)
+
+			_, err = uc.GetPayoffQuote(ctx, "loan-1")
+			assert.ErrorAs(t, err, &transitionErr)
+
+			_, err = uc.SettleLoan(ctx, "loan-1", "quote-1", quote.TotalAmount, "transfer", "ref-1", time.Time{})
+			assert.ErrorAs(t, err, &transitionErr)
+			assert.Equal(t, loanStatus, transitionErr.From)
+			assert.Equal(t, model.LoanStatusPaidOff, transitionErr.To)
+
+			payoffQuoteRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestGetPayoffQuote_EarlyTerminationFee(t *testing.T) {
+	ctx := model.ContextWithActor(context.Background(), model.Actor{ID: "user-1", Role: model.ActorRoleCustomer})
+	now := time.Now()
+	schedule := []model.Installment{
+		{ID: "i1", InstallmentNumber: 1, DueDate: now.AddDate(0, 1, 0), PrincipalAmount: model.NewMoney(1000000), InterestAmount: model.NewMoney(100000), Status: model.InstallmentStatusPending},
+		{ID: "i2", InstallmentNumber: 2, DueDate: now.AddDate(0, 2, 0), PrincipalAmount: model.NewMoney(1000000), InterestAmount: model.NewMoney(90000), Status: model.InstallmentStatusPending},
+	}
+	product := personalLoanProduct()
+	product.EarlyTerminationFeePercent = 3
+
+	newUseCase := func(productCode string, payoffQuoteRepo *MockPayoffQuoteRepository) LoanUseCase {
+		loanRepo := new(MockLoanRepository)
+		loanRepo.On("GetByID", ctx, "loan-1").Return(&model.Loan{ID: "loan-1", UserID: "user-1", ProductCode: productCode, Status: model.LoanStatusDisbursed}, nil)
+		installmentRepo := new(MockInstallmentRepository)
+		installmentRepo.On("GetByLoanID", ctx, "loan-1").Return(schedule, nil)
+		productRepo := newProductRepo(ctx, product)
+		productRepo.On("GetByCode", ctx, "RETIRED").Return(nil, nil)
+		return NewLoanUseCase(loanRepo, nil, installmentRepo, nil, payoffQuoteRepo, nil, productRepo, nil, nil)
+	}
+
+	t.Run("fee of the loan's product", func(t *testing.T) {
+		payoffQuoteRepo := new(MockPayoffQuoteRepository)
+		payoffQuoteRepo.On("Create", ctx, mock.AnythingOfType("*model.PayoffQuote")).Return(nil)
+
+		quote, err := newUseCase("PERSONAL", payoffQuoteRepo).GetPayoffQuote(ctx, "loan-1")
+
+		require.NoError(t, err)
+		assert.Equal(t, model.NewMoney(2000000), quote.OutstandingPrincipal)
+		assert.Equal(t, model.NewMoney(60000), quote.EarlyTerminationFee)
+		assert.Equal(t, quote.OutstandingPrincipal+quote.AccruedInterest+quote.OutstandingFees+quote.EarlyTerminationFee, quote.TotalAmount)
+	})
+
+	t.Run("product missing from the catalog", func(t *testing.T) {
+		payoffQuoteRepo := new(MockPayoffQuoteRepository)
+
+		_, err := newUseCase("RETIRED", payoffQuoteRepo).GetPayoffQuote(ctx, "loan-1")
+
+		assert.Error(t, err)
+		payoffQuoteRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
+
+func TestGetPayoffQuote_OtherCustomersLoan(t *testing.T) {
+	ctx := model.ContextWithActor(context.Background(), model.Actor{ID: "user-2", Role: model.ActorRoleCustomer})
+	loanRepo := new(MockLoanRepository)
+	loanRepo.On("GetByID", ctx, "loan-1").Return(&model.Loan{ID: "loan-1", UserID: "user-1", Status: model.LoanStatusDisbursed}, nil)
+	payoffQuoteRepo := new(MockPayoffQuoteRepository)
+
+	_, err := NewLoanUseCase(loanRepo, nil, nil, nil, payoffQuoteRepo, nil, nil, nil, nil).GetPayoffQuote(ctx, "loan-1")
+
+	assert.ErrorIs(t, err, ErrLoanAccessDenied)
+	payoffQuoteRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,62 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/edosulai/pt-xyz-multifinance/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComputeLateCharges(t *testing.T) {
+	dueDate := time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC)
+	inst := model.Installment{
+		ID:              "i1",
+		LoanID:          "l1",
+		DueDate:         dueDate,
+		PrincipalAmount: model.NewMoney(900000),
+		InterestAmount:  model.NewMoney(100000),
+		TotalAmount:     model.NewMoney(1000000),
+	}
+	rule := PenaltyRule{DailyRatePercent: 0.1, CapPercent: 0.5, GracePeriodDays: 3}
+
+	t.Run("nothing within grace period", func(t *testing.T) {
+		charges := computeLateCharges(inst, rule, model.NewMoney(0), nil, dueDate.AddDate(0, 0, 3))
+		assert.Empty(t, charges)
+	})
+
+	t.Run("accrues daily after grace period", func(t *testing.T) {
+		charges := computeLateCharges(inst, rule, model.NewMoney(0), nil, dueDate.AddDate(0, 0, 5))
+
+		assert.Len(t, charges, 2)
+		assert.Equal(t, 4, charges[0].DaysPastDue)
+		assert.Equal(t, 5, charges[1].DaysPastDue)
+		assert.Equal(t, model.NewMoney(1000), charges[0].Amount)
+		assert.Equal(t, model.NewMoney(1000000), charges[0].OverdueAmount)
+	})
+
+	t.Run("resumes after last accrual", func(t *testing.T) {
+		last := dueDate.AddDate(0, 0, 4)
+		charges := computeLateCharges(inst, rule, model.NewMoney(1000), &last, dueDate.AddDate(0, 0, 5))
+
+		assert.Len(t, charges, 1)
+		assert.Equal(t, dueDate.AddDate(0, 0, 5), charges[0].AccrualDate)
+	})
+
+	t.Run("stops at cap", func(t *testing.T) {
+		charges := computeLateCharges(inst, rule, model.NewMoney(4500), nil, dueDate.AddDate(0, 0, 30))
+
+		assert.Len(t, charges, 1)
+		assert.Equal(t, model.NewMoney(500), charges[0].Amount)
+	})
+
+	t.Run("only unpaid interest and principal count", func(t *testing.T) {
+		partial := inst
+		partial.InterestPaid = model.NewMoney(100000)
+		partial.PrincipalPaid = model.NewMoney(400000)
+		charges := computeLateCharges(partial, rule, 0, nil, dueDate.AddDate(0, 0, 4))
+
+		assert.Len(t, charges, 1)
+		assert.Equal(t, model.NewMoney(500), charges[0].Amount)
+	})
+}
@@ -0,0 +1,164 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/edosulai/pt-xyz-multifinance/internal/model"
+	"github.com/edosulai/pt-xyz-multifinance/internal/repo"
+)
+
+// RestructureTerms describes the new terms requested for a loan restructuring
+type RestructureTerms struct {
+	// TenureMonths is the number of amortizing installments after the grace period
+	TenureMonths int
+	// InterestRate replaces the current annual rate when set
+	InterestRate *float64
+	// GracePeriodMonths is the number of interest-only installments before amortization starts
+	GracePeriodMonths int
+	Reason            string
+}
+
+// RestructureUseCase defines the interface for loan restructuring
+type RestructureUseCase interface {
+	// Restructure a loan under repayment with new terms (for admin)
+	RestructureLoan(ctx context.Context, loanID string, terms RestructureTerms) (*model.LoanRestructuring, error)
+
+	// Get the restructuring history of a loan
+	GetRestructurings(ctx context.Context, loanID string) ([]model.LoanRestructuring, error)
+}
+
+// RestructureUseCaseImpl implements RestructureUseCase interface
+type RestructureUseCaseImpl struct {
+	loanRepo          repo.LoanRepository
+	installmentRepo   repo.InstallmentRepository
+	restructuringRepo repo.RestructuringRepository
+	productRepo       repo.LoanProductRepository
+}
+
+// NewRestructureUseCase creates a new restructure use case instance
+func NewRestructureUseCase(loanRepo repo.LoanRepository, installmentRepo repo.InstallmentRepository, restructuringRepo repo.RestructuringRepository, productRepo repo.LoanProductRepository) RestructureUseCase {
+	return &RestructureUseCaseImpl{
+		loanRepo:          loanRepo,
+		installmentRepo:   installmentRepo,
+		restructuringRepo: restructuringRepo,
+		productRepo:       productRepo,
+	}
+}
+
+// RestructureLoan closes the open installments of a loan and schedules the outstanding principal on new terms.
+// Interest and fees already due on the closed installments are capitalized into the new principal.
+// The new tenure must be one offered by the loan's product.
+func (uc *RestructureUseCaseImpl) RestructureLoan(ctx context.Context, loanID string, terms RestructureTerms) (*model.LoanRestructuring, error) {
+	if terms.GracePeriodMonths < 0 || terms.GracePeriodMonths > 12 {
+		return nil, NewValidationError("grace period must be between 0 and 12 months")
+	}
+	if terms.InterestRate != nil {
+		if err := checkInterestRate(roundPercent(*terms.InterestRate)); err != nil {
+			return nil, err
+		}
+	}
+
+	loan, err := uc.loanRepo.GetByID(ctx, loanID)
+	if err != nil {
+		return nil, err
+	}
+	if loan == nil {
+		return nil, ErrLoanNotFound
+	}
+
+	if err := checkTransition(ctx, loan.Status, model.LoanStatusRestructured); err != nil {
+		return nil, err
+	}
+	if err := uc.checkTenure(ctx, loan, terms.TenureMonths); err != nil {
+		return nil, err
+	}
+
+	installments, err := uc.installmentRepo.GetByLoanID(ctx, loanID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	lastNumber := 0
+	var capitalized model.Money
+	for _, inst := range installments {
+		if inst.InstallmentNumber > lastNumber {
+			lastNumber = inst.InstallmentNumber
+		}
+		if inst.IsOpen() && inst.DueDate.Before(now) {
+			capitalized += (inst.FeeAmount - inst.FeePaid) + (inst.InterestAmount - inst.InterestPaid)
+		}
+	}
+	principal := loan.OutstandingBalance + capitalized
+	if principal <= 0 {
+		return nil, NewValidationError("loan has no outstanding balance to restructure")
+	}
+
+	interestRate := loan.InterestRate
+	if terms.InterestRate != nil {
+		interestRate = *terms.InterestRate
+	}
+
+	amortization, err := calculateSchedule(loan.InterestMethod, principal, interestRate, terms.TenureMonths)
+	if err != nil {
+		return nil, err
+	}
+	schedule := buildRestructuredSchedule(amortization, principal, interestRate, terms.GracePeriodMonths, lastNumber+1, now)
+
+	restructuring := &model.LoanRestructuring{
+		PreviousStatus:         loan.Status,
+		PreviousTenureMonths:   loan.TenureMonths,
+		PreviousInterestRate:   loan.InterestRate,
+		PreviousMonthlyPayment: loan.MonthlyPayment,
+		OutstandingPrincipal:   loan.OutstandingBalance,
+		CapitalizedAmount:      capitalized,
+		NewTenureMonths:        terms.TenureMonths,
+		NewInterestRate:        interestRate,
+		GracePeriodMonths:      terms.GracePeriodMonths,
+		NewMonthlyPayment:      schedule[terms.GracePeriodMonths].TotalAmount,
+		Reason:                 terms.Reason,
+	}
+
+	loan.TenureMonths = terms.TenureMonths
+	loan.InterestRate = interestRate
+	loan.MonthlyPayment = restructuring.NewMonthlyPayment
+	loan.EffectiveAnnualRate = amortization.EffectiveAnnualRate
+	loan.OutstandingBalance = principal
+	loan.Status = model.LoanStatusRestructured
+
+	if err := uc.restructuringRepo.Create(ctx, restructuring, loan, schedule); err != nil {
+		return nil, err
+	}
+
+	return restructuring, nil
+}
+
+// checkTenure validates the restructured tenure against the tenure options of the loan's product
+func (uc *RestructureUseCaseImpl) checkTenure(ctx context.Context, loan *model.Loan, tenureMonths int) error {
+	product, err := getLoanProduct(ctx, uc.productRepo, loan)
+	if err != nil {
+		return err
+	}
+	if !product.AllowsTenure(tenureMonths) {
+		return NewValidationError(fmt.Sprintf("restructured tenure must be one of %v months for product %s", product.TenureOptions, product.Code))
+	}
+	return nil
+}
+
+// GetRestructurings retrieves the restructuring history of a loan
+func (uc *RestructureUseCaseImpl) GetRestructurings(ctx context.Context, loanID string) ([]model.LoanRestructuring, error) {
+	loan, err := uc.loanRepo.GetByID(ctx, loanID)
+	if err != nil {
+		return nil, err
+	}
+	if loan == nil {
+		return nil, ErrLoanNotFound
+	}
+	if err := authorizeLoanAccess(ctx, loan); err != nil {
+		return nil, err
+	}
+
+	return uc.restructuringRepo.GetByLoanID(ctx, loanID)
+}
@@ -0,0 +1,86 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/edosulai/pt-xyz-multifinance/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockRestructuringRepository struct {
+	mock.Mock
+}
+
+func (m *MockRestructuringRepository) Create(ctx context.Context, restructuring *model.LoanRestructuring, loan *model.Loan, installments []model.Installment) error {
+	args := m.Called(ctx, restructuring, loan, installments)
+	return args.Error(0)
+}
+
+func (m *MockRestructuringRepository) GetByLoanID(ctx context.Context, loanID string) ([]model.LoanRestructuring, error) {
+	args := m.Called(ctx, loanID)
+	return args.Get(0).([]model.LoanRestructuring), args.Error(1)
+}
+
+func TestRestructureLoan_Tenure(t *testing.T) {
+	ctx := model.ContextWithActor(context.Background(), model.Actor{ID: "admin-1", Role: model.ActorRoleAdmin})
+	product := personalLoanProduct()
+	product.TenureOptions = []int{12, 24}
+
+	tests := []struct {
+		name         string
+		tenureMonths int
+		valid        bool
+	}{
+		{"tenure offered by the product", 24, true},
+		{"tenure not offered by the product", 18, false},
+		{"tenure beyond the product's longest", 36, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loanRepo := new(MockLoanRepository)
+			loanRepo.On("GetByID", ctx, "loan-1").Return(&model.Loan{
+				ID: "loan-1", ProductCode: "PERSONAL", Status: model.LoanStatusDisbursed, InterestRate: 18,
+				InterestMethod: model.InterestMethodAnnuity, TenureMonths: 12, OutstandingBalance: model.NewMoney(6000000),
+			}, nil)
+			installmentRepo := new(MockInstallmentRepository)
+			installmentRepo.On("GetByLoanID", ctx, "loan-1").Return([]model.Installment{}, nil)
+			restructuringRepo := new(MockRestructuringRepository)
+			restructuringRepo.On("Create", ctx, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+			uc := NewRestructureUseCase(loanRepo, installmentRepo, restructuringRepo, newProductRepo(ctx, product))
+			restructuring, err := uc.RestructureLoan(ctx, "loan-1", RestructureTerms{TenureMonths: tt.tenureMonths})
+
+			if !tt.valid {
+				assert.ErrorAs(t, err, &ValidationError{})
+				restructuringRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.tenureMonths, restructuring.NewTenureMonths)
+		})
+	}
+}
+
+func TestGetRestructurings(t *testing.T) {
+	owner := model.ContextWithActor(context.Background(), model.Actor{ID: "user-1", Role: model.ActorRoleCustomer})
+	other := model.ContextWithActor(context.Background(), model.Actor{ID: "user-2", Role: model.ActorRoleCustomer})
+	history := []model.LoanRestructuring{{ID: "rs-1", LoanID: "loan-1", NewTenureMonths: 24}}
+
+	loanRepo := new(MockLoanRepository)
+	loanRepo.On("GetByID", mock.Anything, "loan-1").Return(&model.Loan{ID: "loan-1", UserID: "user-1", Status: model.LoanStatusRestructured}, nil)
+	restructuringRepo := new(MockRestructuringRepository)
+	restructuringRepo.On("GetByLoanID", mock.Anything, "loan-1").Return(history, nil)
+	uc := NewRestructureUseCase(loanRepo, nil, restructuringRepo, nil)
+
+	restructurings, err := uc.GetRestructurings(owner, "loan-1")
+	assert.NoError(t, err)
+	assert.Equal(t, history, restructurings)
+
+	_, err = uc.GetRestructurings(other, "loan-1")
+	assert.ErrorIs(t, err, ErrLoanAccessDenied)
+	restructuringRepo.AssertNumberOfCalls(t, "GetByLoanID", 1)
+}
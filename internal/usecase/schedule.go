@@ -0,0 +1,162 @@
+package usecase
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/edosulai/pt-xyz-multifinance/internal/model"
+	"github.com/edosulai/pt-xyz-multifinance/pkg/interest"
+)
+
+// calculateSchedule splits principal into installments with the loan's interest method; loans
+// created before methods were selectable use annuity.
+func calculateSchedule(method model.InterestMethod, principal model.Money, interestRate float64, tenureMonths int) (*interest.Schedule, error) {
+	parsed, err := interest.ParseMethod(string(method))
+	if err != nil {
+		return nil, NewValidationError(err.Error())
+	}
+
+	schedule, err := interest.Calculate(parsed, principal.Sen(), interestRate, tenureMonths)
+	if err != nil {
+		return nil, NewValidationError(err.Error())
+	}
+	return schedule, nil
+}
+
+// buildInstallmentSchedule converts a calculated schedule into installments.
+// The first installment is due one month after start.
+func buildInstallmentSchedule(schedule *interest.Schedule, start time.Time) []model.Installment {
+	installments := make([]model.Installment, 0, len(schedule.Periods))
+	for _, period := range schedule.Periods {
+		installments = append(installments, model.Installment{
+			InstallmentNumber: period.Number,
+			DueDate:           addMonths(start, period.Number),
+			PrincipalAmount:   model.Money(period.Principal),
+			InterestAmount:    model.Money(period.Interest),
+			TotalAmount:       model.Money(period.Payment),
+			RemainingBalance:  model.Money(period.RemainingBalance),
+			Status:            model.InstallmentStatusPending,
+		})
+	}
+
+	return installments
+}
+
+// buildRestructuredSchedule generates graceMonths interest-only installments on principal followed by the
+// amortizing schedule. Installments are numbered from firstNumber so they follow the closed schedule.
+func buildRestructuredSchedule(amortization *interest.Schedule, principal model.Money, interestRate float64, graceMonths, firstNumber int, start time.Time) []model.Installment {
+	graceInterest := model.Money(interest.MonthlyInterest(principal.Sen(), interestRate))
+
+	installments := make([]model.Installment, 0, graceMonths+len(amortization.Periods))
+	for n := 1; n <= graceMonths; n++ {
+		installments = append(installments, model.Installment{
+			DueDate:          addMonths(start, n),
+			InterestAmount:   graceInterest,
+			TotalAmount:      graceInterest,
+			RemainingBalance: principal,
+			Status:           model.InstallmentStatusPending,
+		})
+	}
+
+	installments = append(installments, buildInstallmentSchedule(amortization, addMonths(start, graceMonths))...)
+	for i := range installments {
+		installments[i].InstallmentNumber = firstNumber + i
+	}
+
+	return installments
+}
+
+// continueInstallmentSchedule converts a calculated schedule into installments numbered from
+// firstNumber. Installment n stays due n months after start, so the cadence of the installments
+// before firstNumber carries on.
+func continueInstallmentSchedule(schedule *interest.Schedule, start time.Time, firstNumber int) []model.Installment {
+	installments := buildInstallmentSchedule(schedule, start)
+	for i := range installments {
+		installments[i].InstallmentNumber = firstNumber + i
+		installments[i].DueDate = addMonths(start, firstNumber+i)
+	}
+
+	return installments
+}
+
+// tranchePlan is the schedule needed once a tranche of a loan is released
+type tranchePlan struct {
+	principal    model.Money
+	tenureMonths int
+	firstNumber  int
+	start        time.Time
+}
+
+// planTranche keeps the installments already due or paid and reschedules the principal of the
+// rest, together with the new tranche of amount, over the remaining tenure. The first tranche
+// starts a schedule on releasedAt; later tranches keep the due dates of the first.
+func planTranche(loan *model.Loan, installments []model.Installment, amount model.Money, releasedAt time.Time) tranchePlan {
+	plan := tranchePlan{principal: amount, firstNumber: 1, start: releasedAt}
+	if len(installments) > 0 && loan.DisbursedAt != nil {
+		plan.start = *loan.DisbursedAt
+	}
+
+	for _, inst := range installments {
+		untouched := inst.Status == model.InstallmentStatusPending && inst.FeePaid+inst.InterestPaid+inst.PrincipalPaid == 0
+		if (!untouched || !inst.DueDate.After(releasedAt)) && inst.InstallmentNumber >= plan.firstNumber {
+			plan.firstNumber = inst.InstallmentNumber + 1
+		}
+	}
+	for _, inst := range installments {
+		if inst.InstallmentNumber >= plan.firstNumber {
+			plan.principal += inst.PrincipalAmount
+		}
+	}
+
+	plan.tenureMonths = loan.TenureMonths - (plan.firstNumber - 1)
+	if plan.tenureMonths < 1 {
+		plan.tenureMonths = 1
+	}
+
+	return plan
+}
+
+// addMonths returns the date n months after t, clamped to the last day of the target month
+func addMonths(t time.Time, n int) time.Time {
+	year, month, day := t.Date()
+	firstOfTarget := time.Date(year, month+time.Month(n), 1, 0, 0, 0, 0, t.Location())
+	lastDay := firstOfTarget.AddDate(0, 1, -1).Day()
+	if day > lastDay {
+		day = lastDay
+	}
+	return time.Date(firstOfTarget.Year(), firstOfTarget.Month(), day, 0, 0, 0, 0, t.Location())
+}
+
+// startOfDay truncates t to midnight in its own location
+func startOfDay(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+}
+
+// daysBetween returns the number of calendar days from a to b
+func daysBetween(a, b time.Time) int {
+	from := time.Date(a.Year(), a.Month(), a.Day(), 0, 0, 0, 0, time.UTC)
+	to := time.Date(b.Year(), b.Month(), b.Day(), 0, 0, 0, 0, time.UTC)
+	return int(to.Sub(from).Hours() / 24)
+}
+
+// maxInterestRate is the largest annual rate the DECIMAL(5,2) interest rate columns hold
+const maxInterestRate = 999.99
+
+// roundPercent rounds a percentage to the two decimal places of the DECIMAL(5,2) interest rate
+// and DECIMAL(6,2) DTI columns
+func roundPercent(percent float64) float64 {
+	return math.Round(percent*100) / 100
+}
+
+// checkInterestRate rejects annual rates, already rounded, that the interest rate columns cannot hold
+func checkInterestRate(rate float64) error {
+	if rate < 0 {
+		return NewValidationError("interest rate must not be negative")
+	}
+	if rate > maxInterestRate {
+		return NewValidationError(fmt.Sprintf("interest rate must not exceed %.2f%%", maxInterestRate))
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/edosulai/pt-xyz-multifinance/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildInstallmentSchedule(t *testing.T) {
+	start := time.Date(2025, time.January, 31, 10, 0, 0, 0, time.UTC)
+
+	build := func(principal model.Money, interestRate float64, tenureMonths int) []model.Installment {
+		calculated, err := calculateSchedule(model.InterestMethodAnnuity, principal, interestRate, tenureMonths)
+		assert.NoError(t, err)
+		return buildInstallmentSchedule(calculated, start)
+	}
+
+	t.Run("principal fully amortized", func(t *testing.T) {
+		schedule := build(model.NewMoney(10000000), 12, 12)
+
+		assert.Len(t, schedule, 12)
+
+		var totalPrincipal model.Money
+		for i, inst := range schedule {
+			assert.Equal(t, i+1, inst.InstallmentNumber)
+			assert.Equal(t, inst.PrincipalAmount+inst.InterestAmount, inst.TotalAmount)
+			totalPrincipal += inst.PrincipalAmount
+		}
+
+		assert.Equal(t, model.NewMoney(10000000), totalPrincipal)
+		assert.Equal(t, model.Money(0), schedule[11].RemainingBalance)
+		assert.Equal(t, model.NewMoney(100000), schedule[0].InterestAmount)
+	})
+
+	t.Run("due dates clamped to end of month", func(t *testing.T) {
+		schedule := build(model.NewMoney(6000000), 10, 6)
+
+		assert.Equal(t, time.Date(2025, time.February, 28, 0, 0, 0, 0, time.UTC), schedule[0].DueDate)
+		assert.Equal(t, time.Date(2025, time.March, 31, 0, 0, 0, 0, time.UTC), schedule[1].DueDate)
+		assert.Equal(t, time.Date(2025, time.April, 30, 0, 0, 0, 0, time.UTC), schedule[2].DueDate)
+	})
+}
+
+func TestBuildRestructuredSchedule(t *testing.T) {
+	start := time.Date(2025, time.June, 15, 0, 0, 0, 0, time.UTC)
+	amortization, err := calculateSchedule(model.InterestMethodAnnuity, model.NewMoney(12000000), 12, 12)
+	assert.NoError(t, err)
+	schedule := buildRestructuredSchedule(amortization, model.NewMoney(12000000), 12, 2, 5, start)
+
+	assert.Len(t, schedule, 14)
+	assert.Equal(t, 5, schedule[0].InstallmentNumber)
+	assert.Equal(t, 18, schedule[13].InstallmentNumber)
+
+	for _, inst := range schedule[:2] {
+		assert.Equal(t, model.Money(0), inst.PrincipalAmount)
+		assert.Equal(t, model.NewMoney(120000), inst.InterestAmount)
+		assert.Equal(t, model.NewMoney(12000000), inst.RemainingBalance)
+	}
+
+	assert.Equal(t, time.Date(2025, time.July, 15, 0, 0, 0, 0, time.UTC), schedule[0].DueDate)
+	assert.Equal(t, time.Date(2025, time.September, 15, 0, 0, 0, 0, time.UTC), schedule[2].DueDate)
+	assert.Positive(t, schedule[2].PrincipalAmount)
+	assert.Equal(t, model.Money(0), schedule[13].RemainingBalance)
+}
+
+func TestCalculateSchedule(t *testing.T) {
+	t.Run("legacy loans default to annuity", func(t *testing.T) {
+		schedule, err := calculateSchedule("", model.NewMoney(12000000), 12, 12)
+		assert.NoError(t, err)
+		assert.Equal(t, "annuity", string(schedule.Method))
+	})
+
+	t.Run("unknown method", func(t *testing.T) {
+		_, err := calculateSchedule("balloon", model.NewMoney(12000000), 12, 12)
+		assert.ErrorAs(t, err, &ValidationError{})
+	})
+}
+
+func TestPlanTranche(t *testing.T) {
+	firstTranche := time.Date(2025, time.January, 31, 0, 0, 0, 0, time.UTC)
+	loan := &model.Loan{Amount: model.NewMoney(10000000), TenureMonths: 6, DisbursedAmount: model.NewMoney(6000000), DisbursedAt: &firstTranche}
+
+	calculated, err := calculateSchedule(model.InterestMethodAnnuity, model.NewMoney(6000000), 12, 6)
+	assert.NoError(t, err)
+	current := buildInstallmentSchedule(calculated, firstTranche)
+
+	t.Run("first tranche", func(t *testing.T) {
+		releasedAt := time.Date(2025, time.January, 10, 0, 0, 0, 0, time.UTC)
+		plan := planTranche(&model.Loan{TenureMonths: 6}, nil, model.NewMoney(6000000), releasedAt)
+
+		assert.Equal(t, tranchePlan{principal: model.NewMoney(6000000), tenureMonths: 6, firstNumber: 1, start: releasedAt}, plan)
+	})
+
+	t.Run("due and prepaid installments are kept", func(t *testing.T) {
+		installments := append([]model.Installment(nil), current...)
+		installments[0].Status = model.InstallmentStatusPaid
+		installments[0].PrincipalPaid = installments[0].PrincipalAmount
+		installments[0].InterestPaid = installments[0].InterestAmount
+		installments[1].Status = model.InstallmentStatusPartiallyPaid
+		installments[1].InterestPaid = installments[1].InterestAmount
+
+		// The second installment is not due yet but has already received a payment
+		plan := planTranche(loan, installments, model.NewMoney(4000000), time.Date(2025, time.March, 5, 0, 0, 0, 0, time.UTC))
+
+		assert.Equal(t, 3, plan.firstNumber)
+		assert.Equal(t, 4, plan.tenureMonths)
+		assert.Equal(t, firstTranche, plan.start)
+		assert.Equal(t, installments[1].RemainingBalance+model.NewMoney(4000000), plan.principal)
+
+		rescheduled, err := calculateSchedule(model.InterestMethodAnnuity, plan.principal, 12, plan.tenureMonths)
+		assert.NoError(t, err)
+		next := continueInstallmentSchedule(rescheduled, plan.start, plan.firstNumber)
+		assert.Equal(t, 3, next[0].InstallmentNumber)
+		assert.Equal(t, time.Date(2025, time.April, 30, 0, 0, 0, 0, time.UTC), next[0].DueDate)
+		assert.Equal(t, time.Date(2025, time.July, 31, 0, 0, 0, 0, time.UTC), next[3].DueDate)
+	})
+
+	t.Run("tenure elapsed", func(t *testing.T) {
+		plan := planTranche(loan, current, model.NewMoney(4000000), time.Date(2025, time.December, 1, 0, 0, 0, 0, time.UTC))
+
+		assert.Equal(t, 7, plan.firstNumber)
+		assert.Equal(t, 1, plan.tenureMonths)
+		assert.Equal(t, model.NewMoney(4000000), plan.principal)
+	})
+}
@@ -0,0 +1,105 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/edosulai/pt-xyz-multifinance/internal/model"
+	"github.com/edosulai/pt-xyz-multifinance/internal/repo"
+)
+
+// TransactionUseCase defines the interface for consumer financing transactions
+type TransactionUseCase interface {
+	// Create a financing transaction for a user, drawing down their credit limit
+	CreateTransaction(ctx context.Context, transaction *model.Transaction) error
+
+	// Get a user's transactions
+	ListTransactions(ctx context.Context, userID string, page, pageSize int) ([]model.Transaction, int64, error)
+}
+
+// TransactionUseCaseImpl implements TransactionUseCase interface
+type TransactionUseCaseImpl struct {
+	transactionRepo repo.TransactionRepository
+	creditLimitRepo repo.CreditLimitRepository
+	userRepo        repo.UserRepository
+	// annualFlatRate is the flat interest rate charged per year on the OTR price, in percent
+	annualFlatRate float64
+}
+
+// NewTransactionUseCase creates a new transaction use case instance
+func NewTransactionUseCase(transactionRepo repo.TransactionRepository, creditLimitRepo repo.CreditLimitRepository, userRepo repo.UserRepository, annualFlatRate float64) TransactionUseCase {
+	return &TransactionUseCaseImpl{
+		transactionRepo: transactionRepo,
+		creditLimitRepo: creditLimitRepo,
+		userRepo:        userRepo,
+		annualFlatRate:  annualFlatRate,
+	}
+}
+
+// CreateTransaction prices a financing transaction and stores it with a new contract number.
+// The OTR price is financed; the admin fee is charged upfront and does not use the credit limit.
+func (uc *TransactionUseCaseImpl) CreateTransaction(ctx context.Context, transaction *model.Transaction) error {
+	transaction.AssetName = strings.TrimSpace(transaction.AssetName)
+	transaction.SalesChannel = strings.TrimSpace(transaction.SalesChannel)
+
+	if transaction.OTRPrice <= 0 {
+		return NewValidationError("OTR price must be greater than 0")
+	}
+	if transaction.AdminFee < 0 {
+		return NewValidationError("admin fee must not be negative")
+	}
+	if transaction.TenureMonths < 1 || transaction.TenureMonths > 60 {
+		return NewValidationError("tenure must be between 1 and 60 months")
+	}
+	if transaction.AssetName == "" {
+		return NewValidationError("asset name is required")
+	}
+	if transaction.SalesChannel == "" {
+		return NewValidationError("sales channel is required")
+	}
+
+	user, err := uc.userRepo.GetByID(ctx, transaction.UserID)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return ErrUserNotFound
+	}
+
+	limit, err := uc.creditLimitRepo.GetByUserAndTenure(ctx, transaction.UserID, transaction.TenureMonths)
+	if err != nil {
+		return err
+	}
+	if limit == nil {
+		return NewValidationError(fmt.Sprintf("no credit limit available for %d month tenure", transaction.TenureMonths))
+	}
+	if transaction.OTRPrice > limit.RemainingAmount() {
+		return NewValidationError(fmt.Sprintf("OTR price exceeds remaining credit limit of %s for %d month tenure", limit.RemainingAmount(), transaction.TenureMonths))
+	}
+
+	transaction.InterestAmount = transaction.OTRPrice.Percent(uc.annualFlatRate).MulRatio(int64(transaction.TenureMonths), 12)
+	transaction.InstallmentAmount = (transaction.OTRPrice + transaction.InterestAmount).Div(int64(transaction.TenureMonths))
+
+	if err := uc.transactionRepo.Create(ctx, transaction); err != nil {
+		if errors.Is(err, repo.ErrCreditLimitExceeded) {
+			return NewValidationError("OTR price exceeds remaining credit limit")
+		}
+		return err
+	}
+
+	return nil
+}
+
+// ListTransactions retrieves the transactions of a user
+func (uc *TransactionUseCaseImpl) ListTransactions(ctx context.Context, userID string, page, pageSize int) ([]model.Transaction, int64, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 10
+	}
+
+	return uc.transactionRepo.GetUserTransactions(ctx, userID, page, pageSize)
+}
@@ -0,0 +1,77 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/edosulai/pt-xyz-multifinance/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockTransactionRepository struct {
+	mock.Mock
+}
+
+func (m *MockTransactionRepository) Create(ctx context.Context, transaction *model.Transaction) error {
+	args := m.Called(ctx, transaction)
+	return args.Error(0)
+}
+
+func (m *MockTransactionRepository) GetUserTransactions(ctx context.Context, userID string, page, pageSize int) ([]model.Transaction, int64, error) {
+	args := m.Called(ctx, userID, page, pageSize)
+	return args.Get(0).([]model.Transaction), args.Get(1).(int64), args.Error(2)
+}
+
+func TestCreateTransaction(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("priced with flat interest", func(t *testing.T) {
+		userRepo := new(MockUserRepository)
+		limitRepo := new(MockCreditLimitRepository)
+		transactionRepo := new(MockTransactionRepository)
+		userRepo.On("GetByID", ctx, "user-1").Return(&model.User{ID: "user-1"}, nil)
+		limitRepo.On("GetByUserAndTenure", ctx, "user-1", 3).
+			Return(&model.CreditLimit{TenureMonths: 3, LimitAmount: model.NewMoney(5000000)}, nil)
+		transactionRepo.On("Create", ctx, mock.AnythingOfType("*model.Transaction")).Return(nil)
+
+		uc := NewTransactionUseCase(transactionRepo, limitRepo, userRepo, 24)
+		transaction := &model.Transaction{
+			UserID:       "user-1",
+			TenureMonths: 3,
+			OTRPrice:     model.NewMoney(3000000),
+			AdminFee:     model.NewMoney(50000),
+			AssetName:    " Smartphone X ",
+			SalesChannel: "ecommerce",
+		}
+
+		err := uc.CreateTransaction(ctx, transaction)
+
+		assert.NoError(t, err)
+		assert.Equal(t, model.NewMoney(180000), transaction.InterestAmount)
+		assert.Equal(t, model.NewMoney(1060000), transaction.InstallmentAmount)
+		assert.Equal(t, "Smartphone X", transaction.AssetName)
+		transactionRepo.AssertExpectations(t)
+	})
+
+	t.Run("OTR price above remaining limit", func(t *testing.T) {
+		userRepo := new(MockUserRepository)
+		limitRepo := new(MockCreditLimitRepository)
+		transactionRepo := new(MockTransactionRepository)
+		userRepo.On("GetByID", ctx, "user-1").Return(&model.User{ID: "user-1"}, nil)
+		limitRepo.On("GetByUserAndTenure", ctx, "user-1", 6).
+			Return(&model.CreditLimit{TenureMonths: 6, LimitAmount: model.NewMoney(5000000), UsedAmount: model.NewMoney(4000000)}, nil)
+
+		uc := NewTransactionUseCase(transactionRepo, limitRepo, userRepo, 24)
+		err := uc.CreateTransaction(ctx, &model.Transaction{
+			UserID:       "user-1",
+			TenureMonths: 6,
+			OTRPrice:     model.NewMoney(2000000),
+			AssetName:    "Motorcycle",
+			SalesChannel: "dealer",
+		})
+
+		assert.ErrorAs(t, err, &ValidationError{})
+		transactionRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/dchest/captcha"
 	"github.com/edosulai/pt-xyz-multifinance/internal/model"
 	"github.com/edosulai/pt-xyz-multifinance/internal/repo"
+	"github.com/edosulai/pt-xyz-multifinance/pkg/nik"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/ulule/limiter/v3"
 	"github.com/ulule/limiter/v3/drivers/store/memory"
@@ -94,6 +95,11 @@ func (u *userUseCase) Register(ctx context.Context, user *model.User) error {
 		return err
 	}
 
+	// Validate the NIK and take the date of birth and gender from it
+	if err := applyNIK(user); err != nil {
+		return err
+	}
+
 	// Check if username exists
 	existingUser, _ := u.userRepo.GetByUsername(ctx, user.Username)
 	if existingUser != nil {
@@ -153,6 +159,7 @@ func (u *userUseCase) Login(ctx context.Context, username, password string) (str
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
 		"user_id":  user.ID,
 		"username": user.Username,
+		"role":     string(user.Role),
 		"exp":      time.Now().Add(u.jwtDuration).Unix(),
 	})
 
@@ -229,6 +236,15 @@ func (u *userUseCase) UpdateProfile(ctx context.Context, user *model.User) error
 		user.Password = existingUser.Password
 	}
 
+	// Validate a changed NIK, keeping the existing one and what it encodes otherwise
+	if user.KTPNumber == "" || strings.TrimSpace(user.KTPNumber) == existingUser.KTPNumber {
+		user.KTPNumber = existingUser.KTPNumber
+		user.DateOfBirth = existingUser.DateOfBirth
+		user.Gender = existingUser.Gender
+	} else if err := applyNIK(user); err != nil {
+		return err
+	}
+
 	// Preserve certain fields from existing user
 	user.CreatedAt = existingUser.CreatedAt
 	user.UpdatedAt = time.Now()
@@ -246,6 +262,7 @@ func (u *userUseCase) generateTokens(user *model.User) (string, string, error) {
 	claims := jwt.MapClaims{
 		"user_id":    user.ID,
 		"username":   user.Username,
+		"role":       string(user.Role),
 		"exp":        time.Now().Add(u.jwtDuration).Unix(),
 		"iat":        time.Now().Unix(),
 		"token_type": "access",
@@ -274,6 +291,22 @@ func (u *userUseCase) generateTokens(user *model.User) (string, string, error) {
 	return accessToken, refreshToken, nil
 }
 
+// applyNIK validates the user's KTP number and sets the date of birth and gender it encodes
+func applyNIK(user *model.User) error {
+	user.KTPNumber = strings.TrimSpace(user.KTPNumber)
+	parsed, err := nik.Parse(user.KTPNumber)
+	if err != nil {
+		return NewValidationError(err.Error())
+	}
+
+	user.DateOfBirth = &parsed.BirthDate
+	user.Gender = model.GenderMale
+	if parsed.Gender == nik.Female {
+		user.Gender = model.GenderFemale
+	}
+	return nil
+}
+
 // validatePassword checks password strength requirements
 func validatePassword(password string) error {
 	if len(password) < 8 {
@@ -61,9 +61,10 @@ func TestUserUseCase_Register(t *testing.T) {
 	assert.NoError(t, err)
 	t.Run("successful registration", func(t *testing.T) {
 		user := &model.User{
-			Username: "testuser",
-			Password: "Test@123", // Contains uppercase, number, and special char
-			Email:    "test@example.com",
+			Username:  "testuser",
+			Password:  "Test@123", // Contains uppercase, number, and special char
+			Email:     "test@example.com",
+			KTPNumber: "3174051708900001",
 		}
 
 		mockRepo.On("GetByUsername", mock.Anything, "testuser").Return(nil, nil)
@@ -274,6 +275,7 @@ func TestUserUseCase_UpdateProfile(t *testing.T) {
 		existingUser := &model.User{
 			ID:        "1",
 			Username:  "testuser",
+			KTPNumber: "3174051708900001",
 			Password:  "oldhash",
 			CreatedAt: time.Now().Add(-24 * time.Hour),
 		}
@@ -310,6 +312,7 @@ func TestUserUseCase_UpdateProfile(t *testing.T) {
 		existingUser := &model.User{
 			ID:        "1",
 			Username:  "testuser",
+			KTPNumber: "3174051708900001",
 			Password:  "oldhash",
 			CreatedAt: time.Now().Add(-24 * time.Hour),
 		}
@@ -356,6 +359,7 @@ func TestUserUseCase_UpdateProfile(t *testing.T) {
 		existingUser := &model.User{
 			ID:        "1",
 			Username:  "testuser",
+			KTPNumber: "3174051708900001",
 			Email:     "old@example.com",
 			Password:  "oldhash",
 			CreatedAt: time.Now().Add(-24 * time.Hour),
@@ -379,6 +383,7 @@ func TestUserUseCase_UpdateProfile(t *testing.T) {
 		existingUser := &model.User{
 			ID:        "1",
 			Username:  "testuser",
+			KTPNumber: "3174051708900001",
 			Password:  "oldhash",
 			CreatedAt: time.Now().Add(-24 * time.Hour),
 		}
@@ -398,6 +403,79 @@ func TestUserUseCase_UpdateProfile(t *testing.T) {
 	})
 }
 
+func TestUserUseCase_NIK(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("register derives date of birth and gender", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		mockRepo.On("GetByUsername", ctx, "siti").Return(nil, nil)
+		mockRepo.On("GetByEmail", ctx, "siti@example.com").Return(nil, nil)
+		mockRepo.On("Create", ctx, mock.AnythingOfType("*model.User")).Return(nil)
+		useCase, err := NewUserUseCase(mockRepo, "test-secret", time.Hour, WithoutRateLimiting())
+		assert.NoError(t, err)
+
+		user := &model.User{Username: "siti", Password: "Test@123", Email: "siti@example.com", KTPNumber: "3273015703950002"}
+		err = useCase.Register(ctx, user)
+
+		assert.NoError(t, err)
+		assert.Equal(t, time.Date(1995, 3, 17, 0, 0, 0, 0, time.UTC), *user.DateOfBirth)
+		assert.Equal(t, model.GenderFemale, user.Gender)
+	})
+
+	t.Run("register rejects invalid NIK", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		useCase, err := NewUserUseCase(mockRepo, "test-secret", time.Hour, WithoutRateLimiting())
+		assert.NoError(t, err)
+
+		for _, number := range []string{"", "1234567890123456", "3273013102950002"} {
+			user := &model.User{Username: "siti", Password: "Test@123", Email: "siti@example.com", KTPNumber: number}
+			err = useCase.Register(ctx, user)
+
+			assert.ErrorAs(t, err, &ValidationError{}, number)
+		}
+		mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("update profile with new NIK", func(t *testing.T) {
+		existingUser := &model.User{ID: "1", Username: "budi", KTPNumber: "3174051708900001"}
+		mockRepo := new(MockUserRepository)
+		mockRepo.On("GetByID", ctx, "1").Return(existingUser, nil)
+		mockRepo.On("Update", ctx, mock.AnythingOfType("*model.User")).Return(nil)
+		useCase, err := NewUserUseCase(mockRepo, "test-secret", time.Hour, WithoutRateLimiting())
+		assert.NoError(t, err)
+
+		user := &model.User{ID: "1", Username: "budi", KTPNumber: "3578100101050003"}
+		assert.NoError(t, useCase.UpdateProfile(ctx, user))
+		assert.Equal(t, time.Date(2005, 1, 1, 0, 0, 0, 0, time.UTC), *user.DateOfBirth)
+		assert.Equal(t, model.GenderMale, user.Gender)
+
+		user = &model.User{ID: "1", Username: "budi"}
+		assert.NoError(t, useCase.UpdateProfile(ctx, user))
+		assert.Equal(t, "3174051708900001", user.KTPNumber)
+
+		user = &model.User{ID: "1", Username: "budi", KTPNumber: "3199051708900001"}
+		assert.ErrorAs(t, useCase.UpdateProfile(ctx, user), &ValidationError{})
+		mockRepo.AssertNumberOfCalls(t, "Update", 2)
+	})
+
+	t.Run("update profile keeps a NIK stored before validation", func(t *testing.T) {
+		existingUser := &model.User{ID: "1", Username: "budi", KTPNumber: "1234567890123456"}
+		mockRepo := new(MockUserRepository)
+		mockRepo.On("GetByID", ctx, "1").Return(existingUser, nil)
+		mockRepo.On("Update", ctx, mock.AnythingOfType("*model.User")).Return(nil)
+		useCase, err := NewUserUseCase(mockRepo, "test-secret", time.Hour, WithoutRateLimiting())
+		assert.NoError(t, err)
+
+		user := &model.User{ID: "1", Username: "budi", Email: "budi@example.com"}
+		assert.NoError(t, useCase.UpdateProfile(ctx, user))
+		assert.Equal(t, "1234567890123456", user.KTPNumber)
+
+		user = &model.User{ID: "1", Username: "budi", KTPNumber: "1234567890123456"}
+		assert.NoError(t, useCase.UpdateProfile(ctx, user))
+		mockRepo.AssertNumberOfCalls(t, "Update", 2)
+	})
+}
+
 func TestUserUseCase_ValidateCaptcha(t *testing.T) {
 	useCase, err := NewUserUseCase(nil, "test-secret", time.Hour)
 	assert.NoError(t, err)
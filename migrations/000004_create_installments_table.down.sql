@@ -0,0 +1,7 @@
+-- Drop indexes first
+DROP INDEX IF EXISTS idx_installments_loan_number;
+DROP INDEX IF EXISTS idx_installments_due_date;
+DROP INDEX IF EXISTS idx_installments_loan_id;
+
+-- Drop table
+DROP TABLE IF EXISTS installments;
@@ -0,0 +1,22 @@
+-- Create installments table
+CREATE TABLE IF NOT EXISTS installments (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    loan_id UUID NOT NULL REFERENCES loans(id),
+    installment_number INTEGER NOT NULL,
+    due_date DATE NOT NULL,
+    principal_amount DECIMAL(15,2) NOT NULL,
+    interest_amount DECIMAL(15,2) NOT NULL,
+    total_amount DECIMAL(15,2) NOT NULL,
+    remaining_balance DECIMAL(15,2) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP,
+    CONSTRAINT chk_installment_number CHECK (installment_number > 0)
+);
+
+-- Create indexes
+CREATE INDEX IF NOT EXISTS idx_installments_loan_id ON installments(loan_id);
+CREATE INDEX IF NOT EXISTS idx_installments_due_date ON installments(due_date);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_installments_loan_number
+    ON installments(loan_id, installment_number) WHERE deleted_at IS NULL;
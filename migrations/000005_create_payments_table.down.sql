@@ -0,0 +1,20 @@
+-- Drop indexes first
+DROP INDEX IF EXISTS idx_payment_allocations_installment_id;
+DROP INDEX IF EXISTS idx_payment_allocations_payment_id;
+DROP INDEX IF EXISTS idx_payments_loan_reference;
+DROP INDEX IF EXISTS idx_payments_loan_id;
+
+-- Drop tables in reverse order
+DROP TABLE IF EXISTS payment_allocations;
+DROP TABLE IF EXISTS payments;
+
+-- Remove columns
+ALTER TABLE installments
+    DROP COLUMN IF EXISTS paid_at,
+    DROP COLUMN IF EXISTS principal_paid,
+    DROP COLUMN IF EXISTS interest_paid,
+    DROP COLUMN IF EXISTS fee_paid,
+    DROP COLUMN IF EXISTS fee_amount;
+
+ALTER TABLE loans
+    DROP COLUMN IF EXISTS outstanding_balance;
@@ -0,0 +1,42 @@
+-- Track outstanding principal on loans
+ALTER TABLE loans
+    ADD COLUMN IF NOT EXISTS outstanding_balance DECIMAL(15,2) NOT NULL DEFAULT 0;
+
+-- Track paid components on installments
+ALTER TABLE installments
+    ADD COLUMN IF NOT EXISTS fee_amount DECIMAL(15,2) NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS fee_paid DECIMAL(15,2) NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS interest_paid DECIMAL(15,2) NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS principal_paid DECIMAL(15,2) NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS paid_at TIMESTAMP;
+
+-- Create payments table
+CREATE TABLE IF NOT EXISTS payments (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    loan_id UUID NOT NULL REFERENCES loans(id),
+    amount DECIMAL(15,2) NOT NULL,
+    payment_method VARCHAR(50) NOT NULL,
+    reference VARCHAR(100),
+    paid_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP,
+    CONSTRAINT chk_payment_amount CHECK (amount > 0)
+);
+
+-- Create payment allocations table
+CREATE TABLE IF NOT EXISTS payment_allocations (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    payment_id UUID NOT NULL REFERENCES payments(id),
+    installment_id UUID NOT NULL REFERENCES installments(id),
+    component VARCHAR(20) NOT NULL,
+    amount DECIMAL(15,2) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create indexes
+CREATE INDEX IF NOT EXISTS idx_payments_loan_id ON payments(loan_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_payments_loan_reference
+    ON payments(loan_id, reference) WHERE reference IS NOT NULL AND deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_payment_allocations_payment_id ON payment_allocations(payment_id);
+CREATE INDEX IF NOT EXISTS idx_payment_allocations_installment_id ON payment_allocations(installment_id);
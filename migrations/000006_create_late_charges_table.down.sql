@@ -0,0 +1,6 @@
+-- Drop indexes first
+DROP INDEX IF EXISTS idx_late_charges_installment_date;
+DROP INDEX IF EXISTS idx_late_charges_loan_id;
+
+-- Drop table
+DROP TABLE IF EXISTS late_charges;
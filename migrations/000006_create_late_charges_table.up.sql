@@ -0,0 +1,17 @@
+-- Create late charges table
+CREATE TABLE IF NOT EXISTS late_charges (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    loan_id UUID NOT NULL REFERENCES loans(id),
+    installment_id UUID NOT NULL REFERENCES installments(id),
+    accrual_date DATE NOT NULL,
+    days_past_due INTEGER NOT NULL,
+    overdue_amount DECIMAL(15,2) NOT NULL,
+    amount DECIMAL(15,2) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT chk_late_charge_amount CHECK (amount >= 0)
+);
+
+-- Create indexes
+CREATE INDEX IF NOT EXISTS idx_late_charges_loan_id ON late_charges(loan_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_late_charges_installment_date
+    ON late_charges(installment_id, accrual_date);
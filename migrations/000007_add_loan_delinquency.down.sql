@@ -0,0 +1,12 @@
+-- Drop indexes first
+DROP INDEX IF EXISTS idx_loan_delinquency_history_loan_id;
+DROP INDEX IF EXISTS idx_loans_collectibility;
+
+-- Drop table
+DROP TABLE IF EXISTS loan_delinquency_history;
+
+-- Remove columns from loans table
+ALTER TABLE loans
+    DROP CONSTRAINT IF EXISTS chk_collectibility,
+    DROP COLUMN IF EXISTS collectibility,
+    DROP COLUMN IF EXISTS days_past_due;
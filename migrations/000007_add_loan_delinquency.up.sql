@@ -0,0 +1,22 @@
+-- Track days past due and OJK collectibility on loans
+ALTER TABLE loans
+    ADD COLUMN IF NOT EXISTS days_past_due INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS collectibility SMALLINT NOT NULL DEFAULT 1,
+    ADD CONSTRAINT chk_collectibility CHECK (collectibility BETWEEN 1 AND 5);
+
+-- Create delinquency history table
+CREATE TABLE IF NOT EXISTS loan_delinquency_history (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    loan_id UUID NOT NULL REFERENCES loans(id),
+    as_of_date DATE NOT NULL,
+    days_past_due INTEGER NOT NULL,
+    previous_collectibility SMALLINT NOT NULL,
+    collectibility SMALLINT NOT NULL,
+    previous_status VARCHAR(20) NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create indexes
+CREATE INDEX IF NOT EXISTS idx_loans_collectibility ON loans(collectibility);
+CREATE INDEX IF NOT EXISTS idx_loan_delinquency_history_loan_id ON loan_delinquency_history(loan_id);
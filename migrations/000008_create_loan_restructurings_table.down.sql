@@ -0,0 +1,9 @@
+-- Drop indexes first
+DROP INDEX IF EXISTS idx_loan_restructurings_loan_id;
+
+-- Remove columns from installments table
+ALTER TABLE installments
+    DROP COLUMN IF EXISTS restructuring_id;
+
+-- Drop table
+DROP TABLE IF EXISTS loan_restructurings;
@@ -0,0 +1,24 @@
+-- Create loan restructurings table
+CREATE TABLE IF NOT EXISTS loan_restructurings (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    loan_id UUID NOT NULL REFERENCES loans(id),
+    previous_status VARCHAR(20) NOT NULL,
+    previous_tenure_months INTEGER NOT NULL,
+    previous_interest_rate DECIMAL(5,2) NOT NULL,
+    previous_monthly_payment DECIMAL(15,2),
+    outstanding_principal DECIMAL(15,2) NOT NULL,
+    capitalized_amount DECIMAL(15,2) NOT NULL DEFAULT 0,
+    new_tenure_months INTEGER NOT NULL,
+    new_interest_rate DECIMAL(5,2) NOT NULL,
+    grace_period_months INTEGER NOT NULL DEFAULT 0,
+    new_monthly_payment DECIMAL(15,2) NOT NULL,
+    reason TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Link installments to the restructuring that produced them
+ALTER TABLE installments
+    ADD COLUMN IF NOT EXISTS restructuring_id UUID REFERENCES loan_restructurings(id);
+
+-- Create indexes
+CREATE INDEX IF NOT EXISTS idx_loan_restructurings_loan_id ON loan_restructurings(loan_id);
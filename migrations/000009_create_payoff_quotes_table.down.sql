@@ -0,0 +1,5 @@
+-- Drop indexes first
+DROP INDEX IF EXISTS idx_payoff_quotes_loan_id;
+
+-- Drop table
+DROP TABLE IF EXISTS payoff_quotes;
@@ -0,0 +1,20 @@
+-- Create payoff quotes table
+CREATE TABLE IF NOT EXISTS payoff_quotes (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    loan_id UUID NOT NULL REFERENCES loans(id),
+    outstanding_principal DECIMAL(15,2) NOT NULL,
+    accrued_interest DECIMAL(15,2) NOT NULL DEFAULT 0,
+    outstanding_fees DECIMAL(15,2) NOT NULL DEFAULT 0,
+    early_termination_fee DECIMAL(15,2) NOT NULL DEFAULT 0,
+    total_amount DECIMAL(15,2) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'active',
+    quoted_at TIMESTAMP NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    payment_id UUID REFERENCES payments(id),
+    settled_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create indexes
+CREATE INDEX IF NOT EXISTS idx_payoff_quotes_loan_id ON payoff_quotes(loan_id);
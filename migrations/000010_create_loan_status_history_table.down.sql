@@ -0,0 +1,9 @@
+-- Drop indexes first
+DROP INDEX IF EXISTS idx_loan_status_history_loan_id;
+
+-- Drop table
+DROP TABLE IF EXISTS loan_status_history;
+
+-- Remove role from users
+ALTER TABLE users
+    DROP COLUMN IF EXISTS role;
@@ -0,0 +1,18 @@
+-- Add role to users
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS role VARCHAR(20) NOT NULL DEFAULT 'customer';
+
+-- Create loan status history table
+CREATE TABLE IF NOT EXISTS loan_status_history (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    loan_id UUID NOT NULL REFERENCES loans(id),
+    from_status VARCHAR(20) NOT NULL,
+    to_status VARCHAR(20) NOT NULL,
+    actor_id UUID REFERENCES users(id),
+    actor_role VARCHAR(20) NOT NULL,
+    reason TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create indexes
+CREATE INDEX IF NOT EXISTS idx_loan_status_history_loan_id ON loan_status_history(loan_id);
@@ -0,0 +1,7 @@
+-- Drop indexes first
+DROP INDEX IF EXISTS idx_credit_limit_usages_loan_id;
+DROP INDEX IF EXISTS idx_credit_limits_user_tenure;
+
+-- Drop tables
+DROP TABLE IF EXISTS credit_limit_usages;
+DROP TABLE IF EXISTS credit_limits;
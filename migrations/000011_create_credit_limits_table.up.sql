@@ -0,0 +1,26 @@
+-- Create credit limits table
+CREATE TABLE IF NOT EXISTS credit_limits (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id),
+    tenure_months INTEGER NOT NULL,
+    limit_amount DECIMAL(15,2) NOT NULL,
+    used_amount DECIMAL(15,2) NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT chk_credit_limit_amount CHECK (limit_amount >= 0),
+    CONSTRAINT chk_credit_limit_used CHECK (used_amount >= 0)
+);
+
+-- Create credit limit usages table
+CREATE TABLE IF NOT EXISTS credit_limit_usages (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    credit_limit_id UUID NOT NULL REFERENCES credit_limits(id),
+    loan_id UUID NOT NULL REFERENCES loans(id),
+    amount DECIMAL(15,2) NOT NULL,
+    released_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create indexes
+CREATE UNIQUE INDEX IF NOT EXISTS idx_credit_limits_user_tenure ON credit_limits(user_id, tenure_months);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_credit_limit_usages_loan_id ON credit_limit_usages(loan_id);
@@ -0,0 +1,16 @@
+-- Drop indexes first
+DROP INDEX IF EXISTS idx_credit_limit_usages_transaction_id;
+DROP INDEX IF EXISTS idx_transactions_user_id;
+
+-- Remove transaction usages from credit limit usages
+DELETE FROM credit_limit_usages WHERE transaction_id IS NOT NULL;
+ALTER TABLE credit_limit_usages
+    DROP CONSTRAINT IF EXISTS chk_credit_limit_usage_owner,
+    DROP COLUMN IF EXISTS transaction_id,
+    ALTER COLUMN loan_id SET NOT NULL;
+
+-- Drop table
+DROP TABLE IF EXISTS transactions;
+
+-- Drop sequence
+DROP SEQUENCE IF EXISTS transaction_contract_seq;
@@ -0,0 +1,31 @@
+-- Create contract number sequence
+CREATE SEQUENCE IF NOT EXISTS transaction_contract_seq;
+
+-- Create transactions table
+CREATE TABLE IF NOT EXISTS transactions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id),
+    contract_number VARCHAR(30) NOT NULL UNIQUE,
+    tenure_months INTEGER NOT NULL,
+    otr_price DECIMAL(15,2) NOT NULL,
+    admin_fee DECIMAL(15,2) NOT NULL DEFAULT 0,
+    installment_amount DECIMAL(15,2) NOT NULL,
+    interest_amount DECIMAL(15,2) NOT NULL,
+    asset_name VARCHAR(255) NOT NULL,
+    sales_channel VARCHAR(50) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP,
+    CONSTRAINT chk_transaction_otr_price CHECK (otr_price > 0),
+    CONSTRAINT chk_transaction_admin_fee CHECK (admin_fee >= 0)
+);
+
+-- Allow credit limit usages to belong to a transaction instead of a loan
+ALTER TABLE credit_limit_usages
+    ALTER COLUMN loan_id DROP NOT NULL,
+    ADD COLUMN IF NOT EXISTS transaction_id UUID REFERENCES transactions(id),
+    ADD CONSTRAINT chk_credit_limit_usage_owner CHECK ((loan_id IS NULL) <> (transaction_id IS NULL));
+
+-- Create indexes
+CREATE INDEX IF NOT EXISTS idx_transactions_user_id ON transactions(user_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_credit_limit_usages_transaction_id ON credit_limit_usages(transaction_id);
@@ -0,0 +1,3 @@
+ALTER TABLE loans
+    DROP COLUMN IF EXISTS affordability_flagged,
+    DROP COLUMN IF EXISTS dti_ratio;
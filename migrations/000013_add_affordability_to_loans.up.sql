@@ -0,0 +1,4 @@
+-- Add debt-to-income assessment to loans
+ALTER TABLE loans
+    ADD COLUMN IF NOT EXISTS dti_ratio DECIMAL(6,2) NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS affordability_flagged BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,9 @@
+-- Drop indexes first
+DROP INDEX IF EXISTS idx_loans_risk_grade;
+
+ALTER TABLE loans
+    DROP COLUMN IF EXISTS scored_at,
+    DROP COLUMN IF EXISTS scoring_model,
+    DROP COLUMN IF EXISTS score_reason_codes,
+    DROP COLUMN IF EXISTS risk_grade,
+    DROP COLUMN IF EXISTS credit_score;
@@ -0,0 +1,10 @@
+-- Add credit scoring results to loans
+ALTER TABLE loans
+    ADD COLUMN IF NOT EXISTS credit_score INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS risk_grade VARCHAR(1) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS score_reason_codes TEXT[],
+    ADD COLUMN IF NOT EXISTS scoring_model VARCHAR(50) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS scored_at TIMESTAMP;
+
+-- Create indexes
+CREATE INDEX IF NOT EXISTS idx_loans_risk_grade ON loans(risk_grade);
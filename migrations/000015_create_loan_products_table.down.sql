@@ -0,0 +1,13 @@
+-- Restore the original bounds on loans
+ALTER TABLE loans
+    DROP CONSTRAINT IF EXISTS chk_amount,
+    DROP CONSTRAINT IF EXISTS chk_tenure,
+    DROP COLUMN IF EXISTS product_code,
+    ADD CONSTRAINT chk_amount CHECK (amount >= 1000000),
+    ADD CONSTRAINT chk_tenure CHECK (tenure_months BETWEEN 6 AND 60);
+
+-- Drop indexes first
+DROP INDEX IF EXISTS idx_loan_products_code;
+
+-- Drop table
+DROP TABLE IF EXISTS loan_products;
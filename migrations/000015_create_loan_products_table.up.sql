@@ -0,0 +1,45 @@
+-- Create loan products table
+CREATE TABLE IF NOT EXISTS loan_products (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    code VARCHAR(30) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    description TEXT,
+    min_amount DECIMAL(15,2) NOT NULL,
+    max_amount DECIMAL(15,2) NOT NULL,
+    tenure_options INTEGER[] NOT NULL,
+    interest_method VARCHAR(20) NOT NULL DEFAULT 'annuity',
+    min_interest_rate DECIMAL(5,2) NOT NULL,
+    max_interest_rate DECIMAL(5,2) NOT NULL,
+    admin_fee DECIMAL(15,2) NOT NULL DEFAULT 0,
+    provision_fee_percent DECIMAL(5,2) NOT NULL DEFAULT 0,
+    required_documents TEXT[] NOT NULL DEFAULT '{}',
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP,
+    CONSTRAINT chk_loan_product_amount CHECK (min_amount > 0 AND max_amount >= min_amount),
+    CONSTRAINT chk_loan_product_rate CHECK (min_interest_rate >= 0 AND max_interest_rate >= min_interest_rate),
+    CONSTRAINT chk_loan_product_interest_method CHECK (interest_method IN ('annuity', 'flat', 'effective'))
+);
+
+-- Create indexes
+CREATE UNIQUE INDEX IF NOT EXISTS idx_loan_products_code ON loan_products(code);
+
+-- Seed the personal loan that matches the previously hard-coded bounds
+INSERT INTO loan_products (
+    code, name, description, min_amount, max_amount, tenure_options,
+    interest_method, min_interest_rate, max_interest_rate, required_documents
+) VALUES (
+    'PERSONAL', 'Personal Loan', 'Multipurpose personal loan', 1000000, 500000000,
+    '{6,12,18,24,36,48,60}', 'annuity', 8, 30, '{ktp,payslip,bank_statement}'
+) ON CONFLICT DO NOTHING;
+
+-- Link loans to products; bounds are now enforced per product
+ALTER TABLE loans
+    ADD COLUMN IF NOT EXISTS product_code VARCHAR(30) REFERENCES loan_products(code),
+    DROP CONSTRAINT IF EXISTS chk_amount,
+    DROP CONSTRAINT IF EXISTS chk_tenure,
+    ADD CONSTRAINT chk_amount CHECK (amount > 0),
+    ADD CONSTRAINT chk_tenure CHECK (tenure_months > 0);
+
+UPDATE loans SET product_code = 'PERSONAL' WHERE product_code IS NULL;
@@ -0,0 +1,4 @@
+ALTER TABLE loans
+    DROP CONSTRAINT IF EXISTS chk_loan_interest_method,
+    DROP COLUMN IF EXISTS effective_annual_rate,
+    DROP COLUMN IF EXISTS interest_method;
@@ -0,0 +1,6 @@
+-- Record the interest method and effective annual rate of each loan.
+-- Loans created so far were priced as annuities.
+ALTER TABLE loans
+    ADD COLUMN IF NOT EXISTS interest_method VARCHAR(20) NOT NULL DEFAULT 'annuity',
+    ADD COLUMN IF NOT EXISTS effective_annual_rate DECIMAL(8,4) NOT NULL DEFAULT 0,
+    ADD CONSTRAINT chk_loan_interest_method CHECK (interest_method IN ('annuity', 'flat', 'effective'));
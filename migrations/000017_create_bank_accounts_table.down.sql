@@ -0,0 +1,8 @@
+-- Drop indexes first
+DROP INDEX IF EXISTS idx_disbursement_instructions_status;
+DROP INDEX IF EXISTS idx_disbursement_instructions_loan_id;
+DROP INDEX IF EXISTS idx_bank_accounts_user_account;
+
+-- Drop tables
+DROP TABLE IF EXISTS disbursement_instructions;
+DROP TABLE IF EXISTS bank_accounts;
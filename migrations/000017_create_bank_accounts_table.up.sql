@@ -0,0 +1,37 @@
+-- Create bank accounts table
+CREATE TABLE IF NOT EXISTS bank_accounts (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id),
+    bank_code VARCHAR(3) NOT NULL,
+    account_number VARCHAR(20) NOT NULL,
+    holder_name VARCHAR(100) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    rejection_reason TEXT NOT NULL DEFAULT '',
+    verified_by VARCHAR(100) NOT NULL DEFAULT '',
+    verified_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT chk_bank_account_status CHECK (status IN ('pending', 'verified', 'rejected'))
+);
+
+-- Create disbursement instructions table
+CREATE TABLE IF NOT EXISTS disbursement_instructions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    loan_id UUID NOT NULL REFERENCES loans(id),
+    bank_account_id UUID NOT NULL REFERENCES bank_accounts(id),
+    amount DECIMAL(15,2) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    bank_reference VARCHAR(100) NOT NULL DEFAULT '',
+    failure_reason TEXT NOT NULL DEFAULT '',
+    sent_at TIMESTAMP,
+    completed_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT chk_disbursement_amount CHECK (amount > 0),
+    CONSTRAINT chk_disbursement_status CHECK (status IN ('pending', 'sent', 'succeeded', 'failed'))
+);
+
+-- Create indexes
+CREATE UNIQUE INDEX IF NOT EXISTS idx_bank_accounts_user_account ON bank_accounts(user_id, bank_code, account_number);
+CREATE INDEX IF NOT EXISTS idx_disbursement_instructions_loan_id ON disbursement_instructions(loan_id);
+CREATE INDEX IF NOT EXISTS idx_disbursement_instructions_status ON disbursement_instructions(status);
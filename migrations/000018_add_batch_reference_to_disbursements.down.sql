@@ -0,0 +1,10 @@
+-- Restore one credit limit usage per loan
+DROP INDEX IF EXISTS idx_credit_limit_usages_loan_id;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_credit_limit_usages_loan_id ON credit_limit_usages(loan_id);
+
+-- Drop indexes first
+DROP INDEX IF EXISTS idx_disbursement_instructions_loan_in_progress;
+DROP INDEX IF EXISTS idx_disbursement_instructions_batch_reference;
+
+ALTER TABLE disbursement_instructions
+    DROP COLUMN IF EXISTS batch_reference;
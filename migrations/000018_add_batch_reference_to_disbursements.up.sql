@@ -0,0 +1,13 @@
+-- Track the bank batch file each instruction was sent in
+ALTER TABLE disbursement_instructions
+    ADD COLUMN IF NOT EXISTS batch_reference VARCHAR(30) NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS idx_disbursement_instructions_batch_reference ON disbursement_instructions(batch_reference);
+
+-- Only one disbursement of a loan may be in progress at a time
+CREATE UNIQUE INDEX IF NOT EXISTS idx_disbursement_instructions_loan_in_progress
+    ON disbursement_instructions(loan_id) WHERE status IN ('pending', 'sent');
+
+-- A loan whose disbursement failed releases its credit limit usage and may draw it down again
+DROP INDEX IF EXISTS idx_credit_limit_usages_loan_id;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_credit_limit_usages_loan_id ON credit_limit_usages(loan_id) WHERE released_at IS NULL;
@@ -0,0 +1,16 @@
+-- Restore one unreleased credit limit usage per loan
+DROP INDEX IF EXISTS idx_credit_limit_usages_disbursement_instruction_id;
+DROP INDEX IF EXISTS idx_credit_limit_usages_loan_id;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_credit_limit_usages_loan_id ON credit_limit_usages(loan_id) WHERE released_at IS NULL;
+
+ALTER TABLE credit_limit_usages
+    DROP COLUMN IF EXISTS disbursement_instruction_id;
+
+-- Drop indexes first
+DROP INDEX IF EXISTS idx_disbursements_instruction_id;
+DROP INDEX IF EXISTS idx_disbursements_loan_tranche;
+
+DROP TABLE IF EXISTS disbursements;
+
+-- PostgreSQL cannot drop an enum value; partially disbursed loans fall back to disbursed
+UPDATE loans SET status = 'disbursed' WHERE status = 'partially_disbursed';
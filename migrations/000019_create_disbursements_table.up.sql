@@ -0,0 +1,28 @@
+-- Loans released in tranches stay partially disbursed until the full amount has landed
+ALTER TYPE loan_status ADD VALUE IF NOT EXISTS 'partially_disbursed' AFTER 'approved';
+
+-- Create disbursements table, one row per tranche that reached the borrower
+CREATE TABLE IF NOT EXISTS disbursements (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    loan_id UUID NOT NULL REFERENCES loans(id),
+    disbursement_instruction_id UUID NOT NULL REFERENCES disbursement_instructions(id),
+    tranche_number INTEGER NOT NULL,
+    amount DECIMAL(15,2) NOT NULL,
+    disbursed_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT chk_disbursement_tranche_number CHECK (tranche_number > 0),
+    CONSTRAINT chk_disbursement_tranche_amount CHECK (amount > 0)
+);
+
+-- Create indexes
+CREATE UNIQUE INDEX IF NOT EXISTS idx_disbursements_loan_tranche ON disbursements(loan_id, tranche_number);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_disbursements_instruction_id ON disbursements(disbursement_instruction_id);
+
+-- Each tranche reserves its own share of the credit limit, so usages are tracked per instruction
+ALTER TABLE credit_limit_usages
+    ADD COLUMN IF NOT EXISTS disbursement_instruction_id UUID REFERENCES disbursement_instructions(id);
+
+DROP INDEX IF EXISTS idx_credit_limit_usages_loan_id;
+CREATE INDEX IF NOT EXISTS idx_credit_limit_usages_loan_id ON credit_limit_usages(loan_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_credit_limit_usages_disbursement_instruction_id
+    ON credit_limit_usages(disbursement_instruction_id);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_loans_disbursed_at;
+DROP INDEX IF EXISTS idx_loans_product_code;
+DROP INDEX IF EXISTS idx_loans_status_created_at_id;
+DROP INDEX IF EXISTS idx_loans_amount_id;
+DROP INDEX IF EXISTS idx_loans_created_at_id;
@@ -0,0 +1,6 @@
+-- Support keyset pagination of the back-office loan search
+CREATE INDEX IF NOT EXISTS idx_loans_created_at_id ON loans(created_at, id) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_loans_amount_id ON loans(amount, id) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_loans_status_created_at_id ON loans(status, created_at, id) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_loans_product_code ON loans(product_code);
+CREATE INDEX IF NOT EXISTS idx_loans_disbursed_at ON loans(disbursed_at) WHERE disbursed_at IS NOT NULL;
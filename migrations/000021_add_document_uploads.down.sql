@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_documents_storage_key;
+
+ALTER TABLE documents
+    DROP COLUMN IF EXISTS sha256,
+    DROP COLUMN IF EXISTS size_bytes,
+    DROP COLUMN IF EXISTS content_type,
+    DROP COLUMN IF EXISTS storage_key;
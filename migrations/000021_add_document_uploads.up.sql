@@ -0,0 +1,8 @@
+-- Reference uploaded files in the blob store instead of client-supplied URLs
+ALTER TABLE documents
+    ADD COLUMN IF NOT EXISTS storage_key VARCHAR(255),
+    ADD COLUMN IF NOT EXISTS content_type VARCHAR(100),
+    ADD COLUMN IF NOT EXISTS size_bytes BIGINT,
+    ADD COLUMN IF NOT EXISTS sha256 CHAR(64);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_documents_storage_key ON documents(storage_key) WHERE storage_key IS NOT NULL;
@@ -0,0 +1,8 @@
+ALTER TABLE documents
+    DROP COLUMN IF EXISTS reviewed_at,
+    DROP COLUMN IF EXISTS reviewed_by,
+    DROP COLUMN IF EXISTS review_note,
+    DROP COLUMN IF EXISTS reason_code;
+
+-- PostgreSQL cannot drop an enum value; loans waiting for documents go back to review
+UPDATE loans SET status = 'in_review' WHERE status = 'documents_needed';
@@ -0,0 +1,9 @@
+-- Loans wait in documents_needed while the applicant re-uploads rejected documents
+ALTER TYPE loan_status ADD VALUE IF NOT EXISTS 'documents_needed' AFTER 'in_review';
+
+-- Record the analyst review of each document
+ALTER TABLE documents
+    ADD COLUMN IF NOT EXISTS reason_code VARCHAR(30),
+    ADD COLUMN IF NOT EXISTS review_note TEXT,
+    ADD COLUMN IF NOT EXISTS reviewed_by UUID REFERENCES users(id),
+    ADD COLUMN IF NOT EXISTS reviewed_at TIMESTAMP;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_documents_open_slot;
+
+ALTER TABLE documents DROP COLUMN IF EXISTS required;
@@ -0,0 +1,8 @@
+-- Checklist slots created when a loan is applied for keep this flag once they are filled
+ALTER TABLE documents ADD COLUMN IF NOT EXISTS required BOOLEAN NOT NULL DEFAULT false;
+
+UPDATE documents SET required = true WHERE status = 'required';
+
+-- A loan has at most one open slot per document type; submissions fill it instead of adding a row
+CREATE UNIQUE INDEX IF NOT EXISTS idx_documents_open_slot ON documents(loan_id, type)
+    WHERE status = 'required' AND deleted_at IS NULL;
@@ -0,0 +1,4 @@
+-- Derived from the NIK when a user registers or changes their KTP number
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS date_of_birth DATE,
+    ADD COLUMN IF NOT EXISTS gender VARCHAR(10) CHECK (gender IN ('male', 'female'));
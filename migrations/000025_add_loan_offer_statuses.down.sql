@@ -0,0 +1,4 @@
+-- PostgreSQL cannot drop an enum value; open offers fall back to approved and declined
+-- offers to rejected
+UPDATE loans SET status = 'approved' WHERE status = 'offered';
+UPDATE loans SET status = 'rejected' WHERE status = 'declined';
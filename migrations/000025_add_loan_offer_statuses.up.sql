@@ -0,0 +1,3 @@
+-- Approved loans are offered to the applicant, who accepts or declines the final terms
+ALTER TYPE loan_status ADD VALUE IF NOT EXISTS 'offered' AFTER 'documents_needed';
+ALTER TYPE loan_status ADD VALUE IF NOT EXISTS 'declined' AFTER 'rejected';
@@ -0,0 +1,5 @@
+-- Drop indexes first
+DROP INDEX IF EXISTS idx_loan_offer_acceptances_loan_id;
+
+-- Drop table
+DROP TABLE IF EXISTS loan_offer_acceptances;
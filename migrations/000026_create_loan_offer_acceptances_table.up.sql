@@ -0,0 +1,19 @@
+-- Record the applicant's consent to the terms of a loan offer
+CREATE TABLE IF NOT EXISTS loan_offer_acceptances (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    loan_id UUID NOT NULL REFERENCES loans(id),
+    user_id UUID NOT NULL REFERENCES users(id),
+    -- Kept verbatim as text: terms_hash is the SHA-256 of these exact bytes
+    terms TEXT NOT NULL,
+    terms_hash CHAR(64) NOT NULL,
+    accepted_at TIMESTAMP NOT NULL,
+    ip_address VARCHAR(45),
+    user_agent TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create indexes
+CREATE UNIQUE INDEX IF NOT EXISTS idx_loan_offer_acceptances_loan_id ON loan_offer_acceptances(loan_id);
+
+-- Loans approved but not disbursed yet have no recorded consent; offer them to the applicant
+UPDATE loans SET status = 'offered' WHERE status = 'approved' AND deleted_at IS NULL;
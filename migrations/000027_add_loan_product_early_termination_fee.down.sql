@@ -0,0 +1,2 @@
+ALTER TABLE loan_products
+    DROP COLUMN IF EXISTS early_termination_fee_percent;
@@ -0,0 +1,6 @@
+-- Early termination is priced per product; existing products keep the fee previously configured globally
+ALTER TABLE loan_products
+    ADD COLUMN IF NOT EXISTS early_termination_fee_percent DECIMAL(5,2) NOT NULL DEFAULT 0
+        CHECK (early_termination_fee_percent >= 0);
+
+UPDATE loan_products SET early_termination_fee_percent = 2;
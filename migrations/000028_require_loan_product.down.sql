@@ -0,0 +1,2 @@
+ALTER TABLE loans
+    ALTER COLUMN product_code DROP NOT NULL;
@@ -0,0 +1,5 @@
+-- Every loan is booked under a product; loans from before the catalog were assigned PERSONAL
+UPDATE loans SET product_code = 'PERSONAL' WHERE product_code IS NULL;
+
+ALTER TABLE loans
+    ALTER COLUMN product_code SET NOT NULL;
@@ -0,0 +1,88 @@
+// Package bankfile writes disbursement batch files for bank host-to-host transfers and reads
+// the result files the bank returns.
+package bankfile
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Format is the layout of batch and result files agreed with the bank
+type Format string
+
+const (
+	// CSV files have a header row of column names followed by one record per line
+	CSV Format = "csv"
+	// FixedWidth files have a header record, one detail record per transfer and a trailer record
+	FixedWidth Format = "fixed_width"
+)
+
+// ParseFormat validates a format name; an empty name yields CSV
+func ParseFormat(name string) (Format, error) {
+	switch format := Format(name); format {
+	case "":
+		return CSV, nil
+	case CSV, FixedWidth:
+		return format, nil
+	default:
+		return "", fmt.Errorf("unknown bank file format %q", name)
+	}
+}
+
+// Transfer is one credit to a beneficiary account. Amount is in sen (1/100 Rupiah).
+type Transfer struct {
+	// Reference identifies the transfer in the result file
+	Reference     string
+	BankCode      string
+	AccountNumber string
+	HolderName    string
+	Amount        int64
+}
+
+// Batch is a set of transfers debited from one source account
+type Batch struct {
+	Reference     string
+	SourceAccount string
+	Date          time.Time
+	Transfers     []Transfer
+}
+
+// Total returns the sum of the transfer amounts in sen
+func (b *Batch) Total() int64 {
+	var total int64
+	for _, t := range b.Transfers {
+		total += t.Amount
+	}
+	return total
+}
+
+// Result is the outcome of one transfer as reported by the bank
+type Result struct {
+	Reference     string
+	Succeeded     bool
+	BankReference string
+	// Reason explains a failed transfer
+	Reason string
+}
+
+// formatAmount renders sen as a decimal Rupiah amount, e.g. 150000050 as "1500000.50"
+func formatAmount(sen int64) string {
+	sign := ""
+	if sen < 0 {
+		sign = "-"
+		sen = -sen
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, sen/100, sen%100)
+}
+
+// cleanText keeps a free-text field on one line and within the characters banks accept
+func cleanText(s string) string {
+	s = strings.Map(func(r rune) rune {
+		if r < 0x20 || r > 0x7e {
+			return ' '
+		}
+		return r
+	}, s)
+	return strings.Join(strings.Fields(s), " ")
+}
@@ -0,0 +1,128 @@
+package bankfile
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sampleBatch() Batch {
+	return Batch{
+		Reference:     "DISB20240115093000",
+		SourceAccount: "1234567890",
+		Date:          time.Date(2024, 1, 15, 9, 30, 0, 0, time.UTC),
+		Transfers: []Transfer{
+			{
+				Reference:     "11111111-2222-3333-4444-555555555555",
+				BankCode:      "014",
+				AccountNumber: "0987654321",
+				HolderName:    "Budi Santoso",
+				Amount:        1500000000,
+			},
+			{
+				Reference:     "66666666-7777-8888-9999-000000000000",
+				BankCode:      "008",
+				AccountNumber: "1122334455",
+				HolderName:    "Siti,\nAminah",
+				Amount:        250000050,
+			},
+		},
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	format, err := ParseFormat("")
+	assert.NoError(t, err)
+	assert.Equal(t, CSV, format)
+
+	format, err = ParseFormat("fixed_width")
+	assert.NoError(t, err)
+	assert.Equal(t, FixedWidth, format)
+
+	_, err = ParseFormat("xml")
+	assert.Error(t, err)
+}
+
+func TestWrite_CSV(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, CSV, sampleBatch()))
+
+	assert.Equal(t, "reference,bank_code,account_number,holder_name,amount\n"+
+		"11111111-2222-3333-4444-555555555555,014,0987654321,Budi Santoso,15000000.00\n"+
+		"66666666-7777-8888-9999-000000000000,008,1122334455,\"Siti, Aminah\",2500000.50\n",
+		buf.String())
+}
+
+func TestWrite_FixedWidth(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, FixedWidth, sampleBatch()))
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	require.Len(t, lines, 4)
+
+	assert.Equal(t, "HDISB20240115093000            202401151234567890          000002000000001750000050", lines[0])
+	assert.Equal(t, "D11111111-2222-3333-4444-555555555555014"+
+		"0987654321          "+
+		"BUDI SANTOSO                            "+
+		"000000001500000000", lines[1])
+	assert.Len(t, lines[2], len(lines[1]))
+	assert.Contains(t, lines[2], "SITI, AMINAH")
+	assert.Equal(t, "T000002000000001750000050", lines[3])
+}
+
+func TestWrite_InvalidTransfer(t *testing.T) {
+	batch := sampleBatch()
+	batch.Transfers[0].BankCode = "14"
+	assert.Error(t, Write(&bytes.Buffer{}, CSV, batch))
+
+	batch = sampleBatch()
+	batch.Transfers[1].Amount = 0
+	assert.Error(t, Write(&bytes.Buffer{}, FixedWidth, batch))
+
+	batch = sampleBatch()
+	batch.Reference = ""
+	assert.Error(t, Write(&bytes.Buffer{}, CSV, batch))
+}
+
+func TestReadResults_CSV(t *testing.T) {
+	input := "Reference,Status,Bank_Reference,Reason\n" +
+		"11111111-2222-3333-4444-555555555555,SUCCESS,TRX001,\n" +
+		"66666666-7777-8888-9999-000000000000,failed,,Account closed\n"
+
+	results, err := ReadResults(strings.NewReader(input), CSV)
+	require.NoError(t, err)
+	assert.Equal(t, []Result{
+		{Reference: "11111111-2222-3333-4444-555555555555", Succeeded: true, BankReference: "TRX001"},
+		{Reference: "66666666-7777-8888-9999-000000000000", Reason: "Account closed"},
+	}, results)
+
+	_, err = ReadResults(strings.NewReader("reference,status\nabc,PENDING\n"), CSV)
+	assert.Error(t, err)
+
+	_, err = ReadResults(strings.NewReader("reference,bank_reference\nabc,TRX\n"), CSV)
+	assert.Error(t, err)
+}
+
+func TestReadResults_FixedWidth(t *testing.T) {
+	input := "HDISB20240115093000            20240115\r\n" +
+		"D11111111-2222-3333-4444-555555555555S" + padRight("TRX001", 30) + "\r\n" +
+		"D66666666-7777-8888-9999-000000000000F" + padRight("", 30) + "Account closed\r\n" +
+		"T000002\r\n"
+
+	results, err := ReadResults(strings.NewReader(input), FixedWidth)
+	require.NoError(t, err)
+	assert.Equal(t, []Result{
+		{Reference: "11111111-2222-3333-4444-555555555555", Succeeded: true, BankReference: "TRX001"},
+		{Reference: "66666666-7777-8888-9999-000000000000", Reason: "Account closed"},
+	}, results)
+
+	_, err = ReadResults(strings.NewReader("D11111111-2222-3333-4444-555555555555X\n"), FixedWidth)
+	assert.Error(t, err)
+
+	_, err = ReadResults(strings.NewReader("X\n"), FixedWidth)
+	assert.Error(t, err)
+}
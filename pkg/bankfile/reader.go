@@ -0,0 +1,163 @@
+package bankfile
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Result statuses used by the bank
+const (
+	statusSuccess = "SUCCESS"
+	statusFailed  = "FAILED"
+)
+
+// Fixed-width result detail record: D, reference, S or F, bank reference, then the reason
+const (
+	resultStatusOffset        = 1 + referenceWidth
+	resultBankReferenceOffset = resultStatusOffset + 1
+	resultBankReferenceWidth  = 30
+	resultReasonOffset        = resultBankReferenceOffset + resultBankReferenceWidth
+)
+
+// ReadResults parses a result file returned by the bank
+func ReadResults(r io.Reader, format Format) ([]Result, error) {
+	switch format {
+	case CSV:
+		return readCSVResults(r)
+	case FixedWidth:
+		return readFixedWidthResults(r)
+	default:
+		return nil, fmt.Errorf("unknown bank file format %q", format)
+	}
+}
+
+// readCSVResults reads a CSV file whose header row names the reference, status, bank_reference
+// and reason columns in any order
+func readCSVResults(r io.Reader) ([]Result, error) {
+	cr := csv.NewReader(r)
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read result header: %v", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range []string{"reference", "status"} {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("result file is missing the %s column", name)
+		}
+	}
+
+	field := func(record []string, name string) string {
+		i, ok := columns[name]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	var results []Result
+	for line := 2; ; line++ {
+		record, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read result line %d: %v", line, err)
+		}
+
+		succeeded, err := parseStatus(field(record, "status"))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", line, err)
+		}
+
+		results = append(results, Result{
+			Reference:     field(record, "reference"),
+			Succeeded:     succeeded,
+			BankReference: field(record, "bank_reference"),
+			Reason:        field(record, "reason"),
+		})
+	}
+
+	return results, nil
+}
+
+// readFixedWidthResults reads the detail records of a fixed-width result file. Header and
+// trailer records are skipped.
+func readFixedWidthResults(r io.Reader) ([]Result, error) {
+	scanner := bufio.NewScanner(r)
+
+	var results []Result
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimRight(scanner.Text(), "\r")
+		if strings.TrimSpace(text) == "" {
+			continue
+		}
+
+		switch text[0] {
+		case 'H', 'T':
+			continue
+		case 'D':
+		default:
+			return nil, fmt.Errorf("line %d: unknown record type %q", line, text[0])
+		}
+
+		if len(text) < resultBankReferenceOffset {
+			return nil, fmt.Errorf("line %d: detail record is too short", line)
+		}
+
+		var succeeded bool
+		switch text[resultStatusOffset] {
+		case 'S':
+			succeeded = true
+		case 'F':
+		default:
+			return nil, fmt.Errorf("line %d: unknown status %q", line, text[resultStatusOffset])
+		}
+
+		results = append(results, Result{
+			Reference:     strings.TrimSpace(text[1:resultStatusOffset]),
+			Succeeded:     succeeded,
+			BankReference: strings.TrimSpace(slice(text, resultBankReferenceOffset, resultReasonOffset)),
+			Reason:        strings.TrimSpace(slice(text, resultReasonOffset, len(text))),
+		})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read result file: %v", err)
+	}
+
+	return results, nil
+}
+
+func parseStatus(status string) (bool, error) {
+	switch strings.ToUpper(status) {
+	case statusSuccess:
+		return true, nil
+	case statusFailed:
+		return false, nil
+	default:
+		return false, fmt.Errorf("unknown status %q", status)
+	}
+}
+
+// slice returns s[from:to] clamped to the length of s
+func slice(s string, from, to int) string {
+	if from >= len(s) {
+		return ""
+	}
+	if to > len(s) {
+		to = len(s)
+	}
+	return s[from:to]
+}
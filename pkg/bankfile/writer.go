@@ -0,0 +1,118 @@
+package bankfile
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Fixed-width field lengths of the batch file records
+const (
+	batchReferenceWidth = 30
+	referenceWidth      = 36
+	bankCodeWidth       = 3
+	accountNumberWidth  = 20
+	holderNameWidth     = 40
+	countWidth          = 6
+	amountWidth         = 18
+)
+
+// csvBatchHeader names the columns of a CSV batch file
+var csvBatchHeader = []string{"reference", "bank_code", "account_number", "holder_name", "amount"}
+
+// Write renders a batch in the given format
+func Write(w io.Writer, format Format, batch Batch) error {
+	if err := validateBatch(batch); err != nil {
+		return err
+	}
+
+	switch format {
+	case CSV:
+		return writeCSV(w, batch)
+	case FixedWidth:
+		return writeFixedWidth(w, batch)
+	default:
+		return fmt.Errorf("unknown bank file format %q", format)
+	}
+}
+
+func validateBatch(batch Batch) error {
+	if batch.Reference == "" || len(batch.Reference) > batchReferenceWidth {
+		return fmt.Errorf("batch reference must be 1 to %d characters", batchReferenceWidth)
+	}
+	if len(batch.SourceAccount) > accountNumberWidth {
+		return fmt.Errorf("source account must be at most %d characters", accountNumberWidth)
+	}
+
+	for _, t := range batch.Transfers {
+		if t.Reference == "" || len(t.Reference) > referenceWidth {
+			return fmt.Errorf("transfer reference %q must be 1 to %d characters", t.Reference, referenceWidth)
+		}
+		if len(t.BankCode) != bankCodeWidth {
+			return fmt.Errorf("transfer %s: bank code must be %d characters", t.Reference, bankCodeWidth)
+		}
+		if t.AccountNumber == "" || len(t.AccountNumber) > accountNumberWidth {
+			return fmt.Errorf("transfer %s: account number must be 1 to %d characters", t.Reference, accountNumberWidth)
+		}
+		if t.Amount <= 0 {
+			return fmt.Errorf("transfer %s: amount must be greater than 0", t.Reference)
+		}
+	}
+
+	return nil
+}
+
+func writeCSV(w io.Writer, batch Batch) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvBatchHeader); err != nil {
+		return err
+	}
+
+	for _, t := range batch.Transfers {
+		record := []string{t.Reference, t.BankCode, t.AccountNumber, cleanText(t.HolderName), formatAmount(t.Amount)}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// writeFixedWidth writes a header record (H), one detail record (D) per transfer and a trailer
+// record (T) carrying the count and total for the bank to reconcile. Amounts are in sen.
+func writeFixedWidth(w io.Writer, batch Batch) error {
+	bw := bufio.NewWriter(w)
+
+	count := len(batch.Transfers)
+	total := batch.Total()
+
+	fmt.Fprintf(bw, "H%s%s%s%0*d%0*d\n",
+		padRight(batch.Reference, batchReferenceWidth),
+		batch.Date.Format("20060102"),
+		padRight(batch.SourceAccount, accountNumberWidth),
+		countWidth, count, amountWidth, total)
+
+	for _, t := range batch.Transfers {
+		fmt.Fprintf(bw, "D%s%s%s%s%0*d\n",
+			padRight(t.Reference, referenceWidth),
+			t.BankCode,
+			padRight(t.AccountNumber, accountNumberWidth),
+			padRight(strings.ToUpper(cleanText(t.HolderName)), holderNameWidth),
+			amountWidth, t.Amount)
+	}
+
+	fmt.Fprintf(bw, "T%0*d%0*d\n", countWidth, count, amountWidth, total)
+
+	return bw.Flush()
+}
+
+// padRight pads s with spaces to width, truncating longer values
+func padRight(s string, width int) string {
+	if len(s) > width {
+		return s[:width]
+	}
+	return s + strings.Repeat(" ", width-len(s))
+}
@@ -8,13 +8,20 @@ import (
 )
 
 type Config struct {
-	Server   ServerConfig   `mapstructure:"server"`
-	Database DatabaseConfig `mapstructure:"database"`
-	Redis    RedisConfig    `mapstructure:"redis"`
-	JWT      JWTConfig      `mapstructure:"jwt"`
-	RabbitMQ RabbitMQConfig `mapstructure:"rabbitmq"`
-	Logging  LoggingConfig  `mapstructure:"logging"`
-	I18n     I18nConfig     `mapstructure:"i18n"`
+	Server        ServerConfig        `mapstructure:"server"`
+	Database      DatabaseConfig      `mapstructure:"database"`
+	Redis         RedisConfig         `mapstructure:"redis"`
+	JWT           JWTConfig           `mapstructure:"jwt"`
+	RabbitMQ      RabbitMQConfig      `mapstructure:"rabbitmq"`
+	Logging       LoggingConfig       `mapstructure:"logging"`
+	I18n          I18nConfig          `mapstructure:"i18n"`
+	Loan          LoanConfig          `mapstructure:"loan"`
+	Penalty       PenaltyConfig       `mapstructure:"penalty"`
+	Delinquency   DelinquencyConfig   `mapstructure:"delinquency"`
+	Transaction   TransactionConfig   `mapstructure:"transaction"`
+	Affordability AffordabilityConfig `mapstructure:"affordability"`
+	Disbursement  DisbursementConfig  `mapstructure:"disbursement"`
+	Document      DocumentConfig      `mapstructure:"document"`
 }
 
 type ServerConfig struct {
@@ -59,6 +66,53 @@ type I18nConfig struct {
 	AvailableLanguages []string `mapstructure:"available_languages"`
 }
 
+type LoanConfig struct {
+	PaymentAllocationOrder     []string      `mapstructure:"payment_allocation_order"`
+	PayoffQuoteValidity        time.Duration `mapstructure:"payoff_quote_validity"`
+	MaxOpenApplications        int           `mapstructure:"max_open_applications"`
+	DuplicateApplicationWindow time.Duration `mapstructure:"duplicate_application_window"`
+}
+
+type PenaltyConfig struct {
+	DailyRatePercent float64 `mapstructure:"daily_rate_percent"`
+	CapPercent       float64 `mapstructure:"cap_percent"`
+	GracePeriodDays  int     `mapstructure:"grace_period_days"`
+}
+
+type DelinquencyConfig struct {
+	DefaultDPDThreshold int `mapstructure:"default_dpd_threshold"`
+}
+
+type TransactionConfig struct {
+	AnnualFlatRatePercent float64 `mapstructure:"annual_flat_rate_percent"`
+}
+
+type AffordabilityConfig struct {
+	MaxDTIPercent        float64 `mapstructure:"max_dti_percent"`
+	EstimateInterestRate float64 `mapstructure:"estimate_interest_rate"`
+	RejectAboveMax       bool    `mapstructure:"reject_above_max"`
+}
+
+type DisbursementConfig struct {
+	BatchFormat   string `mapstructure:"batch_format"`
+	SourceAccount string `mapstructure:"source_account"`
+}
+
+type DocumentConfig struct {
+	StorageDir          string   `mapstructure:"storage_dir"`
+	MaxSizeBytes        int64    `mapstructure:"max_size_bytes"`
+	AllowedContentTypes []string `mapstructure:"allowed_content_types"`
+	// RequiredRules add documents to the checklist of new applications
+	RequiredRules []DocumentRuleConfig `mapstructure:"required_rules"`
+}
+
+type DocumentRuleConfig struct {
+	Type             string   `mapstructure:"type"`
+	ProductCodes     []string `mapstructure:"product_codes"`
+	MinAmount        string   `mapstructure:"min_amount"`
+	MinMonthlyIncome string   `mapstructure:"min_monthly_income"`
+}
+
 func LoadConfig(path string) (*Config, error) {
 	viper.SetConfigFile(path)
 
@@ -69,6 +123,8 @@ func LoadConfig(path string) (*Config, error) {
 	viper.SetDefault("database.password", "root")
 	viper.SetDefault("database.name", "xyz_multifinance")
 	viper.SetDefault("database.ssl_mode", "disable")
+	viper.SetDefault("loan.max_open_applications", 1)
+	viper.SetDefault("loan.duplicate_application_window", "5m")
 
 	// Enable environment variable overrides
 	viper.AutomaticEnv()
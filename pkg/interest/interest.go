@@ -0,0 +1,205 @@
+// Package interest calculates installment schedules for the interest methods used in consumer financing.
+package interest
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+)
+
+// Method determines how interest is charged over the tenure of a loan
+type Method string
+
+const (
+	// Annuity charges interest on the declining balance with equal total payments
+	Annuity Method = "annuity"
+	// Flat charges interest on the original principal for every period
+	Flat Method = "flat"
+	// Effective charges interest on the declining balance with equal principal payments
+	Effective Method = "effective"
+)
+
+// ParseMethod validates a method name; an empty name yields Annuity
+func ParseMethod(name string) (Method, error) {
+	switch method := Method(name); method {
+	case "":
+		return Annuity, nil
+	case Annuity, Flat, Effective:
+		return method, nil
+	default:
+		return "", fmt.Errorf("unknown interest method %q", name)
+	}
+}
+
+// Period is one installment of a schedule. Amounts are in sen (1/100 Rupiah).
+type Period struct {
+	Number           int
+	Principal        int64
+	Interest         int64
+	Payment          int64
+	RemainingBalance int64
+}
+
+// Schedule is the installment plan of a loan and its cost
+type Schedule struct {
+	Method        Method
+	Periods       []Period
+	TotalInterest int64
+	// EffectiveAnnualRate is the compounded yearly cost of the schedule, in percent
+	EffectiveAnnualRate float64
+}
+
+// FirstPayment returns the payment of the first period, which is the largest for every method
+func (s *Schedule) FirstPayment() int64 {
+	if len(s.Periods) == 0 {
+		return 0
+	}
+	return s.Periods[0].Payment
+}
+
+// Calculate builds the schedule of principal sen repaid over tenureMonths at annualRate percent.
+// Every amount is rounded half away from zero to the sen and the residue is absorbed by the last period.
+func Calculate(method Method, principal int64, annualRate float64, tenureMonths int) (*Schedule, error) {
+	if principal <= 0 {
+		return nil, fmt.Errorf("principal must be greater than 0")
+	}
+	if annualRate < 0 {
+		return nil, fmt.Errorf("interest rate must not be negative")
+	}
+	if tenureMonths <= 0 {
+		return nil, fmt.Errorf("tenure must be greater than 0")
+	}
+
+	monthlyRate := monthlyRat(annualRate)
+	equalPrincipal := roundRat(big.NewRat(principal, int64(tenureMonths)))
+
+	var interestFor func(balance int64) int64
+	var principalFor func(interest int64) int64
+	switch method {
+	case Annuity:
+		payment := int64(math.Round(AnnuityPayment(float64(principal), annualRate, tenureMonths)))
+		interestFor = func(balance int64) int64 { return mulRat(balance, monthlyRate) }
+		principalFor = func(interest int64) int64 { return payment - interest }
+	case Flat:
+		flatInterest := mulRat(principal, monthlyRate)
+		interestFor = func(balance int64) int64 { return flatInterest }
+		principalFor = func(interest int64) int64 { return equalPrincipal }
+	case Effective:
+		interestFor = func(balance int64) int64 { return mulRat(balance, monthlyRate) }
+		principalFor = func(interest int64) int64 { return equalPrincipal }
+	default:
+		return nil, fmt.Errorf("unknown interest method %q", method)
+	}
+
+	schedule := &Schedule{Method: method, Periods: make([]Period, 0, tenureMonths)}
+	balance := principal
+	for n := 1; n <= tenureMonths; n++ {
+		interest := interestFor(balance)
+		principalPart := principalFor(interest)
+		if n == tenureMonths || principalPart > balance {
+			principalPart = balance
+		}
+		balance -= principalPart
+
+		schedule.Periods = append(schedule.Periods, Period{
+			Number:           n,
+			Principal:        principalPart,
+			Interest:         interest,
+			Payment:          principalPart + interest,
+			RemainingBalance: balance,
+		})
+		schedule.TotalInterest += interest
+	}
+
+	schedule.EffectiveAnnualRate = effectiveAnnualRate(principal, schedule.Periods)
+	return schedule, nil
+}
+
+// MonthlyInterest returns one month of interest on balance sen at annualRate percent,
+// rounded half away from zero to the sen
+func MonthlyInterest(balance int64, annualRate float64) int64 {
+	return mulRat(balance, monthlyRat(annualRate))
+}
+
+// AnnuityPayment returns the unrounded equal payment that amortizes principal over tenureMonths.
+// A 0% rate repays the principal in equal parts.
+func AnnuityPayment(principal, annualRate float64, tenureMonths int) float64 {
+	if tenureMonths <= 0 {
+		return 0
+	}
+
+	monthlyRate := annualRate / 12 / 100
+	if monthlyRate == 0 {
+		return principal / float64(tenureMonths)
+	}
+
+	// PMT = P * (r * (1 + r)^n) / ((1 + r)^n - 1)
+	factor := math.Pow(1+monthlyRate, float64(tenureMonths))
+	return principal * (monthlyRate * factor) / (factor - 1)
+}
+
+// effectiveAnnualRate finds the monthly rate at which the payments discount to the principal
+// and compounds it over a year, in percent
+func effectiveAnnualRate(principal int64, periods []Period) float64 {
+	presentValue := func(rate float64) float64 {
+		var pv float64
+		for _, p := range periods {
+			pv += float64(p.Payment) / math.Pow(1+rate, float64(p.Number))
+		}
+		return pv
+	}
+
+	// The present value falls as the rate rises, so bisect until it matches the principal
+	target := float64(principal)
+	low, high := 0.0, 1.0
+	if presentValue(low) <= target {
+		return 0
+	}
+	for i := 0; i < 100; i++ {
+		mid := (low + high) / 2
+		if presentValue(mid) > target {
+			low = mid
+		} else {
+			high = mid
+		}
+	}
+
+	monthly := (low + high) / 2
+	return math.Round((math.Pow(1+monthly, 12)-1)*100*10000) / 10000
+}
+
+// monthlyRat converts an annual rate in percent into the exact monthly fraction
+func monthlyRat(annualRate float64) *big.Rat {
+	rate := decimalRat(annualRate)
+	return rate.Quo(rate, big.NewRat(1200, 1))
+}
+
+// mulRat multiplies an amount by a rate, rounding half away from zero
+func mulRat(amount int64, rate *big.Rat) int64 {
+	return roundRat(new(big.Rat).Mul(big.NewRat(amount, 1), rate))
+}
+
+// decimalRat converts f to the exact decimal it prints as
+func decimalRat(f float64) *big.Rat {
+	rat, ok := new(big.Rat).SetString(strconv.FormatFloat(f, 'f', -1, 64))
+	if !ok {
+		return new(big.Rat)
+	}
+	return rat
+}
+
+// roundRat rounds r to the nearest integer, half away from zero
+func roundRat(r *big.Rat) int64 {
+	num := new(big.Int).Abs(r.Num())
+	den := r.Denom()
+
+	quotient, remainder := new(big.Int).QuoRem(num, den, new(big.Int))
+	if remainder.Mul(remainder, big.NewInt(2)).Cmp(den) >= 0 {
+		quotient.Add(quotient, big.NewInt(1))
+	}
+	if r.Sign() < 0 {
+		quotient.Neg(quotient)
+	}
+	return quotient.Int64()
+}
@@ -0,0 +1,100 @@
+package interest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCalculate(t *testing.T) {
+	t.Run("annuity", func(t *testing.T) {
+		schedule, err := Calculate(Annuity, 1200000000, 12, 12)
+		assert.NoError(t, err)
+
+		assert.Len(t, schedule.Periods, 12)
+		assert.Equal(t, int64(106618546), schedule.Periods[0].Payment)
+		assert.Equal(t, int64(12000000), schedule.Periods[0].Interest)
+		assert.Equal(t, int64(0), schedule.Periods[11].RemainingBalance)
+		assert.InDelta(t, 12.6825, schedule.EffectiveAnnualRate, 0.001)
+		assertPrincipalRepaid(t, schedule, 1200000000)
+	})
+
+	t.Run("flat", func(t *testing.T) {
+		schedule, err := Calculate(Flat, 1200000000, 12, 12)
+		assert.NoError(t, err)
+
+		for _, period := range schedule.Periods {
+			assert.Equal(t, int64(12000000), period.Interest)
+			assert.Equal(t, int64(100000000), period.Principal)
+			assert.Equal(t, int64(112000000), period.Payment)
+		}
+		assert.Equal(t, int64(144000000), schedule.TotalInterest)
+		// A flat rate costs roughly twice its nominal rate
+		assert.InDelta(t, 24.0, schedule.EffectiveAnnualRate, 0.5)
+		assertPrincipalRepaid(t, schedule, 1200000000)
+	})
+
+	t.Run("effective", func(t *testing.T) {
+		schedule, err := Calculate(Effective, 1200000000, 12, 12)
+		assert.NoError(t, err)
+
+		assert.Equal(t, int64(112000000), schedule.Periods[0].Payment)
+		assert.Equal(t, int64(101000000), schedule.Periods[11].Payment)
+		assert.Equal(t, int64(78000000), schedule.TotalInterest)
+		assert.InDelta(t, 12.6825, schedule.EffectiveAnnualRate, 0.001)
+		assertPrincipalRepaid(t, schedule, 1200000000)
+	})
+
+	t.Run("zero rate", func(t *testing.T) {
+		for _, method := range []Method{Annuity, Flat, Effective} {
+			schedule, err := Calculate(method, 1000000000, 0, 3)
+			assert.NoError(t, err)
+
+			assert.Equal(t, int64(0), schedule.TotalInterest)
+			assert.Equal(t, 0.0, schedule.EffectiveAnnualRate)
+			assert.Equal(t, int64(333333333), schedule.Periods[0].Payment)
+			assert.Equal(t, int64(333333334), schedule.Periods[2].Payment)
+			assertPrincipalRepaid(t, schedule, 1000000000)
+		}
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		_, err := Calculate(Annuity, 0, 12, 12)
+		assert.Error(t, err)
+		_, err = Calculate(Annuity, 100000000, -1, 12)
+		assert.Error(t, err)
+		_, err = Calculate(Annuity, 100000000, 12, 0)
+		assert.Error(t, err)
+		_, err = Calculate("balloon", 100000000, 12, 12)
+		assert.Error(t, err)
+	})
+}
+
+func TestMonthlyInterest(t *testing.T) {
+	// 0.1% of 1,235.00 is 1.235, which rounds half away from zero
+	assert.Equal(t, int64(124), MonthlyInterest(123500, 1.2))
+	assert.Equal(t, int64(123), MonthlyInterest(123455, 1.2))
+	assert.Equal(t, int64(0), MonthlyInterest(0, 12))
+}
+
+func TestParseMethod(t *testing.T) {
+	method, err := ParseMethod("")
+	assert.NoError(t, err)
+	assert.Equal(t, Annuity, method)
+
+	method, err = ParseMethod("flat")
+	assert.NoError(t, err)
+	assert.Equal(t, Flat, method)
+
+	_, err = ParseMethod("balloon")
+	assert.Error(t, err)
+}
+
+func assertPrincipalRepaid(t *testing.T, schedule *Schedule, principal int64) {
+	var total int64
+	for _, period := range schedule.Periods {
+		total += period.Principal
+		assert.Equal(t, period.Principal+period.Interest, period.Payment)
+	}
+	assert.Equal(t, principal, total)
+}
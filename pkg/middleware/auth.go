@@ -2,6 +2,7 @@ package middleware
 
 import (
 	"context"
+	"net/http"
 	"strings"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -48,6 +49,27 @@ func (i *AuthInterceptor) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
 	}
 }
 
+// HTTPMiddleware authenticates plain HTTP routes the same way the interceptor authenticates
+// gRPC calls, storing the token claims in the request context
+func (i *AuthInterceptor) HTTPMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authHeader := r.Header.Get("Authorization")
+		if !strings.HasPrefix(authHeader, "Bearer ") {
+			http.Error(w, "no authorization token provided", http.StatusUnauthorized)
+			return
+		}
+
+		claims, err := i.validateToken(strings.TrimPrefix(authHeader, "Bearer "))
+		if err != nil {
+			http.Error(w, "invalid token", http.StatusUnauthorized)
+			return
+		}
+
+		ctx := context.WithValue(r.Context(), "user_claims", claims)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
 func (i *AuthInterceptor) extractToken(ctx context.Context) (string, error) {
 	md, ok := metadata.FromIncomingContext(ctx)
 	if !ok {
@@ -0,0 +1,125 @@
+// Package nik validates Indonesian population identity numbers (Nomor Induk Kependudukan, the
+// number printed on a KTP) and decodes the region, birth date and gender encoded in them.
+//
+// An NIK has 16 digits: PPRRDD DDMMYY SSSS, being the province, regency/city and district
+// where it was issued, the holder's birth date, with 40 added to the day for women, and a
+// serial number.
+package nik
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Length is the number of digits of an NIK
+const Length = 16
+
+// femaleDayOffset is added to the day of birth of women
+const femaleDayOffset = 40
+
+// ErrInvalid is wrapped by every error returned for a malformed NIK
+var ErrInvalid = errors.New("invalid NIK")
+
+// Gender is the holder's gender as encoded in the NIK
+type Gender string
+
+const (
+	Male   Gender = "male"
+	Female Gender = "female"
+)
+
+// NIK is a decoded identity number
+type NIK struct {
+	Number       string
+	ProvinceCode string
+	Province     string
+	RegencyCode  string
+	Regency      string
+	DistrictCode string
+	BirthDate    time.Time
+	Gender       Gender
+	Serial       string
+}
+
+// Parse validates number and decodes it. Two-digit birth years are placed in the latest
+// century that does not put the birth date in the future.
+func Parse(number string) (*NIK, error) {
+	return parse(number, time.Now())
+}
+
+func parse(number string, now time.Time) (*NIK, error) {
+	if len(number) != Length {
+		return nil, fmt.Errorf("%w: must be %d digits", ErrInvalid, Length)
+	}
+	for i := 0; i < len(number); i++ {
+		if number[i] < '0' || number[i] > '9' {
+			return nil, fmt.Errorf("%w: must be %d digits", ErrInvalid, Length)
+		}
+	}
+
+	n := &NIK{
+		Number:       number,
+		ProvinceCode: number[0:2],
+		RegencyCode:  number[0:4],
+		DistrictCode: number[4:6],
+		Serial:       number[12:16],
+	}
+
+	var ok bool
+	if n.Province, ok = provinces()[n.ProvinceCode]; !ok {
+		return nil, fmt.Errorf("%w: unknown province code %s", ErrInvalid, n.ProvinceCode)
+	}
+	if n.Regency, ok = regencies()[n.RegencyCode]; !ok {
+		return nil, fmt.Errorf("%w: unknown regency code %s", ErrInvalid, n.RegencyCode)
+	}
+	if n.DistrictCode == "00" {
+		return nil, fmt.Errorf("%w: district code must not be 00", ErrInvalid)
+	}
+	if n.Serial == "0000" {
+		return nil, fmt.Errorf("%w: serial number must not be 0000", ErrInvalid)
+	}
+
+	day, month, year := digits(number[6:8]), digits(number[8:10]), digits(number[10:12])
+	n.Gender = Male
+	if day > femaleDayOffset {
+		n.Gender = Female
+		day -= femaleDayOffset
+	}
+
+	birthDate, err := birthDate(day, month, year, now)
+	if err != nil {
+		return nil, err
+	}
+	n.BirthDate = birthDate
+
+	return n, nil
+}
+
+// birthDate resolves a two-digit year and rejects dates that do not exist on the calendar
+func birthDate(day, month, year int, now time.Time) (time.Time, error) {
+	century := now.Year() / 100 * 100
+	date := time.Date(century+year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+	if date.Day() != day || int(date.Month()) != month {
+		return time.Time{}, fmt.Errorf("%w: birth date %02d-%02d-%02d does not exist", ErrInvalid, day, month, year)
+	}
+
+	if date.After(now) {
+		date = date.AddDate(-100, 0, 0)
+		// 29 February only exists in the earlier century if it was a leap year too
+		if date.Day() != day {
+			return time.Time{}, fmt.Errorf("%w: birth date %02d-%02d-%02d does not exist", ErrInvalid, day, month, year)
+		}
+	}
+
+	return date, nil
+}
+
+// digits converts a string of decimal digits, already validated, to an int
+func digits(s string) int {
+	v := 0
+	for i := 0; i < len(s); i++ {
+		v = v*10 + int(s[i]-'0')
+	}
+	return v
+}
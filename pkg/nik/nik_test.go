@@ -0,0 +1,77 @@
+package nik
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	now := time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		number    string
+		regency   string
+		birthDate time.Time
+		gender    Gender
+	}{
+		{"man born in the 1990s", "3174051708900001", "Kota Administrasi Jakarta Barat", time.Date(1990, 8, 17, 0, 0, 0, 0, time.UTC), Male},
+		{"woman has 40 added to the day", "3273015703950002", "Kota Bandung", time.Date(1995, 3, 17, 0, 0, 0, 0, time.UTC), Female},
+		{"born this century", "3578104101050003", "Kota Surabaya", time.Date(2005, 1, 1, 0, 0, 0, 0, time.UTC), Female},
+		{"year not reached yet this century", "1101013112260004", "Kabupaten Simeulue", time.Date(1926, 12, 31, 0, 0, 0, 0, time.UTC), Male},
+		{"leap day", "5171026902000005", "Kota Denpasar", time.Date(2000, 2, 29, 0, 0, 0, 0, time.UTC), Female},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n, err := parse(tt.number, now)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.regency, n.Regency)
+			assert.Equal(t, tt.birthDate, n.BirthDate)
+			assert.Equal(t, tt.gender, n.Gender)
+			assert.Equal(t, tt.number[12:], n.Serial)
+		})
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	now := time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		number string
+	}{
+		{"too short", "317405170890001"},
+		{"not digits", "31740517089O0001"},
+		{"unknown province", "2074051708900001"},
+		{"unknown regency", "3199051708900001"},
+		{"no district", "3174001708900001"},
+		{"no serial", "3174051708900000"},
+		{"day zero", "3174050008900001"},
+		{"day between the ranges", "3174053508900001"},
+		{"day past the female range", "3174057208900001"},
+		{"month thirteen", "3174051713900001"},
+		{"30 February", "3174053002900001"},
+		{"29 February of a common year", "3174052902010001"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parse(tt.number, now)
+
+			assert.True(t, errors.Is(err, ErrInvalid), "got %v", err)
+		})
+	}
+}
+
+func TestRegions(t *testing.T) {
+	assert.Len(t, provinces(), 38)
+	for code := range regencies() {
+		assert.Contains(t, provinces(), code[:2], "regency %s has no province", code)
+	}
+}
@@ -0,0 +1,56 @@
+package nik
+
+import (
+	_ "embed"
+	"encoding/csv"
+	"strings"
+	"sync"
+)
+
+// regionsCSV lists the province (2-digit) and regency/city (4-digit) codes of the Ministry of
+// Home Affairs. Codes of regions that were since split off into new provinces are kept, as
+// NIKs issued under them remain valid.
+//
+//go:embed regions.csv
+var regionsCSV string
+
+var (
+	loadRegions   sync.Once
+	provinceNames map[string]string
+	regencyNames  map[string]string
+)
+
+// provinces returns the province names by code
+func provinces() map[string]string {
+	loadRegions.Do(parseRegions)
+	return provinceNames
+}
+
+// regencies returns the regency and city names by code
+func regencies() map[string]string {
+	loadRegions.Do(parseRegions)
+	return regencyNames
+}
+
+// parseRegions fills the region tables from the embedded CSV; it panics on a malformed file,
+// which the package tests catch
+func parseRegions() {
+	records, err := csv.NewReader(strings.NewReader(regionsCSV)).ReadAll()
+	if err != nil {
+		panic("nik: malformed region table: " + err.Error())
+	}
+
+	provinceNames = make(map[string]string)
+	regencyNames = make(map[string]string)
+	for _, record := range records[1:] {
+		code, name := record[0], record[1]
+		switch len(code) {
+		case 2:
+			provinceNames[code] = name
+		case 4:
+			regencyNames[code] = name
+		default:
+			panic("nik: malformed region code " + code)
+		}
+	}
+}
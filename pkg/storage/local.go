@@ -0,0 +1,93 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// LocalStore is a BlobStore that keeps each object as a file below a root directory
+type LocalStore struct {
+	root string
+}
+
+// NewLocalStore creates a store rooted at dir, creating the directory if needed
+func NewLocalStore(dir string) (*LocalStore, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create storage directory: %v", err)
+	}
+	return &LocalStore{root: dir}, nil
+}
+
+// Put writes the object to a temporary file first so readers never see a partial object
+func (s *LocalStore) Put(ctx context.Context, key string, r io.Reader) error {
+	name, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(name), 0o750); err != nil {
+		return fmt.Errorf("failed to create object directory: %v", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(name), filepath.Base(name)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create object: %v", err)
+	}
+
+	_, err = io.Copy(tmp, r)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), name)
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to store object %s: %w", key, err)
+	}
+
+	return nil
+}
+
+// Open opens the file of the object
+func (s *LocalStore) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	name, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(name)
+	if os.IsNotExist(err) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open object %s: %v", key, err)
+	}
+	return f, nil
+}
+
+// Delete removes the file of the object
+func (s *LocalStore) Delete(ctx context.Context, key string) error {
+	name, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(name); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to delete object %s: %v", key, err)
+	}
+	return nil
+}
+
+// path maps a key to a file below the root, rejecting keys that would escape it
+func (s *LocalStore) path(key string) (string, error) {
+	clean := path.Clean(key)
+	if key == "" || clean != key || path.IsAbs(clean) || clean == ".." || strings.HasPrefix(clean, "../") || strings.Contains(clean, "\\") {
+		return "", fmt.Errorf("invalid object key %q", key)
+	}
+	return filepath.Join(s.root, filepath.FromSlash(clean)), nil
+}
@@ -0,0 +1,69 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalStore(t *testing.T) {
+	ctx := context.Background()
+	store, err := NewLocalStore(filepath.Join(t.TempDir(), "blobs"))
+	require.NoError(t, err)
+
+	require.NoError(t, store.Put(ctx, "documents/loan-1/abc", strings.NewReader("first")))
+	require.NoError(t, store.Put(ctx, "documents/loan-1/abc", strings.NewReader("second")))
+
+	r, err := store.Open(ctx, "documents/loan-1/abc")
+	require.NoError(t, err)
+	data, err := io.ReadAll(r)
+	r.Close()
+	require.NoError(t, err)
+	assert.Equal(t, "second", string(data))
+
+	require.NoError(t, store.Delete(ctx, "documents/loan-1/abc"))
+	require.NoError(t, store.Delete(ctx, "documents/loan-1/abc"))
+
+	_, err = store.Open(ctx, "documents/loan-1/abc")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestLocalStore_FailedPutLeavesNothing(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	store, err := NewLocalStore(dir)
+	require.NoError(t, err)
+
+	broken := io.MultiReader(strings.NewReader("partial"), errReader{})
+	assert.Error(t, store.Put(ctx, "documents/loan-1/abc", broken))
+
+	_, err = store.Open(ctx, "documents/loan-1/abc")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	entries, err := os.ReadDir(filepath.Join(dir, "documents", "loan-1"))
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestLocalStore_InvalidKey(t *testing.T) {
+	ctx := context.Background()
+	store, err := NewLocalStore(t.TempDir())
+	require.NoError(t, err)
+
+	for _, key := range []string{"", "../escape", "/etc/passwd", "documents/../../escape", "documents//abc", `documents\abc`} {
+		assert.Error(t, store.Put(ctx, key, strings.NewReader("data")), key)
+	}
+}
+
+type errReader struct{}
+
+func (errReader) Read([]byte) (int, error) {
+	return 0, errors.New("connection reset")
+}
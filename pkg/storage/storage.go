@@ -0,0 +1,25 @@
+// Package storage keeps uploaded files as opaque objects addressed by key.
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+// ErrNotFound is returned when no object is stored under a key
+var ErrNotFound = errors.New("object not found")
+
+// BlobStore stores and retrieves objects by key. Keys are slash-separated paths such as
+// "documents/<loan id>/<object id>".
+type BlobStore interface {
+	// Put stores everything read from r under key, replacing any existing object. Nothing is
+	// stored when reading r fails.
+	Put(ctx context.Context, key string, r io.Reader) error
+
+	// Open returns a reader for the object stored under key; the caller must close it
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+
+	// Delete removes the object stored under key; deleting a missing object is not an error
+	Delete(ctx context.Context, key string) error
+}
@@ -21,7 +21,7 @@ $cmd = "protoc",
        "--grpc-gateway_out=.",
        "--grpc-gateway_opt=module=github.com/edosulai/pt-xyz-multifinance",
        "--openapiv2_out=./proto/gen/openapiv2",
-       "proto/user.proto","proto/loan.proto"
+       "proto/user.proto","proto/loan.proto","proto/credit_limit.proto","proto/transaction.proto","proto/bank_account.proto","proto/admin_loan.proto"
 
 & $cmd[0] $cmd[1..($cmd.Length-1)]
 
@@ -30,37 +30,39 @@ func TestLoanRepository(t *testing.T) {
 		Address:       "Test Address",
 		KTPNumber:     "1234567890123456",
 		Status:        "active",
-		MonthlyIncome: 5000000,
+		MonthlyIncome: model.NewMoney(5000000),
 	}
 	createErr := userRepo.Create(context.Background(), user)
 	require.NoError(t, createErr, "Failed to create test user")
 
 	t.Run("CreateLoan", func(t *testing.T) {
 		loan := &model.Loan{
+			ProductCode:  "PERSONAL",
 			UserID:       user.ID,
-			Amount:       10000000,
+			Amount:       model.NewMoney(10000000),
 			TenureMonths: 12,
 			Purpose:      "Test purpose",
 			Status:       model.LoanStatusPending,
 			InterestRate: 10.5,
 		}
 
-		err := loanRepo.Create(context.Background(), loan)
+		err := loanRepo.Create(context.Background(), loan, model.ApplicationPolicy{})
 		assert.NoError(t, err)
 		assert.NotEmpty(t, loan.ID)
 	})
 
 	t.Run("GetByID", func(t *testing.T) {
 		loan := &model.Loan{
+			ProductCode:  "PERSONAL",
 			UserID:       user.ID,
-			Amount:       15000000,
+			Amount:       model.NewMoney(15000000),
 			TenureMonths: 24,
 			Purpose:      "Another test",
 			Status:       model.LoanStatusPending,
 			InterestRate: 11.0,
 		}
 
-		err := loanRepo.Create(context.Background(), loan)
+		err := loanRepo.Create(context.Background(), loan, model.ApplicationPolicy{})
 		require.NoError(t, err)
 		found, err := loanRepo.GetByID(context.Background(), loan.ID)
 		assert.NoError(t, err)
@@ -69,42 +71,126 @@ func TestLoanRepository(t *testing.T) {
 		assert.Equal(t, loan.Amount, found.Amount)
 	})
 
+	t.Run("GetByID not found", func(t *testing.T) {
+		found, err := loanRepo.GetByID(context.Background(), "00000000-0000-0000-0000-000000000000")
+		assert.NoError(t, err)
+		assert.Nil(t, found)
+	})
+
 	t.Run("UpdateLoan", func(t *testing.T) {
 		loan := &model.Loan{
+			ProductCode:  "PERSONAL",
 			UserID:       user.ID,
-			Amount:       20000000,
+			Amount:       model.NewMoney(20000000),
 			TenureMonths: 36,
 			Purpose:      "Test update",
-			Status:       model.LoanStatusPending,
+			Status:       model.LoanStatusInReview,
 			InterestRate: 12.0,
 		}
 
-		err := loanRepo.Create(context.Background(), loan)
+		err := loanRepo.Create(context.Background(), loan, model.ApplicationPolicy{})
 		require.NoError(t, err)
 
-		loan.Status = model.LoanStatusApproved
-		loan.MonthlyPayment = 650000
+		loan.Status = model.LoanStatusOffered
+		loan.MonthlyPayment = model.NewMoney(650000)
 
-		err = loanRepo.UpdateLoan(context.Background(), loan)
+		ctx := model.ContextWithActor(context.Background(), model.Actor{Role: model.ActorRoleAdmin})
+		err = loanRepo.UpdateLoan(ctx, loan, "application approved")
 		assert.NoError(t, err)
 		updated, err := loanRepo.GetByID(context.Background(), loan.ID)
 		assert.NoError(t, err)
-		assert.Equal(t, model.LoanStatusApproved, updated.Status)
-		assert.Equal(t, 650000.0, updated.MonthlyPayment)
+		assert.Equal(t, model.LoanStatusOffered, updated.Status)
+		assert.Equal(t, model.NewMoney(650000), updated.MonthlyPayment)
+
+		history, err := loanRepo.GetStatusHistory(context.Background(), loan.ID)
+		assert.NoError(t, err)
+		require.Len(t, history, 1)
+		assert.Equal(t, model.LoanStatusInReview, history[0].FromStatus)
+		assert.Equal(t, model.ActorRoleAdmin, history[0].ActorRole)
+	})
+
+	t.Run("AcceptOffer", func(t *testing.T) {
+		loan := &model.Loan{
+			ProductCode:  "PERSONAL",
+			UserID:       user.ID,
+			Amount:       model.NewMoney(20000000),
+			TenureMonths: 36,
+			Purpose:      "Test offer",
+			Status:       model.LoanStatusInReview,
+			InterestRate: 12.0,
+		}
+		require.NoError(t, loanRepo.Create(context.Background(), loan, model.ApplicationPolicy{}))
+
+		admin := model.ContextWithActor(context.Background(), model.Actor{Role: model.ActorRoleAdmin})
+		loan.Status = model.LoanStatusOffered
+		require.NoError(t, loanRepo.UpdateLoan(admin, loan, "application approved"))
+		offered, err := loanRepo.GetByID(context.Background(), loan.ID)
+		require.NoError(t, err)
+
+		terms, hash, err := offered.OfferTerms().Encode()
+		require.NoError(t, err)
+		acceptance := &model.LoanOfferAcceptance{
+			LoanID: loan.ID, UserID: user.ID, Terms: terms, TermsHash: hash,
+			AcceptedAt: time.Now(), IPAddress: "203.0.113.7", UserAgent: "XYZ Mobile/2.1",
+		}
+		customer := model.ContextWithActor(context.Background(), model.Actor{ID: user.ID, Role: model.ActorRoleCustomer})
+
+		stale := *acceptance
+		err = loanRepo.AcceptOffer(customer, &stale, offered.UpdatedAt.Add(-time.Second), "offer accepted")
+		assert.ErrorIs(t, err, repo.ErrLoanOfferChanged)
+
+		err = loanRepo.AcceptOffer(customer, acceptance, offered.UpdatedAt, "offer accepted")
+		require.NoError(t, err)
+		assert.NotEmpty(t, acceptance.ID)
+
+		accepted, err := loanRepo.GetByID(context.Background(), loan.ID)
+		require.NoError(t, err)
+		assert.Equal(t, model.LoanStatusApproved, accepted.Status)
+
+		stored, err := loanRepo.GetOfferAcceptance(context.Background(), loan.ID)
+		require.NoError(t, err)
+		require.NotNil(t, stored)
+		assert.Equal(t, hash, stored.TermsHash)
+		assert.Equal(t, "203.0.113.7", stored.IPAddress)
+		assert.Equal(t, string(terms), string(stored.Terms))
+		assert.True(t, stored.TermsIntact(), "stored terms must hash to terms_hash")
+	})
+
+	t.Run("UpdateLoan rejects illegal transition", func(t *testing.T) {
+		loan := &model.Loan{
+			ProductCode:  "PERSONAL",
+			UserID:       user.ID,
+			Amount:       model.NewMoney(20000000),
+			TenureMonths: 36,
+			Purpose:      "Test illegal transition",
+			Status:       model.LoanStatusPending,
+			InterestRate: 12.0,
+		}
+
+		err := loanRepo.Create(context.Background(), loan, model.ApplicationPolicy{})
+		require.NoError(t, err)
+
+		loan.Status = model.LoanStatusDisbursed
+
+		ctx := model.ContextWithActor(context.Background(), model.Actor{Role: model.ActorRoleAdmin})
+		err = loanRepo.UpdateLoan(ctx, loan, "skip approval")
+		var transitionErr *model.StatusTransitionError
+		assert.ErrorAs(t, err, &transitionErr)
 	})
 
 	t.Run("GetUserLoans", func(t *testing.T) {
 		// Create multiple loans for user
 		for i := 0; i < 5; i++ {
 			loan := &model.Loan{
+				ProductCode:  "PERSONAL",
 				UserID:       user.ID,
-				Amount:       1000000 * float64(i+1),
+				Amount:       model.NewMoney(1000000 * int64(i+1)),
 				TenureMonths: 12,
 				Purpose:      "Test pagination",
 				Status:       model.LoanStatusPending,
 				InterestRate: 10.0,
 			}
-			err := loanRepo.Create(context.Background(), loan)
+			err := loanRepo.Create(context.Background(), loan, model.ApplicationPolicy{})
 			require.NoError(t, err)
 		}
 
@@ -114,17 +200,42 @@ func TestLoanRepository(t *testing.T) {
 		assert.Len(t, loans, 3)
 	})
 
+	t.Run("SearchLoans", func(t *testing.T) {
+		filter := model.LoanSearchFilter{
+			UserID:    user.ID,
+			Statuses:  []model.LoanStatus{model.LoanStatusPending},
+			MinAmount: model.NewMoney(2000000),
+			MaxAmount: model.NewMoney(4000000),
+		}
+		sort := model.LoanSort{Field: model.LoanSortAmount, Descending: true}
+
+		first, err := loanRepo.SearchLoans(context.Background(), filter, sort, nil, 2)
+		require.NoError(t, err)
+		require.Len(t, first, 2)
+		assert.True(t, first[0].Amount >= first[1].Amount)
+
+		key := first[1].SearchKey()
+		rest, err := loanRepo.SearchLoans(context.Background(), filter, sort, &key, 10)
+		require.NoError(t, err)
+		for _, loan := range rest {
+			assert.True(t, loan.Amount <= first[1].Amount)
+			assert.NotEqual(t, first[0].ID, loan.ID)
+			assert.NotEqual(t, first[1].ID, loan.ID)
+		}
+	})
+
 	t.Run("AddDocument", func(t *testing.T) {
 		loan := &model.Loan{
+			ProductCode:  "PERSONAL",
 			UserID:       user.ID,
-			Amount:       25000000,
+			Amount:       model.NewMoney(25000000),
 			TenureMonths: 24,
 			Purpose:      "Test with document",
 			Status:       model.LoanStatusPending,
 			InterestRate: 10.5,
 		}
 
-		err := loanRepo.Create(context.Background(), loan)
+		err := loanRepo.Create(context.Background(), loan, model.ApplicationPolicy{})
 		require.NoError(t, err)
 
 		doc := &model.Document{
@@ -146,4 +257,45 @@ func TestLoanRepository(t *testing.T) {
 		assert.Len(t, foundLoan.Documents, 1)
 		assert.Equal(t, doc.ID, foundLoan.Documents[0].ID)
 	})
+
+	t.Run("ApplicationPolicy", func(t *testing.T) {
+		applicant := &model.User{
+			Username:      "applicant",
+			Email:         "applicant@example.com",
+			Password:      "hashedpassword",
+			FullName:      "Applicant",
+			PhoneNumber:   "+6281234567891",
+			Address:       "Test Address",
+			KTPNumber:     "3174051708900002",
+			Status:        "active",
+			MonthlyIncome: model.NewMoney(5000000),
+		}
+		require.NoError(t, userRepo.Create(context.Background(), applicant))
+		policy := model.ApplicationPolicy{MaxOpenApplications: 2, DuplicateWindow: time.Minute}
+		newLoan := func(amount int64) *model.Loan {
+			return &model.Loan{
+				ProductCode:  "PERSONAL",
+				UserID:       applicant.ID,
+				Amount:       model.NewMoney(amount),
+				TenureMonths: 12,
+				Purpose:      "Test policy",
+				Status:       model.LoanStatusPending,
+				InterestRate: 10.0,
+			}
+		}
+
+		first := newLoan(1000000)
+		require.NoError(t, loanRepo.Create(context.Background(), first, policy))
+
+		err := loanRepo.Create(context.Background(), newLoan(1000000), policy)
+		var duplicateErr *model.DuplicateApplicationError
+		require.ErrorAs(t, err, &duplicateErr)
+		assert.Equal(t, first.ID, duplicateErr.LoanID)
+
+		require.NoError(t, loanRepo.Create(context.Background(), newLoan(2000000), policy))
+
+		err = loanRepo.Create(context.Background(), newLoan(3000000), policy)
+		var limitErr *model.OpenApplicationLimitError
+		assert.ErrorAs(t, err, &limitErr)
+	})
 }
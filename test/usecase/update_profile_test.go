@@ -61,6 +61,7 @@ func TestUpdateProfile(t *testing.T) {
 			ID:        "1",
 			Username:  "testuser",
 			Password:  "oldhash",
+			KTPNumber: "3174051708900001",
 			CreatedAt: time.Now().Add(-24 * time.Hour),
 		}
 
@@ -120,6 +121,7 @@ func TestUpdateProfile(t *testing.T) {
 			Username:  "testuser",
 			Email:     "old@example.com",
 			Password:  "oldhash",
+			KTPNumber: "3174051708900001",
 			CreatedAt: time.Now().Add(-24 * time.Hour),
 		}
 